@@ -19,6 +19,18 @@ grpc_method!(
     (),
     bool
 );
+grpc_method!(
+    METHOD_WAIT_READY,
+    "/oasis-core.NodeController/WaitReady",
+    (),
+    ()
+);
+grpc_method!(
+    METHOD_IS_READY,
+    "/oasis-core.NodeController/IsReady",
+    (),
+    bool
+);
 
 /// A node controller gRPC service client.
 #[derive(Clone)]
@@ -41,12 +53,24 @@ impl NodeControllerClient {
     }
 
     /// Wait for the node to finish syncing.
+    #[deprecated(note = "use wait_ready instead")]
     pub fn wait_sync(&self, opt: CallOption) -> Result<ClientUnaryReceiver<()>> {
         self.client.unary_call_async(&METHOD_WAIT_SYNC, &(), opt)
     }
 
     /// Check whether the node has finished syncing.
+    #[deprecated(note = "use is_ready instead")]
     pub fn is_synced(&self, opt: CallOption) -> Result<ClientUnaryReceiver<bool>> {
         self.client.unary_call_async(&METHOD_IS_SYNCED, &(), opt)
     }
+
+    /// Wait for all node services to become ready.
+    pub fn wait_ready(&self, opt: CallOption) -> Result<ClientUnaryReceiver<()>> {
+        self.client.unary_call_async(&METHOD_WAIT_READY, &(), opt)
+    }
+
+    /// Check whether all node services are ready.
+    pub fn is_ready(&self, opt: CallOption) -> Result<ClientUnaryReceiver<bool>> {
+        self.client.unary_call_async(&METHOD_IS_READY, &(), opt)
+    }
 }
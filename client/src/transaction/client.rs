@@ -103,6 +103,7 @@ impl TxnClient {
     }
 
     /// Wait for the node to finish syncing.
+    #[deprecated(note = "use wait_ready instead")]
     pub fn wait_sync(&self) -> BoxFuture<()> {
         let (span, options) = self.prepare_options("TxnClient::wait_sync");
 
@@ -119,6 +120,7 @@ impl TxnClient {
     }
 
     /// Check if the node is finished syncing.
+    #[deprecated(note = "use is_ready instead")]
     pub fn is_synced(&self) -> BoxFuture<bool> {
         let (span, options) = self.prepare_options("TxnClient::is_synced");
 
@@ -134,6 +136,38 @@ impl TxnClient {
         result
     }
 
+    /// Wait for all node services to become ready.
+    pub fn wait_ready(&self) -> BoxFuture<()> {
+        let (span, options) = self.prepare_options("TxnClient::wait_ready");
+
+        let result: BoxFuture<()> = match self.node_controller.wait_ready(options) {
+            Ok(resp) => Box::new(
+                resp.map_err(|error| TxnClientError::CallFailed(format!("{}", error)).into()),
+            ),
+            Err(error) => Box::new(future::err(
+                TxnClientError::CallFailed(format!("{}", error)).into(),
+            )),
+        };
+        drop(span);
+        result
+    }
+
+    /// Check if all node services are ready.
+    pub fn is_ready(&self) -> BoxFuture<bool> {
+        let (span, options) = self.prepare_options("TxnClient::is_ready");
+
+        let result: BoxFuture<bool> = match self.node_controller.is_ready(options) {
+            Ok(resp) => Box::new(
+                resp.map_err(|error| TxnClientError::CallFailed(format!("{}", error)).into()),
+            ),
+            Err(error) => Box::new(future::err(
+                TxnClientError::CallFailed(format!("{}", error)).into(),
+            )),
+        };
+        drop(span);
+        result
+    }
+
     /// Retrieve the latest block snapshot.
     pub fn get_latest_block(&self) -> BoxFuture<BlockSnapshot> {
         let block_watcher = self.block_watcher.clone();
@@ -2,10 +2,18 @@
 package api
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
 	"fmt"
 
+	"golang.org/x/crypto/sha3"
+
+	"github.com/oasislabs/oasis-core/go/common/crypto/hash"
+	"github.com/oasislabs/oasis-core/go/common/crypto/signature"
 	"github.com/oasislabs/oasis-core/go/common/errors"
+	"github.com/oasislabs/oasis-core/go/consensus/api/transaction"
+	epochtime "github.com/oasislabs/oasis-core/go/epochtime/api"
 	"github.com/oasislabs/oasis-core/go/oasis-node/cmd/common/flags"
 )
 
@@ -15,11 +23,53 @@ const (
 
 	// BeaconSize is the size of the beacon in bytes.
 	BeaconSize = 32
+
+	// SecretSize is the size of a commit-reveal secret in bytes.
+	SecretSize = 32
 )
 
-// ErrBeaconNotAvailable is the error returned when a beacon is not
-// available for the requested height for any reason.
-var ErrBeaconNotAvailable = errors.New(ModuleName, 1, "beacon: random beacon not available")
+var (
+	// ErrBeaconNotAvailable is the error returned when a beacon is not
+	// available for the requested height for any reason.
+	ErrBeaconNotAvailable = errors.New(ModuleName, 1, "beacon: random beacon not available")
+
+	// ErrInvalidArgument is the error returned on malformed argument(s).
+	ErrInvalidArgument = errors.New(ModuleName, 2, "beacon: invalid argument")
+
+	// ErrCommitRevealDisabled is the error returned when a commit-reveal
+	// transaction is submitted while the commit-reveal beacon is disabled.
+	ErrCommitRevealDisabled = errors.New(ModuleName, 3, "beacon: commit-reveal beacon disabled")
+
+	// ErrInvalidEpoch is the error returned when a commit or a reveal is
+	// submitted for the wrong epoch.
+	ErrInvalidEpoch = errors.New(ModuleName, 4, "beacon: invalid epoch")
+
+	// ErrNotValidator is the error returned when a commitment is submitted
+	// by a node that is not a registered validator.
+	ErrNotValidator = errors.New(ModuleName, 5, "beacon: signer is not a validator node")
+
+	// ErrInvalidReveal is the error returned when a revealed secret does not
+	// match the commitment or when there is no commitment.
+	ErrInvalidReveal = errors.New(ModuleName, 6, "beacon: invalid reveal")
+
+	// MethodCommit is the method name for commit-reveal commitments.
+	MethodCommit = transaction.NewMethodName(ModuleName, "Commit", Commit{})
+	// MethodReveal is the method name for commit-reveal reveals.
+	MethodReveal = transaction.NewMethodName(ModuleName, "Reveal", Reveal{})
+
+	// Methods is the list of all methods supported by the beacon backend.
+	Methods = []transaction.MethodName{
+		MethodCommit,
+		MethodReveal,
+	}
+
+	// SecretSignatureContext is the context used for deriving commit-reveal
+	// secrets from node signatures.
+	SecretSignatureContext = signature.NewContext("oasis-core/beacon: commit-reveal secret", signature.WithChainSeparation())
+
+	commitRevealEntropyCtx = []byte("EkB-cmrv")
+	failedRoundEntropyCtx  = []byte("EkB-cmrf")
+)
 
 // Backend is a random beacon implementation.
 type Backend interface {
@@ -28,10 +78,193 @@ type Backend interface {
 	// beacon for latest finalized block.
 	GetBeacon(context.Context, int64) ([]byte, error)
 
+	// GetBeaconEvidence gets the evidence used to derive the beacon for the
+	// provided block height.
+	GetBeaconEvidence(context.Context, int64) (*Evidence, error)
+
 	// StateToGenesis returns the genesis state at specified block height.
 	StateToGenesis(context.Context, int64) (*Genesis, error)
 }
 
+// Commit is a commit-reveal beacon commitment.
+type Commit struct {
+	// Epoch is the epoch in which the commitment is made.
+	Epoch epochtime.EpochTime `json:"epoch"`
+	// Commitment is the commitment to the secret, see NewCommitment.
+	Commitment hash.Hash `json:"commitment"`
+}
+
+// Reveal is a commit-reveal beacon reveal.
+type Reveal struct {
+	// Epoch is the epoch in which the commitment was made.
+	Epoch epochtime.EpochTime `json:"epoch"`
+	// Secret is the committed secret.
+	Secret []byte `json:"secret"`
+}
+
+// NewCommitTx creates a new commit-reveal commitment transaction.
+func NewCommitTx(nonce uint64, fee *transaction.Fee, commit *Commit) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodCommit, commit)
+}
+
+// NewRevealTx creates a new commit-reveal reveal transaction.
+func NewRevealTx(nonce uint64, fee *transaction.Fee, reveal *Reveal) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodReveal, reveal)
+}
+
+type commitmentPreimage struct {
+	NodeID signature.PublicKey `json:"node_id"`
+	Epoch  epochtime.EpochTime `json:"epoch"`
+	Secret []byte              `json:"secret"`
+}
+
+// NewCommitment computes the commitment of the given node to a secret in the
+// given epoch.
+func NewCommitment(nodeID signature.PublicKey, epoch epochtime.EpochTime, secret []byte) hash.Hash {
+	return hash.NewFrom(&commitmentPreimage{
+		NodeID: nodeID,
+		Epoch:  epoch,
+		Secret: secret,
+	})
+}
+
+// DeriveSecret derives the commit-reveal secret of a node for the given epoch.
+//
+// As signatures are deterministic the secret is reproducible by the node
+// (e.g., after a restart) while being unpredictable for anyone else until it
+// is revealed.
+func DeriveSecret(signer signature.Signer, epoch epochtime.EpochTime) ([]byte, error) {
+	var tmp [8]byte
+	binary.LittleEndian.PutUint64(tmp[:], uint64(epoch))
+
+	sig, err := signer.ContextSign(SecretSignatureContext, tmp[:])
+	if err != nil {
+		return nil, fmt.Errorf("beacon: failed to derive secret: %w", err)
+	}
+	secret := sha3.Sum256(sig)
+	return secret[:], nil
+}
+
+// EntropySource is the source of the entropy a beacon was derived from.
+type EntropySource uint8
+
+const (
+	// EntropySourceBlock is a beacon derived from block data.
+	EntropySourceBlock EntropySource = 0
+	// EntropySourceDebug is an UNSAFE deterministic beacon.
+	EntropySourceDebug EntropySource = 1
+	// EntropySourceCommitReveal is a beacon derived from commit-reveal
+	// secrets.
+	EntropySourceCommitReveal EntropySource = 2
+)
+
+// String returns a string representation of an EntropySource.
+func (s EntropySource) String() string {
+	switch s {
+	case EntropySourceBlock:
+		return "block"
+	case EntropySourceDebug:
+		return "debug"
+	case EntropySourceCommitReveal:
+		return "commit-reveal"
+	default:
+		return "[unknown entropy source]"
+	}
+}
+
+// RevealEvidence is a revealed commit-reveal secret.
+type RevealEvidence struct {
+	// NodeID is the identifier of the node that made the commitment.
+	NodeID signature.PublicKey `json:"node_id"`
+	// Commitment is the commitment made by the node.
+	Commitment hash.Hash `json:"commitment"`
+	// Secret is the revealed secret.
+	Secret []byte `json:"secret"`
+}
+
+// Evidence is the evidence used to derive a beacon.
+type Evidence struct {
+	// Epoch is the epoch the beacon was generated for.
+	Epoch epochtime.EpochTime `json:"epoch"`
+	// Source is the source of the beacon entropy.
+	Source EntropySource `json:"source"`
+
+	// Round is the epoch in which the commitments used to derive a
+	// commit-reveal beacon were made.
+	Round epochtime.EpochTime `json:"round,omitempty"`
+	// PreviousBeacon is the beacon that the commit-reveal beacon was chained
+	// to, if any.
+	PreviousBeacon []byte `json:"previous_beacon,omitempty"`
+	// Reveals are the revealed secrets, sorted by node identifier.
+	Reveals []RevealEvidence `json:"reveals,omitempty"`
+	// NonRevealers are the nodes that committed in the round but failed to
+	// reveal their secret.
+	NonRevealers []signature.PublicKey `json:"non_revealers,omitempty"`
+	// Failed is true iff the commit-reveal round failed due to not enough
+	// secrets being revealed, in which case the beacon is derived from the
+	// previous beacon only.
+	Failed bool `json:"failed,omitempty"`
+}
+
+// Verify verifies that the given beacon was derived from the commit-reveal
+// evidence. Beacons derived from other entropy sources can not be verified.
+func (e *Evidence) Verify(beacon []byte) error {
+	if e.Source != EntropySourceCommitReveal {
+		return fmt.Errorf("beacon: evidence source %s is not verifiable", e.Source)
+	}
+	if e.Failed {
+		if b := DeriveFailedRoundBeacon(e.Epoch, e.PreviousBeacon); !bytes.Equal(b, beacon) {
+			return fmt.Errorf("beacon: beacon does not match failed round evidence")
+		}
+		return nil
+	}
+	for i, r := range e.Reveals {
+		if i > 0 && bytes.Compare(e.Reveals[i-1].NodeID[:], r.NodeID[:]) >= 0 {
+			return fmt.Errorf("beacon: evidence reveals not sorted or not unique")
+		}
+		if cm := NewCommitment(r.NodeID, e.Round, r.Secret); !cm.Equal(&r.Commitment) {
+			return fmt.Errorf("beacon: evidence reveal for node %s does not match commitment", r.NodeID)
+		}
+	}
+	if b := DeriveCommitRevealBeacon(e.Epoch, e.PreviousBeacon, e.Reveals); !bytes.Equal(b, beacon) {
+		return fmt.Errorf("beacon: beacon does not match evidence")
+	}
+	return nil
+}
+
+// DeriveCommitRevealBeacon derives a beacon from the previous beacon and the
+// revealed secrets, which must be sorted by node identifier.
+func DeriveCommitRevealBeacon(epoch epochtime.EpochTime, previousBeacon []byte, reveals []RevealEvidence) []byte {
+	var tmp [8]byte
+	binary.LittleEndian.PutUint64(tmp[:], uint64(epoch))
+
+	h := sha3.New256()
+	_, _ = h.Write(commitRevealEntropyCtx)
+	_, _ = h.Write(previousBeacon)
+	_, _ = h.Write(tmp[:])
+	for _, r := range reveals {
+		_, _ = h.Write(r.NodeID[:])
+		_, _ = h.Write(r.Secret)
+	}
+	return h.Sum(nil)
+}
+
+// DeriveFailedRoundBeacon derives a beacon for an epoch in which the
+// commit-reveal round failed from the previous beacon.
+//
+// The derived beacon is predictable, but it ensures that committees are still
+// elected when nodes withhold their reveals.
+func DeriveFailedRoundBeacon(epoch epochtime.EpochTime, previousBeacon []byte) []byte {
+	var tmp [8]byte
+	binary.LittleEndian.PutUint64(tmp[:], uint64(epoch))
+
+	h := sha3.New256()
+	_, _ = h.Write(failedRoundEntropyCtx)
+	_, _ = h.Write(previousBeacon)
+	_, _ = h.Write(tmp[:])
+	return h.Sum(nil)
+}
+
 // Genesis is the beacon genesis state.
 type Genesis struct {
 	// Parameters are the beacon consensus parameters.
@@ -42,6 +275,41 @@ type Genesis struct {
 type ConsensusParameters struct {
 	// DebugDeterministic is true iff the output should be deterministic.
 	DebugDeterministic bool `json:"debug_deterministic"`
+
+	// CommitReveal are the commit-reveal beacon parameters. If not set, the
+	// beacon is derived from block data.
+	CommitReveal *CommitRevealParameters `json:"commit_reveal,omitempty"`
+
+	// GasCosts are the beacon transaction gas costs.
+	GasCosts transaction.Costs `json:"gas_costs,omitempty"`
+}
+
+// CommitRevealParameters are the commit-reveal beacon parameters.
+//
+// Validators commit to a secret in one epoch and reveal it in the next. At the
+// end of the reveal epoch the beacon for the following epoch is derived from
+// all valid reveals. Validators that committed but failed to reveal are frozen
+// for at least one epoch and slashed using the staking.SlashBeaconNonReveal
+// slashing procedure, if configured.
+type CommitRevealParameters struct {
+	// MinParticipants is the minimum number of reveals required for the
+	// beacon to be derived from commit-reveal secrets. With fewer reveals
+	// the round fails and the beacon is derived from the previous beacon.
+	MinParticipants uint64 `json:"min_participants,omitempty"`
+}
+
+const (
+	// GasOpCommit is the gas operation identifier for commit-reveal
+	// commitments.
+	GasOpCommit transaction.Op = "commit"
+	// GasOpReveal is the gas operation identifier for commit-reveal reveals.
+	GasOpReveal transaction.Op = "reveal"
+)
+
+// DefaultGasCosts are the "default" gas costs for operations.
+var DefaultGasCosts = transaction.Costs{
+	GasOpCommit: 1000,
+	GasOpReveal: 1000,
 }
 
 // SanityCheck does basic sanity checking on the genesis state.
@@ -51,5 +319,13 @@ func (g *Genesis) SanityCheck() error {
 		return fmt.Errorf("beacon: sanity check failed: one or more unsafe debug flags set")
 	}
 
+	if unsafeFlags && g.Parameters.CommitReveal != nil {
+		return fmt.Errorf("beacon: sanity check failed: commit-reveal beacon can not be deterministic")
+	}
+
+	if g.Parameters.CommitReveal != nil && g.Parameters.CommitReveal.MinParticipants == 0 {
+		return fmt.Errorf("beacon: sanity check failed: commit-reveal beacon requires at least one participant")
+	}
+
 	return nil
 }
@@ -28,4 +28,10 @@ func BeaconImplementationTests(t *testing.T, backend api.Backend, epochtime epoc
 	require.NoError(err, "GetBeacon")
 	require.Len(newBeacon, api.BeaconSize, "GetBeacon - length")
 	require.NotEqual(beacon, newBeacon, "After epoch transition, new beacon should be generated.")
+
+	ev, err := backend.GetBeaconEvidence(context.Background(), consensus.HeightLatest)
+	require.NoError(err, "GetBeaconEvidence")
+	if ev.Source == api.EntropySourceCommitReveal {
+		require.NoError(ev.Verify(newBeacon), "GetBeaconEvidence - verify")
+	}
 }
@@ -8,8 +8,13 @@ import (
 	"sync"
 )
 
-// UnknownModule is the module name used when the module is unknown.
-const UnknownModule = "unknown"
+const (
+	// UnknownModule is the module name used when the module is unknown.
+	UnknownModule = "unknown"
+
+	// CodeNoError is the reserved "no error" code.
+	CodeNoError = 0
+)
 
 var errUnknownError = New(UnknownModule, 1, "unknown error")
 
@@ -40,7 +45,7 @@ func (e *codedError) Error() string {
 // The error code must not be equal to zero as that value is reserved
 // to mean "no error".
 func New(module string, code uint32, msg string) error {
-	if code == 0 {
+	if code == CodeNoError {
 		panic(fmt.Errorf("error: code cannot be zero"))
 	}
 
@@ -0,0 +1,419 @@
+// Package gateway implements a JSON/HTTP gateway for gRPC services.
+//
+// The gateway exposes the methods of registered gRPC services that have been
+// explicitly marked as read-only (see `MethodDesc.WithReadOnly`) as HTTP
+// endpoints where requests and responses are encoded as JSON instead of
+// CBOR. Endpoints use the full gRPC method name as the path (for example
+// `/oasis-core.Consensus/GetEpoch`) and accept both GET and POST requests
+// with the (optional) request encoded as JSON in the request body.
+//
+// Streaming methods (e.g., `Watch*`) are exposed as server-sent event
+// streams where each event contains a single JSON-encoded message.
+//
+// Calls go through the same logging, metrics and (optional) per-client rate
+// limiting interceptors as gRPC calls, with clients identified by their
+// remote address. As HTTP clients cannot be authenticated, access controlled
+// methods are always rejected.
+//
+// Errors are returned as HTTP 500 unless a different status code has been
+// registered for the error's module and code via `RegisterErrorStatus`.
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/oasislabs/oasis-core/go/common/errors"
+	cmnGrpc "github.com/oasislabs/oasis-core/go/common/grpc"
+	"github.com/oasislabs/oasis-core/go/common/logging"
+)
+
+// maxRequestSize is the maximum size of a request body.
+const maxRequestSize = 1 << 20
+
+var _ cmnGrpc.ServiceRegistrar = (*Gateway)(nil)
+
+type unaryMethod struct {
+	impl    interface{}
+	handler func(interface{}, context.Context, func(interface{}) error, grpc.UnaryServerInterceptor) (interface{}, error)
+}
+
+type streamMethod struct {
+	impl    interface{}
+	handler grpc.StreamHandler
+}
+
+// errorResponse is the JSON-encoded error response.
+type errorResponse struct {
+	Module  string `json:"module,omitempty"`
+	Code    uint32 `json:"code,omitempty"`
+	Message string `json:"message"`
+}
+
+type errorCode struct {
+	module string
+	code   uint32
+}
+
+// Config is the JSON/HTTP gateway configuration.
+type Config struct {
+	// RateLimit is the optional per-client rate limiting configuration.
+	RateLimit *cmnGrpc.RateLimitConfig
+	// MaxStreams is the maximum number of concurrently open event streams.
+	// Zero means no limit.
+	MaxStreams int
+}
+
+// Gateway is a JSON/HTTP gateway for gRPC services.
+type Gateway struct {
+	sync.RWMutex
+
+	unary       map[string]*unaryMethod
+	streams     map[string]*streamMethod
+	errorStatus map[errorCode]int
+
+	unaryInterceptor  grpc.UnaryServerInterceptor
+	streamInterceptor grpc.StreamServerInterceptor
+
+	maxStreams  int
+	openStreams int
+
+	logger *logging.Logger
+}
+
+// RegisterService registers the read-only methods of a gRPC service with
+// the gateway.
+//
+// Only methods marked as read-only are exposed.
+func (g *Gateway) RegisterService(desc *grpc.ServiceDesc, impl interface{}) {
+	g.Lock()
+	defer g.Unlock()
+
+	for _, md := range desc.Methods {
+		name := fmt.Sprintf("/%s/%s", desc.ServiceName, md.MethodName)
+		if !isReadOnly(name) {
+			continue
+		}
+		g.unary[name] = &unaryMethod{
+			impl:    impl,
+			handler: md.Handler,
+		}
+	}
+	for _, sd := range desc.Streams {
+		name := fmt.Sprintf("/%s/%s", desc.ServiceName, sd.StreamName)
+		if !isReadOnly(name) || sd.ClientStreams {
+			continue
+		}
+		g.streams[name] = &streamMethod{
+			impl:    impl,
+			handler: sd.Handler,
+		}
+	}
+
+	g.logger.Debug("registered service",
+		"service", desc.ServiceName,
+	)
+}
+
+// RegisterErrorStatus registers the HTTP status code that should be returned
+// for the given errors, matched by their module and code.
+func (g *Gateway) RegisterErrorStatus(status int, errs ...error) {
+	g.Lock()
+	defer g.Unlock()
+
+	for _, err := range errs {
+		module, code := errors.Code(err)
+		g.errorStatus[errorCode{module, code}] = status
+	}
+}
+
+// Methods returns the full names of all methods exposed by the gateway.
+func (g *Gateway) Methods() []string {
+	g.RLock()
+	defer g.RUnlock()
+
+	var methods []string
+	for name := range g.unary {
+		methods = append(methods, name)
+	}
+	for name := range g.streams {
+		methods = append(methods, name)
+	}
+	return methods
+}
+
+// ServeHTTP implements http.Handler.
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method not allowed"))
+		return
+	}
+
+	g.RLock()
+	um := g.unary[r.URL.Path]
+	sm := g.streams[r.URL.Path]
+	g.RUnlock()
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestSize))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("failed to read request: %w", err))
+		return
+	}
+
+	// Identify the client by its remote address, so that the interceptors
+	// (e.g., the rate limiter) handle it like any other unauthenticated
+	// client.
+	ctx := r.Context()
+	if addr, err := net.ResolveTCPAddr("tcp", r.RemoteAddr); err == nil {
+		ctx = peer.NewContext(ctx, &peer.Peer{Addr: addr})
+	}
+
+	switch {
+	case um != nil:
+		g.serveUnary(ctx, w, r, um, body)
+	case sm != nil:
+		g.serveStream(ctx, w, r, sm, body)
+	default:
+		writeError(w, http.StatusNotFound, fmt.Errorf("method not found"))
+	}
+}
+
+func (g *Gateway) serveUnary(ctx context.Context, w http.ResponseWriter, r *http.Request, m *unaryMethod, body []byte) {
+	var decodeErr error
+	dec := func(v interface{}) error {
+		decodeErr = decodeRequest(body, v)
+		return decodeErr
+	}
+
+	rsp, err := m.handler(m.impl, ctx, dec, g.unaryInterceptor)
+	switch {
+	case decodeErr != nil:
+		writeError(w, http.StatusBadRequest, decodeErr)
+		return
+	case err != nil:
+		writeError(w, g.errorStatusFor(err), err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err = json.NewEncoder(w).Encode(rsp); err != nil {
+		g.logger.Error("failed to encode response",
+			"err", err,
+			"method", r.URL.Path,
+		)
+	}
+}
+
+func (g *Gateway) serveStream(ctx context.Context, w http.ResponseWriter, r *http.Request, m *streamMethod, body []byte) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("streaming not supported"))
+		return
+	}
+
+	if !g.acquireStream() {
+		writeError(w, http.StatusServiceUnavailable, fmt.Errorf("too many open streams"))
+		return
+	}
+	defer g.releaseStream()
+
+	stream := &sseStream{
+		ctx:     ctx,
+		w:       w,
+		flusher: flusher,
+		body:    body,
+	}
+	info := &grpc.StreamServerInfo{
+		FullMethod:     r.URL.Path,
+		IsServerStream: true,
+	}
+	err := g.streamInterceptor(m.impl, stream, info, m.handler)
+	switch {
+	case stream.decodeErr != nil:
+		writeError(w, http.StatusBadRequest, stream.decodeErr)
+	case err == nil, err == context.Canceled:
+	case !stream.started:
+		writeError(w, g.errorStatusFor(err), err)
+	default:
+		g.logger.Error("stream terminated with error",
+			"err", err,
+			"method", r.URL.Path,
+		)
+	}
+}
+
+func (g *Gateway) acquireStream() bool {
+	g.Lock()
+	defer g.Unlock()
+
+	if g.maxStreams > 0 && g.openStreams >= g.maxStreams {
+		return false
+	}
+	g.openStreams++
+	return true
+}
+
+func (g *Gateway) releaseStream() {
+	g.Lock()
+	defer g.Unlock()
+
+	g.openStreams--
+}
+
+// sseStream is a grpc.ServerStream that sends messages as server-sent
+// events.
+type sseStream struct {
+	ctx     context.Context
+	w       http.ResponseWriter
+	flusher http.Flusher
+
+	body      []byte
+	received  bool
+	decodeErr error
+	started   bool
+}
+
+func (s *sseStream) SetHeader(metadata.MD) error {
+	return nil
+}
+
+func (s *sseStream) SendHeader(metadata.MD) error {
+	return nil
+}
+
+func (s *sseStream) SetTrailer(metadata.MD) {
+}
+
+func (s *sseStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *sseStream) SendMsg(m interface{}) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	if !s.started {
+		s.w.Header().Set("Content-Type", "text/event-stream")
+		s.w.Header().Set("Cache-Control", "no-cache")
+		s.w.WriteHeader(http.StatusOK)
+		s.started = true
+	}
+
+	if _, err = fmt.Fprintf(s.w, "data: %s\n\n", data); err != nil {
+		return err
+	}
+	s.flusher.Flush()
+	return nil
+}
+
+func (s *sseStream) RecvMsg(m interface{}) error {
+	if s.received {
+		return io.EOF
+	}
+	s.received = true
+
+	if m == nil {
+		return nil
+	}
+	s.decodeErr = decodeRequest(s.body, m)
+	return s.decodeErr
+}
+
+func decodeRequest(body []byte, v interface{}) error {
+	if len(body) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("malformed request: %w", err)
+	}
+	return nil
+}
+
+func (g *Gateway) errorStatusFor(err error) int {
+	if status.Code(err) == codes.PermissionDenied {
+		return http.StatusForbidden
+	}
+	module, code := errors.Code(err)
+
+	g.RLock()
+	defer g.RUnlock()
+
+	if status, ok := g.errorStatus[errorCode{module, code}]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+func isReadOnly(fullName string) bool {
+	md, err := cmnGrpc.GetRegisteredMethod(fullName)
+	if err != nil {
+		// Methods without a registered descriptor are not exposed as we
+		// cannot determine whether they are read-only.
+		return false
+	}
+	return md.IsReadOnly()
+}
+
+// checkAccess rejects calls of access controlled methods as HTTP clients
+// cannot be authenticated.
+func checkAccess(ctx context.Context, fullMethodName string, req interface{}) error {
+	md, err := cmnGrpc.GetRegisteredMethod(fullMethodName)
+	if err != nil {
+		return status.Errorf(codes.PermissionDenied, "invalid request method")
+	}
+
+	ac, err := md.IsAccessControlled(ctx, req)
+	if err != nil {
+		return status.Errorf(codes.PermissionDenied, "internal error: %s", err.Error())
+	}
+	if ac {
+		return status.Errorf(codes.PermissionDenied, "gateway: method is access controlled")
+	}
+	return nil
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	module, code := errors.Code(err)
+	rsp := errorResponse{
+		Message: err.Error(),
+	}
+	if module != errors.UnknownModule {
+		rsp.Module = module
+		rsp.Code = code
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(&rsp)
+}
+
+// New creates a new JSON/HTTP gateway.
+func New(cfg *Config) *Gateway {
+	unaryInterceptor, streamInterceptor := cmnGrpc.NewServerInterceptors("grpc/gateway", checkAccess, cfg.RateLimit)
+	g := &Gateway{
+		unary:             make(map[string]*unaryMethod),
+		streams:           make(map[string]*streamMethod),
+		errorStatus:       make(map[errorCode]int),
+		unaryInterceptor:  unaryInterceptor,
+		streamInterceptor: streamInterceptor,
+		maxStreams:        cfg.MaxStreams,
+		logger:            logging.GetLogger("common/grpc/gateway"),
+	}
+	g.RegisterErrorStatus(http.StatusTooManyRequests, cmnGrpc.ErrQuotaExceeded)
+
+	return g
+}
@@ -0,0 +1,260 @@
+package gateway
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/oasislabs/oasis-core/go/common/errors"
+	cmnGrpc "github.com/oasislabs/oasis-core/go/common/grpc"
+)
+
+var (
+	testServiceName = cmnGrpc.NewServiceName("GatewayTest")
+
+	methodEcho   = testServiceName.NewMethod("Echo", echoRequest{}).WithReadOnly()
+	methodSubmit = testServiceName.NewMethod("Submit", echoRequest{})
+	methodWatch  = testServiceName.NewMethod("Watch", int(0)).WithReadOnly()
+	methodSecret = testServiceName.NewMethod("Secret", echoRequest{}).WithReadOnly().WithAccessControl(alwaysAccessControlled)
+
+	errTestNotFound = errors.New("gateway/test", 1, "gateway/test: not found")
+
+	testServiceDesc = grpc.ServiceDesc{
+		ServiceName: string(testServiceName),
+		HandlerType: (*testService)(nil),
+		Methods: []grpc.MethodDesc{
+			{
+				MethodName: methodEcho.ShortName(),
+				Handler:    handlerEcho,
+			},
+			{
+				MethodName: methodSubmit.ShortName(),
+				Handler:    handlerEcho,
+			},
+			{
+				MethodName: methodSecret.ShortName(),
+				Handler:    handlerSecret,
+			},
+		},
+		Streams: []grpc.StreamDesc{
+			{
+				StreamName:    methodWatch.ShortName(),
+				Handler:       handlerWatch,
+				ServerStreams: true,
+			},
+		},
+	}
+)
+
+type echoRequest struct {
+	Message string `json:"message"`
+}
+
+type testService interface{}
+
+func alwaysAccessControlled(ctx context.Context, req interface{}) (bool, error) {
+	return true, nil
+}
+
+func handlerEcho( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var rq echoRequest
+	if err := dec(&rq); err != nil {
+		return nil, err
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		rq := req.(*echoRequest)
+		switch rq.Message {
+		case "not found":
+			return nil, errTestNotFound
+		case "fail":
+			return nil, fmt.Errorf("failed")
+		}
+		return rq, nil
+	}
+	if interceptor == nil {
+		return handler(ctx, &rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodEcho.FullName(),
+	}
+	return interceptor(ctx, &rq, info, handler)
+}
+
+func handlerSecret( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var rq echoRequest
+	if err := dec(&rq); err != nil {
+		return nil, err
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return req, nil
+	}
+	if interceptor == nil {
+		return handler(ctx, &rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodSecret.FullName(),
+	}
+	return interceptor(ctx, &rq, info, handler)
+}
+
+func handlerWatch(srv interface{}, stream grpc.ServerStream) error {
+	var count int
+	if err := stream.RecvMsg(&count); err != nil {
+		return err
+	}
+
+	// A negative count keeps the stream open until the client goes away.
+	if count < 0 {
+		if err := stream.SendMsg(0); err != nil {
+			return err
+		}
+		<-stream.Context().Done()
+		return stream.Context().Err()
+	}
+
+	for i := 0; i < count; i++ {
+		if err := stream.SendMsg(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestGateway(t *testing.T) {
+	require := require.New(t)
+
+	gw := New(&Config{})
+	gw.RegisterService(&testServiceDesc, struct{}{})
+	gw.RegisterErrorStatus(http.StatusNotFound, errTestNotFound)
+	require.ElementsMatch([]string{methodEcho.FullName(), methodWatch.FullName(), methodSecret.FullName()}, gw.Methods())
+
+	srv := httptest.NewServer(gw)
+	defer srv.Close()
+
+	// Unary method.
+	rsp, err := http.Post(srv.URL+methodEcho.FullName(), "application/json", strings.NewReader(`{"message":"hello"}`))
+	require.NoError(err, "Post")
+	defer rsp.Body.Close()
+	require.Equal(http.StatusOK, rsp.StatusCode)
+	var echo echoRequest
+	require.NoError(json.NewDecoder(rsp.Body).Decode(&echo), "Decode")
+	require.Equal("hello", echo.Message)
+
+	// Malformed request.
+	rsp, err = http.Post(srv.URL+methodEcho.FullName(), "application/json", strings.NewReader(`{`))
+	require.NoError(err, "Post")
+	defer rsp.Body.Close()
+	require.Equal(http.StatusBadRequest, rsp.StatusCode)
+
+	// Registered errors should map to their status code.
+	rsp, err = http.Post(srv.URL+methodEcho.FullName(), "application/json", strings.NewReader(`{"message":"not found"}`))
+	require.NoError(err, "Post")
+	defer rsp.Body.Close()
+	require.Equal(http.StatusNotFound, rsp.StatusCode)
+	var errRsp errorResponse
+	require.NoError(json.NewDecoder(rsp.Body).Decode(&errRsp), "Decode")
+	require.Equal("gateway/test", errRsp.Module)
+	require.EqualValues(1, errRsp.Code)
+
+	// Other errors should be internal errors.
+	rsp, err = http.Post(srv.URL+methodEcho.FullName(), "application/json", strings.NewReader(`{"message":"fail"}`))
+	require.NoError(err, "Post")
+	defer rsp.Body.Close()
+	require.Equal(http.StatusInternalServerError, rsp.StatusCode)
+
+	// Methods not marked as read-only should not be exposed.
+	rsp, err = http.Post(srv.URL+methodSubmit.FullName(), "application/json", strings.NewReader(`{"message":"hello"}`))
+	require.NoError(err, "Post")
+	defer rsp.Body.Close()
+	require.Equal(http.StatusNotFound, rsp.StatusCode)
+
+	// Access controlled methods should be rejected.
+	rsp, err = http.Post(srv.URL+methodSecret.FullName(), "application/json", strings.NewReader(`{"message":"hello"}`))
+	require.NoError(err, "Post")
+	defer rsp.Body.Close()
+	require.Equal(http.StatusForbidden, rsp.StatusCode)
+
+	// Streaming method.
+	rsp, err = http.Post(srv.URL+methodWatch.FullName(), "application/json", strings.NewReader(`3`))
+	require.NoError(err, "Post")
+	defer rsp.Body.Close()
+	require.Equal(http.StatusOK, rsp.StatusCode)
+	require.Equal("text/event-stream", rsp.Header.Get("Content-Type"))
+
+	var events []string
+	scanner := bufio.NewScanner(rsp.Body)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			events = append(events, line)
+		}
+	}
+	require.NoError(scanner.Err(), "Scan")
+	require.Equal([]string{"data: 0", "data: 1", "data: 2"}, events)
+}
+
+func TestGatewayLimits(t *testing.T) {
+	require := require.New(t)
+
+	gw := New(&Config{
+		RateLimit: &cmnGrpc.RateLimitConfig{
+			Default: cmnGrpc.RateLimit{Rate: 0.001, Burst: 2},
+		},
+		MaxStreams: 1,
+	})
+	gw.RegisterService(&testServiceDesc, struct{}{})
+
+	srv := httptest.NewServer(gw)
+	defer srv.Close()
+
+	// Calls should be rate limited per client and method.
+	for i := 0; i < 2; i++ {
+		rsp, err := http.Post(srv.URL+methodEcho.FullName(), "application/json", strings.NewReader(`{"message":"hello"}`))
+		require.NoError(err, "Post")
+		defer rsp.Body.Close()
+		require.Equal(http.StatusOK, rsp.StatusCode)
+	}
+	rsp, err := http.Post(srv.URL+methodEcho.FullName(), "application/json", strings.NewReader(`{"message":"hello"}`))
+	require.NoError(err, "Post")
+	defer rsp.Body.Close()
+	require.Equal(http.StatusTooManyRequests, rsp.StatusCode)
+	var errRsp errorResponse
+	require.NoError(json.NewDecoder(rsp.Body).Decode(&errRsp), "Decode")
+	require.Equal(cmnGrpc.ModuleName, errRsp.Module)
+
+	// The number of concurrently open streams should be limited.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, srv.URL+methodWatch.FullName(), strings.NewReader(`-1`))
+	require.NoError(err, "NewRequest")
+	rsp, err = http.DefaultClient.Do(req)
+	require.NoError(err, "Do")
+	defer rsp.Body.Close()
+	require.Equal(http.StatusOK, rsp.StatusCode)
+	scanner := bufio.NewScanner(rsp.Body)
+	require.True(scanner.Scan(), "first event")
+
+	rsp, err = http.Post(srv.URL+methodWatch.FullName(), "application/json", strings.NewReader(`1`))
+	require.NoError(err, "Post")
+	defer rsp.Body.Close()
+	require.Equal(http.StatusServiceUnavailable, rsp.StatusCode)
+}
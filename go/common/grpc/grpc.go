@@ -15,6 +15,7 @@ import (
 
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_opentracing "github.com/grpc-ecosystem/go-grpc-middleware/tracing/opentracing"
+	"github.com/opentracing/opentracing-go"
 	"github.com/prometheus/client_golang/prometheus"
 	flag "github.com/spf13/pflag"
 	"github.com/spf13/viper"
@@ -66,11 +67,19 @@ var (
 		},
 		[]string{"call"},
 	)
+	grpcRateLimited = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_grpc_rate_limited",
+			Help: "Number of gRPC calls rejected due to rate limiting.",
+		},
+		[]string{"call"},
+	)
 
 	grpcCollectors = []prometheus.Collector{
 		grpcCalls,
 		grpcLatency,
 		grpcStreamWrites,
+		grpcRateLimited,
 	}
 
 	serverKeepAliveParams = keepalive.ServerParameters{
@@ -313,6 +322,8 @@ type ServerConfig struct { // nolint: maligned
 	ClientCommonName string
 	// CustomOptions is an array of extra options for the grpc server.
 	CustomOptions []grpc.ServerOption
+	// RateLimit is the optional per-client rate limiting configuration.
+	RateLimit *RateLimitConfig
 }
 
 type listenerConfig struct {
@@ -464,13 +475,20 @@ func NewServer(config *ServerConfig) (*Server, error) {
 		logAdapter.unaryLogger,
 		grpc_opentracing.UnaryServerInterceptor(),
 		serverUnaryErrorMapper,
-		auth.UnaryServerInterceptor(config.AuthFunc),
 	}
 	streamInterceptors := []grpc.StreamServerInterceptor{
 		logAdapter.streamLogger,
 		grpc_opentracing.StreamServerInterceptor(),
 		serverStreamErrorMapper,
-		auth.StreamServerInterceptor(config.AuthFunc),
+	}
+	unaryInterceptors = append(unaryInterceptors, auth.UnaryServerInterceptor(config.AuthFunc))
+	streamInterceptors = append(streamInterceptors, auth.StreamServerInterceptor(config.AuthFunc))
+	// Rate limits are checked after authentication so that the limiter can
+	// rely on the client identity of authorized calls.
+	if config.RateLimit != nil {
+		limiter := newRateLimiter(config.RateLimit)
+		unaryInterceptors = append(unaryInterceptors, limiter.unaryInterceptor)
+		streamInterceptors = append(streamInterceptors, limiter.streamInterceptor)
 	}
 	if config.InstallWrapper {
 		wrapper = newWrapper()
@@ -515,12 +533,66 @@ func NewServer(config *ServerConfig) (*Server, error) {
 	}, nil
 }
 
+// NewServerInterceptors returns the unary and stream server interceptors for
+// serving calls of registered services over a transport other than gRPC
+// (e.g., the JSON/HTTP gateway).
+//
+// Like the interceptors of servers created by NewServer, they log the calls,
+// record call metrics, enforce the given rate limits (if not nil) and
+// authenticate calls using the given function. Errors are not mapped to
+// gRPC status errors.
+func NewServerInterceptors(
+	name string,
+	authFunc auth.AuthenticationFunction,
+	rateLimit *RateLimitConfig,
+) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	grpcMetricsOnce.Do(func() {
+		prometheus.MustRegister(grpcCollectors...)
+	})
+
+	if authFunc == nil {
+		authFunc = auth.NoAuth
+	}
+	logAdapter := newGrpcLogAdapter(logging.GetLogger(name))
+	unaryInterceptors := []grpc.UnaryServerInterceptor{
+		logAdapter.unaryLogger,
+	}
+	streamInterceptors := []grpc.StreamServerInterceptor{
+		logAdapter.streamLogger,
+	}
+	unaryInterceptors = append(unaryInterceptors, auth.UnaryServerInterceptor(authFunc))
+	streamInterceptors = append(streamInterceptors, auth.StreamServerInterceptor(authFunc))
+	if rateLimit != nil {
+		limiter := newRateLimiter(rateLimit)
+		unaryInterceptors = append(unaryInterceptors, limiter.unaryInterceptor)
+		streamInterceptors = append(streamInterceptors, limiter.streamInterceptor)
+	}
+
+	return grpc_middleware.ChainUnaryServer(unaryInterceptors...), grpc_middleware.ChainStreamServer(streamInterceptors...)
+}
+
+// clientTracingFilter only traces client calls made within an existing
+// span so that background calls do not start fresh traces.
+func clientTracingFilter(ctx context.Context, fullMethodName string) bool {
+	return opentracing.SpanFromContext(ctx) != nil
+}
+
 // Dial creates a client connection to the given target.
+//
+// The span context of any span present in the call context is propagated
+// to the server. This internally takes a snapshot of the current global
+// tracer, so make sure you initialize the global tracer before calling this.
 func Dial(target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
 	dialOpts := []grpc.DialOption{
 		grpc.WithDefaultCallOptions(grpc.ForceCodec(&CBORCodec{})),
-		grpc.WithChainUnaryInterceptor(clientUnaryErrorMapper),
-		grpc.WithChainStreamInterceptor(clientStreamErrorMapper),
+		grpc.WithChainUnaryInterceptor(
+			clientUnaryErrorMapper,
+			grpc_opentracing.UnaryClientInterceptor(grpc_opentracing.WithFilterFunc(clientTracingFilter)),
+		),
+		grpc.WithChainStreamInterceptor(
+			clientStreamErrorMapper,
+			grpc_opentracing.StreamClientInterceptor(grpc_opentracing.WithFilterFunc(clientTracingFilter)),
+		),
 	}
 	dialOpts = append(dialOpts, opts...)
 	return grpc.Dial(target, dialOpts...)
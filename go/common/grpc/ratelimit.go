@@ -0,0 +1,237 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"github.com/oasislabs/oasis-core/go/common/accessctl"
+	"github.com/oasislabs/oasis-core/go/common/errors"
+)
+
+const (
+	// ModuleName is the module name used for gRPC server errors.
+	ModuleName = "grpc"
+
+	// rateLimitPruneInterval is the interval at which idle buckets are pruned.
+	rateLimitPruneInterval = 1 * time.Minute
+)
+
+// ErrQuotaExceeded is the error returned when a client exceeds its call quota.
+var ErrQuotaExceeded = errors.New(ModuleName, 1, "grpc: quota exceeded")
+
+// RateLimit is a token bucket rate limit.
+type RateLimit struct {
+	// Rate is the number of calls per second that a client is allowed to
+	// make. A zero rate disables the limit.
+	Rate float64
+	// Burst is the maximum number of calls that a client is allowed to make
+	// in a single burst. If zero, a burst of one call is used.
+	Burst uint64
+}
+
+func (l RateLimit) capacity() float64 {
+	if l.Burst == 0 {
+		return 1
+	}
+	return float64(l.Burst)
+}
+
+// RateLimitConfig is the rate limiting configuration for a gRPC server.
+//
+// Limits are enforced separately for each (method, client) pair where the
+// client is identified by its IP address. Clients calling access controlled
+// methods are instead identified by their TLS certificate public key, as
+// the certificate has been authorized by the access policy before the limit
+// is checked.
+type RateLimitConfig struct {
+	// Default is the limit applied to methods without an explicit limit.
+	Default RateLimit
+	// Methods contains per-method limits, keyed by the full method name.
+	//
+	// Method names are matched case-insensitively as configuration sources
+	// may not preserve case.
+	Methods map[string]RateLimit
+}
+
+type rateLimitKey struct {
+	method string
+	client string
+}
+
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// take refills the bucket and tries to take a single token from it.
+func (b *tokenBucket) take(limit RateLimit, now time.Time) bool {
+	capacity := limit.capacity()
+	b.tokens += now.Sub(b.updated).Seconds() * limit.Rate
+	if b.tokens > capacity {
+		b.tokens = capacity
+	}
+	b.updated = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// isFull returns true iff the bucket would be full at the given time.
+func (b *tokenBucket) isFull(limit RateLimit, now time.Time) bool {
+	return b.tokens+now.Sub(b.updated).Seconds()*limit.Rate >= limit.capacity()
+}
+
+type rateLimiter struct {
+	sync.Mutex
+
+	defaultLimit RateLimit
+	methodLimits map[string]RateLimit
+
+	buckets    map[rateLimitKey]*tokenBucket
+	lastPruned time.Time
+
+	now func() time.Time
+}
+
+func (r *rateLimiter) limitFor(method string) RateLimit {
+	if l, ok := r.methodLimits[strings.ToLower(method)]; ok {
+		return l
+	}
+	return r.defaultLimit
+}
+
+func (r *rateLimiter) allow(method, client string) bool {
+	limit := r.limitFor(method)
+	if limit.Rate <= 0 {
+		return true
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	now := r.now()
+	if now.Sub(r.lastPruned) >= rateLimitPruneInterval {
+		r.prune(now)
+	}
+
+	key := rateLimitKey{method: method, client: client}
+	bucket, ok := r.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{
+			tokens:  limit.capacity(),
+			updated: now,
+		}
+		r.buckets[key] = bucket
+	}
+	return bucket.take(limit, now)
+}
+
+// prune removes buckets that would be full as they are equivalent to
+// buckets that have not yet been created.
+func (r *rateLimiter) prune(now time.Time) {
+	for key, bucket := range r.buckets {
+		if bucket.isFull(r.limitFor(key.method), now) {
+			delete(r.buckets, key)
+		}
+	}
+	r.lastPruned = now
+}
+
+func (r *rateLimiter) checkQuota(ctx context.Context, method string, authorized bool) error {
+	if r.allow(method, clientFromContext(ctx, authorized)) {
+		return nil
+	}
+
+	grpcRateLimited.With(prometheus.Labels{"call": method}).Inc()
+	return ErrQuotaExceeded
+}
+
+func (r *rateLimiter) unaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	if err := r.checkQuota(ctx, info.FullMethod, isAccessControlled(ctx, info.FullMethod, req)); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (r *rateLimiter) streamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	// Stream requests are only authorized once received, so the client
+	// cannot be identified by its certificate.
+	if err := r.checkQuota(ss.Context(), info.FullMethod, false); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// isAccessControlled returns true iff the given call is subject to access
+// control.
+func isAccessControlled(ctx context.Context, method string, req interface{}) bool {
+	md, err := GetRegisteredMethod(method)
+	if err != nil {
+		return false
+	}
+	ac, err := md.IsAccessControlled(ctx, req)
+	return err == nil && ac
+}
+
+// clientFromContext returns the identifier of the client that made the
+// call.
+//
+// The TLS certificate public key is only used if the call has been
+// authorized by the access policy, as otherwise clients could evade the
+// limits by presenting a different certificate for each call. The IP
+// address is used in all other cases.
+func clientFromContext(ctx context.Context, authorized bool) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	if tlsAuth, ok := p.AuthInfo.(credentials.TLSInfo); ok && authorized && len(tlsAuth.State.PeerCertificates) == 1 {
+		if subject := accessctl.SubjectFromX509Certificate(tlsAuth.State.PeerCertificates[0]); subject != "" {
+			return string(subject)
+		}
+	}
+
+	if p.Addr == nil {
+		return ""
+	}
+	if addr, ok := p.Addr.(*net.TCPAddr); ok {
+		return addr.IP.String()
+	}
+	return p.Addr.String()
+}
+
+func newRateLimiter(cfg *RateLimitConfig) *rateLimiter {
+	methodLimits := make(map[string]RateLimit)
+	for method, limit := range cfg.Methods {
+		methodLimits[strings.ToLower(method)] = limit
+	}
+
+	return &rateLimiter{
+		defaultLimit: cfg.Default,
+		methodLimits: methodLimits,
+		buckets:      make(map[rateLimitKey]*tokenBucket),
+		now:          time.Now,
+	}
+}
@@ -0,0 +1,114 @@
+package grpc
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"github.com/oasislabs/oasis-core/go/common/accessctl"
+)
+
+func TestRateLimiter(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1000, 0)
+	limiter := newRateLimiter(&RateLimitConfig{
+		Default: RateLimit{Rate: 1, Burst: 2},
+		Methods: map[string]RateLimit{
+			"/Test/Unlimited": {},
+			"/Test/Slow":      {Rate: 0.5},
+		},
+	})
+	limiter.now = func() time.Time { return now }
+
+	// Default limit.
+	require.True(limiter.allow("/Test/Default", "a"), "first call should be allowed")
+	require.True(limiter.allow("/Test/Default", "a"), "burst should be allowed")
+	require.False(limiter.allow("/Test/Default", "a"), "call exceeding burst should be rejected")
+	require.True(limiter.allow("/Test/Default", "b"), "other clients should not be affected")
+	now = now.Add(1 * time.Second)
+	require.True(limiter.allow("/Test/Default", "a"), "call should be allowed after refill")
+	require.False(limiter.allow("/Test/Default", "a"), "call exceeding refill should be rejected")
+
+	// Per-method limits (matched case-insensitively).
+	for i := 0; i < 10; i++ {
+		require.True(limiter.allow("/Test/Unlimited", "a"), "unlimited method should always be allowed")
+	}
+	require.True(limiter.allow("/Test/slow", "a"), "first call should be allowed")
+	require.False(limiter.allow("/Test/slow", "a"), "call exceeding burst should be rejected")
+	now = now.Add(1 * time.Second)
+	require.False(limiter.allow("/Test/slow", "a"), "call should be rejected before refill")
+	now = now.Add(1 * time.Second)
+	require.True(limiter.allow("/Test/slow", "a"), "call should be allowed after refill")
+
+	// Idle buckets should be pruned.
+	now = now.Add(rateLimitPruneInterval)
+	require.True(limiter.allow("/Test/Default", "a"), "call should be allowed after refill")
+	require.Len(limiter.buckets, 1, "idle buckets should be pruned")
+}
+
+func TestRateLimitInterceptor(t *testing.T) {
+	require := require.New(t)
+
+	// Generate temporary filename for the socket.
+	f, err := ioutil.TempFile("", "oasis-grpc-ratelimit-test-socket")
+	require.NoError(err, "TempFile")
+	// Remove the file as we only need the name.
+	f.Close()
+	os.Remove(f.Name())
+
+	cfg := &ServerConfig{
+		Path: f.Name(),
+		RateLimit: &RateLimitConfig{
+			Default: RateLimit{Rate: 0.001, Burst: 1},
+		},
+	}
+	grpcServer, err := NewServer(cfg)
+	require.NoError(err, "NewServer")
+	defer os.Remove(f.Name())
+
+	grpcServer.Server().RegisterService(&errorTestServiceDesc, &errorTestServer{})
+
+	err = grpcServer.Start()
+	require.NoErrorf(err, "Failed to start the gRPC server")
+	defer grpcServer.Stop()
+
+	conn, err := Dial("unix:"+f.Name(), grpc.WithInsecure())
+	require.NoError(err, "Dial")
+	defer conn.Close()
+	client := &errorTestClient{conn}
+
+	_, err = client.ErrorTest(context.Background(), &ErrorTestRequest{})
+	require.Equal(errTest, err, "first call should reach the service")
+	_, err = client.ErrorTest(context.Background(), &ErrorTestRequest{})
+	require.Equal(ErrQuotaExceeded, err, "second call should be rate limited")
+}
+
+func TestClientFromContext(t *testing.T) {
+	require := require.New(t)
+
+	pk, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(err, "GenerateKey")
+	cert := &x509.Certificate{PublicKey: pk}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.IPv4(192, 0, 2, 1), Port: 1234},
+		AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}},
+		},
+	})
+
+	require.Equal("192.0.2.1", clientFromContext(ctx, false), "unauthorized clients should be identified by IP address")
+	require.Equal(string(accessctl.SubjectFromX509Certificate(cert)), clientFromContext(ctx, true), "authorized clients should be identified by certificate")
+}
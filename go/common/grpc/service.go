@@ -7,6 +7,8 @@ import (
 	"strings"
 	"sync"
 
+	"google.golang.org/grpc"
+
 	"github.com/oasislabs/oasis-core/go/common"
 	"github.com/oasislabs/oasis-core/go/common/cbor"
 )
@@ -19,6 +21,13 @@ var registeredMethods sync.Map
 // ServiceName is a gRPC service name.
 type ServiceName string
 
+// ServiceRegistrar is an interface for registering gRPC service
+// implementations. It is implemented by *grpc.Server.
+type ServiceRegistrar interface {
+	// RegisterService registers a service and its implementation.
+	RegisterService(desc *grpc.ServiceDesc, impl interface{})
+}
+
 // NamespaceExtractorFunc extracts namespce from a method request.
 type NamespaceExtractorFunc func(ctx context.Context, req interface{}) (common.Namespace, error)
 
@@ -86,11 +95,19 @@ func (m *MethodDesc) WithAccessControl(f AccessControlFunc) *MethodDesc {
 	return m
 }
 
+// WithReadOnly marks the method as a read-only query that does not mutate
+// state (e.g., submit transactions).
+func (m *MethodDesc) WithReadOnly() *MethodDesc {
+	m.readOnly = true
+	return m
+}
+
 // MethodDesc is a gRPC method descriptor.
 type MethodDesc struct {
 	short       string
 	full        string
 	requestType interface{}
+	readOnly    bool
 
 	accessControl      AccessControlFunc
 	namespaceExtractor NamespaceExtractorFunc
@@ -114,6 +131,11 @@ func (m *MethodDesc) IsAccessControlled(ctx context.Context, req interface{}) (b
 	return m.accessControl(ctx, req)
 }
 
+// IsReadOnly returns true iff the method has been marked as read-only.
+func (m *MethodDesc) IsReadOnly() bool {
+	return m.readOnly
+}
+
 // UnmarshalRawMessage unmarshals `cbor.RawMessage` request.
 func (m *MethodDesc) UnmarshalRawMessage(req *cbor.RawMessage) (interface{}, error) {
 	v := reflect.New(reflect.TypeOf(m.requestType)).Interface()
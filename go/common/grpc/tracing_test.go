@@ -0,0 +1,73 @@
+package grpc
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+type tracingTestServer struct {
+	spanCh chan opentracing.Span
+}
+
+func (s *tracingTestServer) ErrorTest(ctx context.Context, req *ErrorTestRequest) (*ErrorTestResponse, error) {
+	s.spanCh <- opentracing.SpanFromContext(ctx)
+	return &ErrorTestResponse{}, nil
+}
+
+func TestTracingPropagation(t *testing.T) {
+	require := require.New(t)
+
+	tracer := mocktracer.New()
+	prevTracer := opentracing.GlobalTracer()
+	opentracing.SetGlobalTracer(tracer)
+	defer opentracing.SetGlobalTracer(prevTracer)
+
+	// Generate temporary filename for the socket.
+	f, err := ioutil.TempFile("", "oasis-grpc-tracing-test-socket")
+	require.NoError(err, "TempFile")
+	// Remove the file as we only need the name.
+	f.Close()
+	os.Remove(f.Name())
+
+	grpcServer, err := NewServer(&ServerConfig{Path: f.Name()})
+	require.NoError(err, "NewServer")
+	defer os.Remove(f.Name())
+
+	srv := &tracingTestServer{spanCh: make(chan opentracing.Span, 1)}
+	grpcServer.Server().RegisterService(&errorTestServiceDesc, srv)
+
+	err = grpcServer.Start()
+	require.NoErrorf(err, "Failed to start the gRPC server")
+	defer grpcServer.Stop()
+
+	conn, err := Dial("unix:"+f.Name(), grpc.WithInsecure())
+	require.NoError(err, "Dial")
+	defer conn.Close()
+	client := &errorTestClient{conn}
+
+	// Calls made within a span should continue the trace.
+	span, ctx := opentracing.StartSpanFromContext(context.Background(), "test")
+	_, err = client.ErrorTest(ctx, &ErrorTestRequest{})
+	require.NoError(err, "ErrorTest")
+	span.Finish()
+
+	serverSpan := (<-srv.spanCh).(*mocktracer.MockSpan)
+	require.Equal(span.(*mocktracer.MockSpan).SpanContext.TraceID, serverSpan.SpanContext.TraceID, "server span should be in the same trace")
+
+	// Calls made without a span should not be traced by the client.
+	tracer.Reset()
+	_, err = client.ErrorTest(context.Background(), &ErrorTestRequest{})
+	require.NoError(err, "ErrorTest")
+	<-srv.spanCh
+	for _, s := range tracer.FinishedSpans() {
+		require.NotEqual(ext.SpanKindRPCClientEnum, s.Tag(string(ext.SpanKind)), "client span should not be created")
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/log/level"
@@ -25,6 +26,7 @@ var (
 	backend = logBackend{
 		baseLogger:   log.NewNopLogger(),
 		defaultLevel: LevelError,
+		levels:       make(map[string]*moduleLevel),
 	}
 
 	_ pflag.Value = (*Level)(nil)
@@ -143,13 +145,27 @@ func (l *Level) Type() string {
 // Logger is a logger instance.
 type Logger struct {
 	logger log.Logger
-	level  Level
+	level  *moduleLevel
 	module string
 }
 
+// moduleLevel is the log level of a module. It is shared by all loggers
+// of the same module so that the level can be changed at runtime.
+type moduleLevel struct {
+	level uint32
+}
+
+func (m *moduleLevel) get() Level {
+	return Level(atomic.LoadUint32(&m.level))
+}
+
+func (m *moduleLevel) set(lvl Level) {
+	atomic.StoreUint32(&m.level, uint32(lvl))
+}
+
 // Debug logs the message and key value pairs at the Debug log level.
 func (l *Logger) Debug(msg string, keyvals ...interface{}) {
-	if l.level > LevelDebug {
+	if l.level.get() > LevelDebug {
 		return
 	}
 	keyvals = append([]interface{}{"msg", msg}, keyvals...)
@@ -158,7 +174,7 @@ func (l *Logger) Debug(msg string, keyvals ...interface{}) {
 
 // Info logs the message and key value pairs at the Info log level.
 func (l *Logger) Info(msg string, keyvals ...interface{}) {
-	if l.level > LevelInfo {
+	if l.level.get() > LevelInfo {
 		return
 	}
 	keyvals = append([]interface{}{"msg", msg}, keyvals...)
@@ -167,7 +183,7 @@ func (l *Logger) Info(msg string, keyvals ...interface{}) {
 
 // Warn logs the message and key value pairs at the Warn log level.
 func (l *Logger) Warn(msg string, keyvals ...interface{}) {
-	if l.level > LevelWarn {
+	if l.level.get() > LevelWarn {
 		return
 	}
 	keyvals = append([]interface{}{"msg", msg}, keyvals...)
@@ -176,7 +192,7 @@ func (l *Logger) Warn(msg string, keyvals ...interface{}) {
 
 // Error logs the message and key value pairs at the Error log level.
 func (l *Logger) Error(msg string, keyvals ...interface{}) {
-	if l.level > LevelError {
+	if l.level.get() > LevelError {
 		return
 	}
 	keyvals = append([]interface{}{"msg", msg}, keyvals...)
@@ -194,6 +210,9 @@ func (l *Logger) With(keyvals ...interface{}) *Logger {
 
 // GetLevel returns the curent global log level.
 func GetLevel() Level {
+	backend.Lock()
+	defer backend.Unlock()
+
 	return backend.defaultLevel
 }
 
@@ -241,8 +260,12 @@ func Initialize(w io.Writer, format Format, defaultLvl Level, moduleLvls map[str
 		}
 	}
 
-	logger = level.NewFilter(logger, defaultLvl.toOption())
-	logger = log.With(logger, "ts", log.DefaultTimestampUTC)
+	// The level filter is wrapped in a swap logger so that the default
+	// level can be changed via SetLevels.
+	backend.writer = logger
+	backend.filter = &log.SwapLogger{}
+	backend.filter.Swap(level.NewFilter(logger, defaultLvl.toOption()))
+	logger = log.With(backend.filter, "ts", log.DefaultTimestampUTC)
 
 	backend.baseLogger = logger
 	backend.moduleLevels = moduleLvls
@@ -251,16 +274,13 @@ func Initialize(w io.Writer, format Format, defaultLvl Level, moduleLvls map[str
 
 	// Swap all the early loggers to the initialized backend.
 	for _, l := range backend.earlyLoggers {
-		l.swapLogger.Swap(backend.baseLogger)
-
-		// Re-evaluate log level.
-		// NOTE: This introduces a potential race condition if loggers are used
-		//       before the logging system is initialized. Protecting the log
-		//       level with a mutex just for this one case seems overkill.
-		backend.setupLogLevelLocked(l.logger)
+		l.Swap(backend.baseLogger)
 	}
 	backend.earlyLoggers = nil
 
+	// Re-evaluate the log levels of all modules.
+	backend.updateLevelsLocked()
+
 	// libp2p/IPFS uses yet another logging library, that appears to be a
 	// wrapper around go-logging.  Because it's quality IPFS code, it's
 	// configured via env vars, from the package `init()`.
@@ -273,23 +293,46 @@ func Initialize(w io.Writer, format Format, defaultLvl Level, moduleLvls map[str
 	return nil
 }
 
-type earlyLogger struct {
-	swapLogger *log.SwapLogger
-	logger     *Logger
+// SetLevels changes the default log level and the per-module log levels
+// of an initialized logging backend. The new levels apply to all existing
+// and future loggers.
+func SetLevels(defaultLvl Level, moduleLvls map[string]Level) error {
+	backend.Lock()
+	defer backend.Unlock()
+
+	if !backend.initialized {
+		return fmt.Errorf("logging: not initialized")
+	}
+
+	backend.filter.Swap(level.NewFilter(backend.writer, defaultLvl.toOption()))
+	backend.moduleLevels = moduleLvls
+	backend.defaultLevel = defaultLvl
+	backend.updateLevelsLocked()
+
+	return nil
 }
 
 type logBackend struct {
 	sync.Mutex
 
 	baseLogger   log.Logger
-	earlyLoggers []*earlyLogger
+	writer       log.Logger
+	filter       *log.SwapLogger
+	earlyLoggers []*log.SwapLogger
 	defaultLevel Level
 	moduleLevels map[string]Level
+	levels       map[string]*moduleLevel
 
 	initialized bool
 }
 
-func (b *logBackend) setupLogLevelLocked(l *Logger) {
+func (b *logBackend) updateLevelsLocked() {
+	for module, ml := range b.levels {
+		ml.set(b.levelForModuleLocked(module))
+	}
+}
+
+func (b *logBackend) levelForModuleLocked(module string) Level {
 	// Check, whether there is a specific logging level set for the module.
 	// The longest prefix match of the module name provided in the config file will be taken.
 	// Otherwise, fallback to level defined by "default" key.
@@ -301,13 +344,13 @@ func (b *logBackend) setupLogLevelLocked(l *Logger) {
 
 	lvl := b.defaultLevel
 	for _, k := range modulePrefixes {
-		if strings.HasPrefix(l.module, k) {
+		if strings.HasPrefix(module, k) {
 			lvl = b.moduleLevels[k]
 			break
 		}
 	}
 
-	l.level = lvl
+	return lvl
 }
 
 func (b *logBackend) getLogger(module string, extraUnwind int) *Logger {
@@ -335,17 +378,22 @@ func (b *logBackend) getLogger(module string, extraUnwind int) *Logger {
 		"caller",
 		log.Caller(defaultUnwind + extraUnwind),
 	}...)
+	ml, ok := b.levels[module]
+	if !ok {
+		ml = new(moduleLevel)
+		ml.set(b.levelForModuleLocked(module))
+		b.levels[module] = ml
+	}
 	l := &Logger{
 		logger: log.WithPrefix(logger, keyvals...),
+		level:  ml,
 		module: module,
 	}
-	b.setupLogLevelLocked(l)
 
 	if !b.initialized {
 		// Stash the logger so that it can be instantiated once logging
 		// is actually initialized.
-		sLog := logger.(*log.SwapLogger)
-		b.earlyLoggers = append(b.earlyLoggers, &earlyLogger{swapLogger: sLog, logger: l})
+		b.earlyLoggers = append(b.earlyLoggers, logger.(*log.SwapLogger))
 	}
 
 	return l
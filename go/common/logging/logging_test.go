@@ -0,0 +1,54 @@
+package logging
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetLevels(t *testing.T) {
+	require := require.New(t)
+
+	var buf bytes.Buffer
+	err := Initialize(&buf, FmtLogfmt, LevelWarn, map[string]Level{"test/quiet": LevelError})
+	require.NoError(err, "Initialize")
+
+	// Loggers created before the levels are changed.
+	loud := GetLogger("test/loud")
+	quiet := GetLogger("test/quiet/module")
+
+	logged := func(logFn func(string, ...interface{}), msg string) bool {
+		buf.Reset()
+		logFn(msg)
+		return bytes.Contains(buf.Bytes(), []byte(msg))
+	}
+
+	require.False(logged(loud.Info, "loud info 1"), "info should not be logged at the warn level")
+	require.True(logged(loud.Warn, "loud warn 1"), "warn should be logged at the warn level")
+	require.False(logged(quiet.Warn, "quiet warn 1"), "warn should not be logged for a module at the error level")
+
+	// Changing the default level should affect existing loggers.
+	err = SetLevels(LevelDebug, map[string]Level{"test/quiet": LevelError})
+	require.NoError(err, "SetLevels")
+	require.Equal(LevelDebug, GetLevel())
+	require.True(logged(loud.Debug, "loud debug 2"), "debug should be logged after lowering the default level")
+	require.False(logged(quiet.Warn, "quiet warn 2"), "module level should still apply")
+	require.True(logged(quiet.Error, "quiet error 2"), "error should be logged for a module at the error level")
+
+	// Removing a module prefix should affect existing loggers.
+	err = SetLevels(LevelDebug, nil)
+	require.NoError(err, "SetLevels")
+	require.True(logged(quiet.Warn, "quiet warn 3"), "warn should be logged after removing the module level")
+
+	// Adding a module prefix should affect existing loggers.
+	err = SetLevels(LevelInfo, map[string]Level{"test/loud": LevelError})
+	require.NoError(err, "SetLevels")
+	require.False(logged(loud.Warn, "loud warn 4"), "warn should not be logged after adding the module level")
+	require.True(logged(quiet.Info, "quiet info 4"), "info should be logged at the info level")
+	require.False(logged(quiet.Debug, "quiet debug 4"), "debug should not be logged after raising the default level")
+
+	// Loggers created after the levels are changed should use the new levels.
+	other := GetLogger("test/loud/other")
+	require.False(logged(other.Warn, "other warn 4"), "module level should apply to new loggers")
+}
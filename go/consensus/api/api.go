@@ -8,6 +8,7 @@ import (
 
 	beacon "github.com/oasislabs/oasis-core/go/beacon/api"
 	"github.com/oasislabs/oasis-core/go/common/cbor"
+	"github.com/oasislabs/oasis-core/go/common/crypto/hash"
 	"github.com/oasislabs/oasis-core/go/common/crypto/signature"
 	"github.com/oasislabs/oasis-core/go/common/errors"
 	"github.com/oasislabs/oasis-core/go/common/node"
@@ -41,6 +42,10 @@ var (
 	// ErrVersionNotFound is the error returned when the given version (height) cannot be found,
 	// possibly because it was pruned.
 	ErrVersionNotFound = errors.New(moduleName, 3, "consensus: version not found")
+
+	// ErrNoPendingTransaction is the error returned when there is no pending
+	// transaction that could be replaced.
+	ErrNoPendingTransaction = errors.New(moduleName, 4, "consensus: no pending transaction")
 )
 
 // ClientBackend is a limited consensus interface used by clients that connect to the local full
@@ -77,6 +82,15 @@ type ClientBackend interface {
 	// NOTE: Any of these transactions could be invalid.
 	GetTransactions(ctx context.Context, height int64) ([][]byte, error)
 
+	// GetTransactionsWithResults returns a list of transactions and their
+	// execution results, contained within a consensus block at a specific
+	// height.
+	GetTransactionsWithResults(ctx context.Context, height int64) (*TransactionsWithResults, error)
+
+	// GetUnconfirmedTransactions returns a list of transactions currently
+	// pending in the local mempool, ordered by their position.
+	GetUnconfirmedTransactions(ctx context.Context, req *GetUnconfirmedTransactionsRequest) ([]*UnconfirmedTransaction, error)
+
 	// WatchBlocks returns a channel that produces a stream of consensus
 	// blocks as they are being finalized.
 	WatchBlocks(ctx context.Context) (<-chan *Block, pubsub.ClosableSubscription, error)
@@ -103,6 +117,65 @@ type Block struct {
 	Meta cbor.RawMessage `json:"meta"`
 }
 
+// TransactionsWithResults is GetTransactionsWithResults response.
+//
+// Results[i] are the results of executing Transactions[i].
+type TransactionsWithResults struct {
+	Transactions [][]byte  `json:"transactions"`
+	Results      []*Result `json:"results"`
+}
+
+// TransactionError is a transaction execution error.
+type TransactionError struct {
+	// Module is the module of the error. It is empty on success.
+	Module string `json:"module,omitempty"`
+	// Code is the code of the error. It is zero on success.
+	Code uint32 `json:"code,omitempty"`
+	// Message is the error message.
+	Message string `json:"message,omitempty"`
+}
+
+// Event is a consensus service event that may be emitted during processing
+// of a transaction.
+type Event struct {
+	Staking  *staking.Event  `json:"staking,omitempty"`
+	Registry *registry.Event `json:"registry,omitempty"`
+	RootHash *roothash.Event `json:"roothash,omitempty"`
+}
+
+// Result is a transaction execution result.
+type Result struct {
+	Error   TransactionError `json:"error"`
+	Events  []*Event         `json:"events"`
+	GasUsed transaction.Gas  `json:"gas_used"`
+}
+
+// IsSuccess returns true if transaction execution was successful.
+func (r *Result) IsSuccess() bool {
+	return r.Error.Code == errors.CodeNoError
+}
+
+// GetUnconfirmedTransactionsRequest is a GetUnconfirmedTransactions request.
+type GetUnconfirmedTransactionsRequest struct {
+	// Signer restricts the returned transactions to the ones signed by the
+	// given signer. If nil, all pending transactions are returned.
+	Signer *signature.PublicKey `json:"signer,omitempty"`
+}
+
+// UnconfirmedTransaction is a transaction pending in the mempool.
+type UnconfirmedTransaction struct {
+	// Hash is the hash of the raw transaction.
+	Hash hash.Hash `json:"hash"`
+	// Position is the estimated number of pending transactions that will be
+	// considered for inclusion before this one.
+	Position uint64 `json:"position"`
+
+	// Signer is the public key of the transaction signer.
+	Signer signature.PublicKey `json:"signer"`
+	// Transaction is the decoded transaction.
+	Transaction *transaction.Transaction `json:"transaction"`
+}
+
 // Status is the current status overview.
 type Status struct {
 	// ConsensusVersion is the version of the consensus protocol that the node is using.
@@ -21,33 +21,37 @@ var (
 	// methodSubmitTx is the SubmitTx method.
 	methodSubmitTx = serviceName.NewMethod("SubmitTx", transaction.SignedTransaction{})
 	// methodStateToGenesis is the StateToGenesis method.
-	methodStateToGenesis = serviceName.NewMethod("StateToGenesis", int64(0))
+	methodStateToGenesis = serviceName.NewMethod("StateToGenesis", int64(0)).WithReadOnly()
 	// methodEstimateGas is the EstimateGas method.
-	methodEstimateGas = serviceName.NewMethod("EstimateGas", &EstimateGasRequest{})
+	methodEstimateGas = serviceName.NewMethod("EstimateGas", &EstimateGasRequest{}).WithReadOnly()
 	// methodGetSignerNonce is a GetSignerNonce method.
-	methodGetSignerNonce = serviceName.NewMethod("GetSignerNonce", &GetSignerNonceRequest{})
+	methodGetSignerNonce = serviceName.NewMethod("GetSignerNonce", &GetSignerNonceRequest{}).WithReadOnly()
 	// methodGetEpoch is the GetEpoch method.
-	methodGetEpoch = serviceName.NewMethod("GetEpoch", int64(0))
+	methodGetEpoch = serviceName.NewMethod("GetEpoch", int64(0)).WithReadOnly()
 	// methodWaitEpoch is the WaitEpoch method.
-	methodWaitEpoch = serviceName.NewMethod("WaitEpoch", epochtime.EpochTime(0))
+	methodWaitEpoch = serviceName.NewMethod("WaitEpoch", epochtime.EpochTime(0)).WithReadOnly()
 	// methodGetBlock is the GetBlock method.
-	methodGetBlock = serviceName.NewMethod("GetBlock", int64(0))
+	methodGetBlock = serviceName.NewMethod("GetBlock", int64(0)).WithReadOnly()
 	// methodGetTransactions is the GetTransactions method.
-	methodGetTransactions = serviceName.NewMethod("GetTransactions", int64(0))
+	methodGetTransactions = serviceName.NewMethod("GetTransactions", int64(0)).WithReadOnly()
+	// methodGetTransactionsWithResults is the GetTransactionsWithResults method.
+	methodGetTransactionsWithResults = serviceName.NewMethod("GetTransactionsWithResults", int64(0)).WithReadOnly()
+	// methodGetUnconfirmedTransactions is the GetUnconfirmedTransactions method.
+	methodGetUnconfirmedTransactions = serviceName.NewMethod("GetUnconfirmedTransactions", GetUnconfirmedTransactionsRequest{}).WithReadOnly()
 	// methodGetGenesisDocument is the GetGenesisDocument method.
-	methodGetGenesisDocument = serviceName.NewMethod("GetGenesisDocument", nil)
+	methodGetGenesisDocument = serviceName.NewMethod("GetGenesisDocument", nil).WithReadOnly()
 	// methodGetStatus is the GetStatus method.
-	methodGetStatus = serviceName.NewMethod("GetStatus", nil)
+	methodGetStatus = serviceName.NewMethod("GetStatus", nil).WithReadOnly()
 
 	// methodWatchBlocks is the WatchBlocks method.
-	methodWatchBlocks = serviceName.NewMethod("WatchBlocks", nil)
+	methodWatchBlocks = serviceName.NewMethod("WatchBlocks", nil).WithReadOnly()
 
 	// methodGetSignedHeader is the GetSignedHeader method.
-	methodGetSignedHeader = lightServiceName.NewMethod("GetSignedHeader", int64(0))
+	methodGetSignedHeader = lightServiceName.NewMethod("GetSignedHeader", int64(0)).WithReadOnly()
 	// methodGetValidatorSet is the GetValidatorSet method.
-	methodGetValidatorSet = lightServiceName.NewMethod("GetValidatorSet", int64(0))
+	methodGetValidatorSet = lightServiceName.NewMethod("GetValidatorSet", int64(0)).WithReadOnly()
 	// methodGetParameters is the GetParameters method.
-	methodGetParameters = lightServiceName.NewMethod("GetParameters", int64(0))
+	methodGetParameters = lightServiceName.NewMethod("GetParameters", int64(0)).WithReadOnly()
 
 	// serviceDesc is the gRPC service descriptor.
 	serviceDesc = grpc.ServiceDesc{
@@ -86,6 +90,14 @@ var (
 				MethodName: methodGetTransactions.ShortName(),
 				Handler:    handlerGetTransactions,
 			},
+			{
+				MethodName: methodGetTransactionsWithResults.ShortName(),
+				Handler:    handlerGetTransactionsWithResults,
+			},
+			{
+				MethodName: methodGetUnconfirmedTransactions.ShortName(),
+				Handler:    handlerGetUnconfirmedTransactions,
+			},
 			{
 				MethodName: methodGetGenesisDocument.ShortName(),
 				Handler:    handlerGetGenesisDocument,
@@ -309,6 +321,52 @@ func handlerGetTransactions( // nolint: golint
 	return interceptor(ctx, height, info, handler)
 }
 
+func handlerGetTransactionsWithResults( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var height int64
+	if err := dec(&height); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientBackend).GetTransactionsWithResults(ctx, height)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetTransactionsWithResults.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientBackend).GetTransactionsWithResults(ctx, req.(int64))
+	}
+	return interceptor(ctx, height, info, handler)
+}
+
+func handlerGetUnconfirmedTransactions( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var req GetUnconfirmedTransactionsRequest
+	if err := dec(&req); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientBackend).GetUnconfirmedTransactions(ctx, &req)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetUnconfirmedTransactions.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientBackend).GetUnconfirmedTransactions(ctx, req.(*GetUnconfirmedTransactionsRequest))
+	}
+	return interceptor(ctx, &req, info, handler)
+}
+
 func handlerGetGenesisDocument( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -445,13 +503,13 @@ func handlerGetParameters( // nolint: golint
 }
 
 // RegisterService registers a new client backend service with the given gRPC server.
-func RegisterService(server *grpc.Server, service ClientBackend) {
+func RegisterService(server cmnGrpc.ServiceRegistrar, service ClientBackend) {
 	server.RegisterService(&serviceDesc, service)
 	RegisterLightService(server, service)
 }
 
 // RegisterLightService registers a new light client backend service with the given gRPC server.
-func RegisterLightService(server *grpc.Server, service LightClientBackend) {
+func RegisterLightService(server cmnGrpc.ServiceRegistrar, service LightClientBackend) {
 	server.RegisterService(&lightServiceDesc, service)
 }
 
@@ -548,6 +606,22 @@ func (c *consensusClient) GetTransactions(ctx context.Context, height int64) ([]
 	return rsp, nil
 }
 
+func (c *consensusClient) GetTransactionsWithResults(ctx context.Context, height int64) (*TransactionsWithResults, error) {
+	var rsp TransactionsWithResults
+	if err := c.conn.Invoke(ctx, methodGetTransactionsWithResults.FullName(), height, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (c *consensusClient) GetUnconfirmedTransactions(ctx context.Context, req *GetUnconfirmedTransactionsRequest) ([]*UnconfirmedTransaction, error) {
+	var rsp []*UnconfirmedTransaction
+	if err := c.conn.Invoke(ctx, methodGetUnconfirmedTransactions.FullName(), req, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
 func (c *consensusClient) GetGenesisDocument(ctx context.Context) (*genesis.Document, error) {
 	var rsp genesis.Document
 	if err := c.conn.Invoke(ctx, methodGetGenesisDocument.FullName(), nil, &rsp); err != nil {
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -17,6 +18,11 @@ import (
 const (
 	maxSubmissionRetryElapsedTime = 60 * time.Second
 	maxSubmissionRetryInterval    = 10 * time.Second
+
+	// replacementFeeBumpPercent is the minimum percentage by which the fee of
+	// a replacement transaction is increased over the replaced transaction in
+	// case the fee is estimated.
+	replacementFeeBumpPercent = 10
 )
 
 // PriceDiscovery is the consensus fee price discovery interface.
@@ -50,16 +56,61 @@ type SubmissionManager interface {
 	//
 	// It also automatically handles retries in case the nonce was incorrectly estimated.
 	SignAndSubmitTx(ctx context.Context, signer signature.Signer, tx *transaction.Transaction) error
+
+	// SignAndReplaceTx replaces a transaction pending in the local mempool that has the same
+	// signer and nonce as the passed transaction, signs the transaction with the passed signer
+	// and submits it to consensus backend.
+	//
+	// In case the fee is not specified, it is estimated and increased if needed so that it is
+	// higher than the fee of the pending transaction.
+	SignAndReplaceTx(ctx context.Context, signer signature.Signer, tx *transaction.Transaction) error
 }
 
 type submissionManager struct {
-	backend        Backend
+	backend        ClientBackend
 	priceDiscovery PriceDiscovery
 	maxFee         quantity.Quantity
 
 	logger *logging.Logger
 }
 
+func (m *submissionManager) estimateFee(ctx context.Context, signer signature.Signer, tx *transaction.Transaction) (*transaction.Fee, error) {
+	// Estimate amount of gas needed to perform the update.
+	gas, err := m.backend.EstimateGas(ctx, &EstimateGasRequest{Caller: signer.Public(), Transaction: tx})
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate gas: %w", err)
+	}
+
+	// Fetch current consensus gas price and compute the fee.
+	amount, err := m.priceDiscovery.GasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine gas price: %w", err)
+	}
+	var gasQuantity quantity.Quantity
+	if err = gasQuantity.FromUint64(uint64(gas)); err != nil {
+		return nil, fmt.Errorf("failed to compute fee amount: %w", err)
+	}
+	if err = amount.Mul(&gasQuantity); err != nil {
+		return nil, fmt.Errorf("failed to compute fee amount: %w", err)
+	}
+
+	return &transaction.Fee{
+		Gas:    gas,
+		Amount: *amount,
+	}, nil
+}
+
+func (m *submissionManager) checkMaxFee(fee *transaction.Fee) error {
+	// Verify that the fee doesn't exceed a configured ceiling.
+	if !m.maxFee.IsZero() && fee.Amount.Cmp(&m.maxFee) == 1 {
+		return fmt.Errorf("computed fee exceeds configured maximum: %s (max: %s)",
+			fee.Amount,
+			m.maxFee,
+		)
+	}
+	return nil
+}
+
 func (m *submissionManager) signAndSubmitTx(ctx context.Context, signer signature.Signer, tx *transaction.Transaction) error {
 	// Update transaction nonce.
 	var err error
@@ -75,39 +126,14 @@ func (m *submissionManager) signAndSubmitTx(ctx context.Context, signer signatur
 
 	// In case the fee is not specified, perform fee estimation.
 	if tx.Fee == nil {
-		// Estimate amount of gas needed to perform the update.
-		var gas transaction.Gas
-		gas, err = m.backend.EstimateGas(ctx, &EstimateGasRequest{Caller: signer.Public(), Transaction: tx})
-		if err != nil {
-			return fmt.Errorf("failed to estimate gas: %w", err)
-		}
-
-		// Fetch current consensus gas price and compute the fee.
-		var amount *quantity.Quantity
-		amount, err = m.priceDiscovery.GasPrice(ctx)
-		if err != nil {
-			return fmt.Errorf("failed to determine gas price: %w", err)
-		}
-		var gasQuantity quantity.Quantity
-		if err = gasQuantity.FromUint64(uint64(gas)); err != nil {
-			return fmt.Errorf("failed to compute fee amount: %w", err)
-		}
-		if err = amount.Mul(&gasQuantity); err != nil {
-			return fmt.Errorf("failed to compute fee amount: %w", err)
-		}
-
-		// Verify that the fee doesn't exceed a configured ceiling.
-		if !m.maxFee.IsZero() && amount.Cmp(&m.maxFee) == 1 {
-			return fmt.Errorf("computed fee exceeds configured maximum: %s (max: %s)",
-				amount,
-				m.maxFee,
-			)
+		var fee *transaction.Fee
+		if fee, err = m.estimateFee(ctx, signer, tx); err != nil {
+			return err
 		}
-
-		tx.Fee = &transaction.Fee{
-			Gas:    gas,
-			Amount: *amount,
+		if err = m.checkMaxFee(fee); err != nil {
+			return err
 		}
+		tx.Fee = fee
 	}
 
 	// Sign the transaction.
@@ -120,13 +146,23 @@ func (m *submissionManager) signAndSubmitTx(ctx context.Context, signer signatur
 	}
 
 	if err = m.backend.SubmitTx(ctx, sigTx); err != nil {
-		if errors.Is(err, transaction.ErrInvalidNonce) {
+		switch {
+		case errors.Is(err, transaction.ErrInvalidNonce):
 			// Invalid nonce, retry submission.
 			m.logger.Debug("retrying transaction submission due to invalid nonce",
 				"account_id", signer.Public(),
 				"nonce", tx.Nonce,
 			)
 			return err
+		case errors.Is(err, transaction.ErrReplacementUnderpriced):
+			// Another transaction with the same nonce is pending (e.g., due to concurrent
+			// submissions by the same signer), retry submission with a new nonce.
+			m.logger.Debug("retrying transaction submission due to conflicting pending transaction",
+				"account_id", signer.Public(),
+				"nonce", tx.Nonce,
+				"err", err,
+			)
+			return err
 		}
 		return backoff.Permanent(err)
 	}
@@ -144,8 +180,81 @@ func (m *submissionManager) SignAndSubmitTx(ctx context.Context, signer signatur
 	}, backoff.WithContext(sched, ctx))
 }
 
+// minReplacementFee returns the fee used for a replacement transaction in case the estimated fee
+// is not higher than the fee of the replaced transaction.
+func minReplacementFee(pendingFee *quantity.Quantity) *quantity.Quantity {
+	fee := pendingFee.ToBigInt()
+	bump := new(big.Int).Mul(fee, big.NewInt(replacementFeeBumpPercent))
+	bump.Quo(bump, big.NewInt(100))
+	bump.Add(bump, big.NewInt(1))
+
+	var q quantity.Quantity
+	_ = q.FromBigInt(fee.Add(fee, bump))
+	return &q
+}
+
+func (m *submissionManager) SignAndReplaceTx(ctx context.Context, signer signature.Signer, tx *transaction.Transaction) error {
+	// Find the pending transaction that should be replaced.
+	signerID := signer.Public()
+	pending, err := m.backend.GetUnconfirmedTransactions(ctx, &GetUnconfirmedTransactionsRequest{Signer: &signerID})
+	if err != nil {
+		return fmt.Errorf("failed to get unconfirmed transactions: %w", err)
+	}
+	var pendingFee quantity.Quantity
+	var found bool
+	for _, ptx := range pending {
+		if ptx.Transaction.Nonce != tx.Nonce {
+			continue
+		}
+		if ptx.Transaction.Fee != nil {
+			pendingFee = *ptx.Transaction.Fee.Amount.Clone()
+		}
+		found = true
+		break
+	}
+	if !found {
+		return ErrNoPendingTransaction
+	}
+
+	// In case the fee is not specified, perform fee estimation and make sure that the fee is
+	// higher than the fee of the pending transaction.
+	if tx.Fee == nil {
+		var fee *transaction.Fee
+		if fee, err = m.estimateFee(ctx, signer, tx); err != nil {
+			return err
+		}
+		if fee.Amount.Cmp(&pendingFee) <= 0 {
+			fee.Amount = *minReplacementFee(&pendingFee)
+		}
+		if err = m.checkMaxFee(fee); err != nil {
+			return err
+		}
+		tx.Fee = fee
+	}
+	if tx.Fee.Amount.Cmp(&pendingFee) <= 0 {
+		return transaction.ErrReplacementUnderpriced
+	}
+
+	sigTx, err := transaction.Sign(signer, tx)
+	if err != nil {
+		m.logger.Error("failed to sign transaction",
+			"err", err,
+		)
+		return err
+	}
+
+	m.logger.Debug("replacing pending transaction",
+		"account_id", signerID,
+		"nonce", tx.Nonce,
+		"fee", tx.Fee.Amount,
+		"pending_fee", pendingFee,
+	)
+
+	return m.backend.SubmitTx(ctx, sigTx)
+}
+
 // NewSubmissionManager creates a new transaction submission manager.
-func NewSubmissionManager(backend Backend, priceDiscovery PriceDiscovery, maxFee uint64) SubmissionManager {
+func NewSubmissionManager(backend ClientBackend, priceDiscovery PriceDiscovery, maxFee uint64) SubmissionManager {
 	sm := &submissionManager{
 		backend:        backend,
 		priceDiscovery: priceDiscovery,
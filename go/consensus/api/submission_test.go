@@ -0,0 +1,159 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasislabs/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasislabs/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasislabs/oasis-core/go/common/errors"
+	"github.com/oasislabs/oasis-core/go/common/quantity"
+	"github.com/oasislabs/oasis-core/go/consensus/api/transaction"
+)
+
+type submissionTestBackend struct {
+	// Backend is embedded to satisfy the interface, unused methods panic.
+	Backend
+
+	gas       transaction.Gas
+	pending   []*UnconfirmedTransaction
+	submitErr []error
+	submitted []*transaction.SignedTransaction
+}
+
+func (b *submissionTestBackend) GetSignerNonce(ctx context.Context, req *GetSignerNonceRequest) (uint64, error) {
+	return 0, nil
+}
+
+func (b *submissionTestBackend) EstimateGas(ctx context.Context, req *EstimateGasRequest) (transaction.Gas, error) {
+	return b.gas, nil
+}
+
+func (b *submissionTestBackend) GetUnconfirmedTransactions(ctx context.Context, req *GetUnconfirmedTransactionsRequest) ([]*UnconfirmedTransaction, error) {
+	return b.pending, nil
+}
+
+func (b *submissionTestBackend) SubmitTx(ctx context.Context, tx *transaction.SignedTransaction) error {
+	b.submitted = append(b.submitted, tx)
+	if len(b.submitErr) == 0 {
+		return nil
+	}
+	err := b.submitErr[0]
+	b.submitErr = b.submitErr[1:]
+	return err
+}
+
+func newTestFee(amount uint64) *transaction.Fee {
+	var fee transaction.Fee
+	_ = fee.Amount.FromUint64(amount)
+	return &fee
+}
+
+func TestMinReplacementFee(t *testing.T) {
+	require := require.New(t)
+
+	for _, tc := range []struct {
+		pending  uint64
+		expected uint64
+	}{
+		{0, 1},
+		{9, 10},
+		{100, 111},
+		{1000, 1101},
+	} {
+		var pending quantity.Quantity
+		require.NoError(pending.FromUint64(tc.pending), "FromUint64")
+
+		var expected quantity.Quantity
+		require.NoError(expected.FromUint64(tc.expected), "FromUint64")
+
+		fee := minReplacementFee(&pending)
+		require.Zero(fee.Cmp(&expected), "minReplacementFee(%d) should be %d (got: %s)", tc.pending, tc.expected, fee)
+	}
+}
+
+func TestSignAndReplaceTx(t *testing.T) {
+	require := require.New(t)
+
+	signature.SetChainContext("test: oasis-core tests")
+	signer, err := memorySigner.NewSigner(rand.Reader)
+	require.NoError(err, "NewSigner")
+
+	pd, err := NewStaticPriceDiscovery(1)
+	require.NoError(err, "NewStaticPriceDiscovery")
+
+	newPending := func(nonce, fee uint64) []*UnconfirmedTransaction {
+		tx := transaction.NewTransaction(nonce, newTestFee(fee), "test.Method", nil)
+		return []*UnconfirmedTransaction{{Signer: signer.Public(), Transaction: tx}}
+	}
+	submittedFee := func(backend *submissionTestBackend) *quantity.Quantity {
+		require.Len(backend.submitted, 1, "exactly one transaction should be submitted")
+		var tx transaction.Transaction
+		require.NoError(backend.submitted[0].Open(&tx), "Open")
+		return &tx.Fee.Amount
+	}
+
+	// Replacing a transaction that is not pending should fail.
+	backend := &submissionTestBackend{gas: 10, pending: newPending(1, 100)}
+	sm := NewSubmissionManager(backend, pd, 0)
+	err = sm.SignAndReplaceTx(context.Background(), signer, transaction.NewTransaction(2, nil, "test.Method", nil))
+	require.Equal(ErrNoPendingTransaction, err, "SignAndReplaceTx should fail without a pending transaction")
+	require.Empty(backend.submitted)
+
+	// An estimated fee that is too low should be bumped over the pending fee.
+	backend = &submissionTestBackend{gas: 10, pending: newPending(1, 100)}
+	sm = NewSubmissionManager(backend, pd, 0)
+	err = sm.SignAndReplaceTx(context.Background(), signer, transaction.NewTransaction(1, nil, "test.Method", nil))
+	require.NoError(err, "SignAndReplaceTx")
+	require.Zero(submittedFee(backend).Cmp(&newTestFee(111).Amount), "estimated fee should be bumped")
+
+	// An estimated fee that is already high enough should be used as-is.
+	backend = &submissionTestBackend{gas: 1000, pending: newPending(1, 100)}
+	sm = NewSubmissionManager(backend, pd, 0)
+	err = sm.SignAndReplaceTx(context.Background(), signer, transaction.NewTransaction(1, nil, "test.Method", nil))
+	require.NoError(err, "SignAndReplaceTx")
+	require.Zero(submittedFee(backend).Cmp(&newTestFee(1000).Amount), "estimated fee should be used")
+
+	// The bumped fee must not exceed the configured maximum fee.
+	backend = &submissionTestBackend{gas: 10, pending: newPending(1, 100)}
+	sm = NewSubmissionManager(backend, pd, 110)
+	err = sm.SignAndReplaceTx(context.Background(), signer, transaction.NewTransaction(1, nil, "test.Method", nil))
+	require.Error(err, "SignAndReplaceTx should fail when exceeding the maximum fee")
+	require.Empty(backend.submitted)
+
+	// An explicit fee that is not higher than the pending fee should be rejected.
+	backend = &submissionTestBackend{gas: 10, pending: newPending(1, 100)}
+	sm = NewSubmissionManager(backend, pd, 0)
+	err = sm.SignAndReplaceTx(context.Background(), signer, transaction.NewTransaction(1, newTestFee(100), "test.Method", nil))
+	require.Equal(transaction.ErrReplacementUnderpriced, err, "SignAndReplaceTx should fail with an underpriced fee")
+	require.Empty(backend.submitted)
+}
+
+func TestSignAndSubmitTxReplaced(t *testing.T) {
+	require := require.New(t)
+
+	signature.SetChainContext("test: oasis-core tests")
+	signer, err := memorySigner.NewSigner(rand.Reader)
+	require.NoError(err, "NewSigner")
+
+	pd, err := NewStaticPriceDiscovery(1)
+	require.NoError(err, "NewStaticPriceDiscovery")
+
+	// Submission should be retried in case the fee is too low to replace a
+	// conflicting pending transaction.
+	backend := &submissionTestBackend{gas: 10, submitErr: []error{transaction.ErrReplacementUnderpriced}}
+	sm := NewSubmissionManager(backend, pd, 0)
+	err = sm.SignAndSubmitTx(context.Background(), signer, transaction.NewTransaction(0, nil, "test.Method", nil))
+	require.NoError(err, "SignAndSubmitTx")
+	require.Len(backend.submitted, 2, "submission should be retried")
+
+	// Submission should not be retried in case the transaction was replaced.
+	backend = &submissionTestBackend{gas: 10, submitErr: []error{transaction.ErrReplaced}}
+	sm = NewSubmissionManager(backend, pd, 0)
+	err = sm.SignAndSubmitTx(context.Background(), signer, transaction.NewTransaction(0, nil, "test.Method", nil))
+	require.True(errors.Is(err, transaction.ErrReplaced), "SignAndSubmitTx should fail with ErrReplaced")
+	require.Len(backend.submitted, 1, "submission should not be retried")
+}
@@ -22,6 +22,15 @@ var (
 	// ErrInvalidNonce is the error returned when a nonce is invalid.
 	ErrInvalidNonce = errors.New(moduleName, 1, "transaction: invalid nonce")
 
+	// ErrReplaced is the error returned when a pending transaction has been
+	// replaced by a transaction with the same nonce and a higher fee.
+	ErrReplaced = errors.New(moduleName, 4, "transaction: replaced by a transaction with a higher fee")
+
+	// ErrReplacementUnderpriced is the error returned when a transaction
+	// would replace a pending transaction with the same nonce without paying
+	// a higher fee.
+	ErrReplacementUnderpriced = errors.New(moduleName, 5, "transaction: replacement fee too low")
+
 	// SignatureContext is the context used for signing transactions.
 	SignatureContext = signature.NewContext("oasis-core/consensus: tx", signature.WithChainSeparation())
 
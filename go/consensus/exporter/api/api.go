@@ -0,0 +1,103 @@
+// Package api implements the consensus event exporter API.
+package api
+
+import (
+	"context"
+
+	"github.com/oasislabs/oasis-core/go/common/crypto/hash"
+	"github.com/oasislabs/oasis-core/go/common/errors"
+	registry "github.com/oasislabs/oasis-core/go/registry/api"
+	roothash "github.com/oasislabs/oasis-core/go/roothash/api"
+	staking "github.com/oasislabs/oasis-core/go/staking/api"
+)
+
+// ModuleName is a unique module name for the event exporter module.
+const ModuleName = "consensus/exporter"
+
+const (
+	// AppStaking is the name of the staking application.
+	AppStaking = "staking"
+	// AppRegistry is the name of the registry application.
+	AppRegistry = "registry"
+	// AppRootHash is the name of the roothash application.
+	AppRootHash = "roothash"
+
+	// MaxEventsRange is the maximum number of heights that can be queried
+	// in a single GetEventsRange call.
+	MaxEventsRange = 1000
+)
+
+var (
+	// ErrInvalidRange is the error returned when the requested height range
+	// is invalid.
+	ErrInvalidRange = errors.New(ModuleName, 1, "exporter: invalid height range")
+
+	// ErrNotExported is the error returned when the requested heights have
+	// not been exported yet.
+	ErrNotExported = errors.New(ModuleName, 2, "exporter: heights not yet exported")
+
+	// ErrNotAvailable is the error returned when the requested heights are
+	// lower than the height at which exporting started.
+	ErrNotAvailable = errors.New(ModuleName, 3, "exporter: heights not available")
+)
+
+// Event is an exported consensus event.
+type Event struct {
+	// Height is the consensus height at which the event was emitted.
+	Height int64 `json:"height"`
+	// TxHash is the hash of the transaction that emitted the event. It is
+	// the empty hash for events emitted outside of transactions.
+	TxHash hash.Hash `json:"tx_hash"`
+	// App is the name of the application that emitted the event.
+	App string `json:"app"`
+
+	Staking  *staking.Event  `json:"staking,omitempty"`
+	Registry *registry.Event `json:"registry,omitempty"`
+	RootHash *roothash.Event `json:"roothash,omitempty"`
+}
+
+// EventFilter is a filter for exported events.
+type EventFilter struct {
+	// Apps restricts events to the given applications. If empty, events
+	// from all applications match.
+	Apps []string `json:"apps,omitempty"`
+	// TxHash restricts events to the ones emitted by the given transaction.
+	TxHash *hash.Hash `json:"tx_hash,omitempty"`
+}
+
+// Matches returns true iff the given event matches the filter.
+func (f *EventFilter) Matches(ev *Event) bool {
+	if f.TxHash != nil && !f.TxHash.Equal(&ev.TxHash) {
+		return false
+	}
+	if len(f.Apps) == 0 {
+		return true
+	}
+	for _, app := range f.Apps {
+		if app == ev.App {
+			return true
+		}
+	}
+	return false
+}
+
+// GetEventsRangeRequest is a GetEventsRange request.
+type GetEventsRangeRequest struct {
+	// From is the first height (inclusive).
+	From int64 `json:"from"`
+	// To is the last height (inclusive).
+	To int64 `json:"to"`
+	// Filter is the event filter.
+	Filter EventFilter `json:"filter"`
+}
+
+// Backend is the event exporter interface.
+type Backend interface {
+	// GetLastHeight returns the last consensus height for which events
+	// have been exported.
+	GetLastHeight(ctx context.Context) (int64, error)
+
+	// GetEventsRange returns all exported events in the given height range
+	// that match the filter, ordered by height.
+	GetEventsRange(ctx context.Context, request *GetEventsRangeRequest) ([]*Event, error)
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	cmnGrpc "github.com/oasislabs/oasis-core/go/common/grpc"
+)
+
+var (
+	// serviceName is the gRPC service name.
+	serviceName = cmnGrpc.NewServiceName("ConsensusExporter")
+
+	// methodGetLastHeight is the GetLastHeight method.
+	methodGetLastHeight = serviceName.NewMethod("GetLastHeight", nil)
+	// methodGetEventsRange is the GetEventsRange method.
+	methodGetEventsRange = serviceName.NewMethod("GetEventsRange", GetEventsRangeRequest{})
+
+	// serviceDesc is the gRPC service descriptor.
+	serviceDesc = grpc.ServiceDesc{
+		ServiceName: string(serviceName),
+		HandlerType: (*Backend)(nil),
+		Methods: []grpc.MethodDesc{
+			{
+				MethodName: methodGetLastHeight.ShortName(),
+				Handler:    handlerGetLastHeight,
+			},
+			{
+				MethodName: methodGetEventsRange.ShortName(),
+				Handler:    handlerGetEventsRange,
+			},
+		},
+		Streams: []grpc.StreamDesc{},
+	}
+)
+
+func handlerGetLastHeight( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	if interceptor == nil {
+		return srv.(Backend).GetLastHeight(ctx)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetLastHeight.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetLastHeight(ctx)
+	}
+	return interceptor(ctx, nil, info, handler)
+}
+
+func handlerGetEventsRange( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var req GetEventsRangeRequest
+	if err := dec(&req); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetEventsRange(ctx, &req)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetEventsRange.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetEventsRange(ctx, req.(*GetEventsRangeRequest))
+	}
+	return interceptor(ctx, &req, info, handler)
+}
+
+// RegisterService registers a new event exporter service with the given
+// gRPC server.
+func RegisterService(server cmnGrpc.ServiceRegistrar, service Backend) {
+	server.RegisterService(&serviceDesc, service)
+}
+
+type exporterClient struct {
+	conn *grpc.ClientConn
+}
+
+func (c *exporterClient) GetLastHeight(ctx context.Context) (int64, error) {
+	var rsp int64
+	if err := c.conn.Invoke(ctx, methodGetLastHeight.FullName(), nil, &rsp); err != nil {
+		return 0, err
+	}
+	return rsp, nil
+}
+
+func (c *exporterClient) GetEventsRange(ctx context.Context, request *GetEventsRangeRequest) ([]*Event, error) {
+	var rsp []*Event
+	if err := c.conn.Invoke(ctx, methodGetEventsRange.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+// NewExporterClient creates a new gRPC event exporter client service.
+func NewExporterClient(c *grpc.ClientConn) Backend {
+	return &exporterClient{c}
+}
@@ -0,0 +1,87 @@
+package exporter
+
+import (
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/oasislabs/oasis-core/go/common/cbor"
+	"github.com/oasislabs/oasis-core/go/common/keyformat"
+	"github.com/oasislabs/oasis-core/go/consensus/exporter/api"
+	"github.com/oasislabs/oasis-core/go/consensus/follower"
+)
+
+const dbVersion = 1
+
+// eventKeyFmt is the event key format (height, index).
+//
+// Value is CBOR-serialized api.Event.
+var eventKeyFmt = keyformat.New(0x02, uint64(0), uint32(0))
+
+// DB is the append-only exported event database.
+type DB struct {
+	*follower.DB
+}
+
+func newDB(fn string) (*DB, error) {
+	db, err := follower.OpenDB(fn, "consensus/exporter", dbVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &DB{db}, nil
+}
+
+// commit appends the events emitted at the given height.
+func (d *DB) commit(height int64, events []*api.Event) error {
+	return d.Commit(height, func(tx *badger.Txn) error {
+		for idx, ev := range events {
+			if err := tx.Set(eventKeyFmt.Encode(uint64(height), uint32(idx)), cbor.Marshal(ev)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// getEventsRange returns all events in the given (inclusive) height range
+// that match the filter.
+func (d *DB) getEventsRange(from, to int64, filter *api.EventFilter) ([]*api.Event, error) {
+	events := []*api.Event{}
+	txErr := d.View(func(tx *badger.Txn) error {
+		it := tx.NewIterator(badger.IteratorOptions{
+			Prefix:         eventKeyFmt.Encode(),
+			PrefetchValues: true,
+			PrefetchSize:   100,
+		})
+		defer it.Close()
+
+		for it.Seek(eventKeyFmt.Encode(uint64(from))); it.Valid(); it.Next() {
+			item := it.Item()
+
+			var (
+				height uint64
+				idx    uint32
+			)
+			if !eventKeyFmt.Decode(item.Key(), &height, &idx) {
+				// This should not happen as the Badger iterator should take care of it.
+				panic("consensus/exporter: bad iterator")
+			}
+			if int64(height) > to {
+				break
+			}
+
+			var ev api.Event
+			if err := item.Value(func(val []byte) error {
+				return cbor.Unmarshal(val, &ev)
+			}); err != nil {
+				return err
+			}
+			if filter.Matches(&ev) {
+				events = append(events, &ev)
+			}
+		}
+		return nil
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	return events, nil
+}
@@ -0,0 +1,75 @@
+package exporter
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasislabs/oasis-core/go/common/crypto/hash"
+	"github.com/oasislabs/oasis-core/go/consensus/exporter/api"
+	registry "github.com/oasislabs/oasis-core/go/registry/api"
+	staking "github.com/oasislabs/oasis-core/go/staking/api"
+)
+
+func TestExporterDB(t *testing.T) {
+	require := require.New(t)
+
+	// Create a new random temporary directory under /tmp.
+	dataDir, err := ioutil.TempDir("", "oasis-consensus-exporter-test_")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dataDir)
+
+	db, err := newDB(filepath.Join(dataDir, DbFilename))
+	require.NoError(err, "newDB")
+	defer db.Close()
+
+	lastHeight, err := db.LastHeight()
+	require.NoError(err, "LastHeight")
+	require.EqualValues(0, lastHeight)
+
+	var txHash hash.Hash
+	txHash.FromBytes([]byte("exporter test tx"))
+
+	err = db.commit(10, []*api.Event{
+		{Height: 10, TxHash: txHash, App: api.AppStaking, Staking: &staking.Event{TxHash: txHash}},
+		{Height: 10, App: api.AppRegistry, Registry: &registry.Event{}},
+	})
+	require.NoError(err, "commit")
+	err = db.commit(11, nil)
+	require.NoError(err, "commit")
+	err = db.commit(12, []*api.Event{
+		{Height: 12, App: api.AppRegistry, Registry: &registry.Event{}},
+	})
+	require.NoError(err, "commit")
+
+	err = db.commit(12, nil)
+	require.Error(err, "commit at same height should fail")
+
+	meta, err := db.Metadata()
+	require.NoError(err, "Metadata")
+	require.EqualValues(10, meta.FirstHeight, "first height should be the first committed height")
+	require.EqualValues(12, meta.LastHeight)
+
+	events, err := db.getEventsRange(1, 12, &api.EventFilter{})
+	require.NoError(err, "getEventsRange")
+	require.Len(events, 3)
+	require.EqualValues(10, events[0].Height)
+	require.EqualValues(12, events[2].Height)
+
+	events, err = db.getEventsRange(11, 11, &api.EventFilter{})
+	require.NoError(err, "getEventsRange")
+	require.Len(events, 0)
+
+	events, err = db.getEventsRange(1, 12, &api.EventFilter{Apps: []string{api.AppRegistry}})
+	require.NoError(err, "getEventsRange")
+	require.Len(events, 2)
+
+	events, err = db.getEventsRange(1, 12, &api.EventFilter{TxHash: &txHash})
+	require.NoError(err, "getEventsRange")
+	require.Len(events, 1)
+	require.Equal(api.AppStaking, events[0].App)
+	require.True(events[0].TxHash.Equal(&txHash))
+}
@@ -0,0 +1,211 @@
+// Package exporter implements the consensus event exporter.
+//
+// The exporter follows consensus blocks and stores all events emitted by the
+// staking, registry and roothash applications in a local append-only
+// database so that they can be queried by height range. Exported events can
+// optionally also be appended to a newline-delimited JSON file.
+package exporter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasislabs/oasis-core/go/common/service"
+	consensus "github.com/oasislabs/oasis-core/go/consensus/api"
+	"github.com/oasislabs/oasis-core/go/consensus/exporter/api"
+	"github.com/oasislabs/oasis-core/go/consensus/follower"
+)
+
+const (
+	// CfgEnabled enables the consensus event exporter.
+	CfgEnabled = "consensus.exporter.enabled"
+	// CfgJSONSink configures the path of the newline-delimited JSON file
+	// exported events are appended to.
+	CfgJSONSink = "consensus.exporter.json_sink"
+	// CfgStartHeight configures the consensus height at which exporting
+	// starts when the database is empty.
+	CfgStartHeight = "consensus.exporter.start_height"
+
+	// DbFilename is the filename of the exported event database.
+	DbFilename = "consensus-events.db"
+)
+
+// Flags has the configuration flags.
+var Flags = flag.NewFlagSet("", flag.ContinueOnError)
+
+var _ api.Backend = (*Service)(nil)
+
+// Service is the consensus event exporter service.
+type Service struct {
+	service.BaseBackgroundService
+
+	consensus consensus.Backend
+	db        *DB
+	sink      *os.File
+	follower  *follower.Follower
+
+	ctx       context.Context
+	cancelCtx context.CancelFunc
+}
+
+// GetLastHeight implements api.Backend.
+func (s *Service) GetLastHeight(ctx context.Context) (int64, error) {
+	return s.db.LastHeight()
+}
+
+// GetEventsRange implements api.Backend.
+func (s *Service) GetEventsRange(ctx context.Context, request *api.GetEventsRangeRequest) ([]*api.Event, error) {
+	if request.From < 1 || request.To < request.From || request.To-request.From >= api.MaxEventsRange {
+		return nil, api.ErrInvalidRange
+	}
+
+	meta, err := s.db.Metadata()
+	if err != nil {
+		return nil, err
+	}
+	if request.To > meta.LastHeight {
+		return nil, api.ErrNotExported
+	}
+	if request.From < meta.FirstHeight {
+		return nil, api.ErrNotAvailable
+	}
+
+	return s.db.getEventsRange(request.From, request.To, &request.Filter)
+}
+
+func (s *Service) worker() {
+	defer s.BaseBackgroundService.Stop()
+
+	s.follower.Run(s.ctx)
+}
+
+func (s *Service) exportHeight(ctx context.Context, height int64) error {
+	var events []*api.Event
+
+	stakingEvents, err := s.consensus.Staking().GetEvents(ctx, height)
+	if err != nil {
+		return err
+	}
+	for i := range stakingEvents {
+		ev := stakingEvents[i]
+		events = append(events, &api.Event{
+			Height:  height,
+			TxHash:  ev.TxHash,
+			App:     api.AppStaking,
+			Staking: &ev,
+		})
+	}
+
+	registryEvents, err := s.consensus.Registry().GetEvents(ctx, height)
+	if err != nil {
+		return err
+	}
+	for i := range registryEvents {
+		ev := registryEvents[i]
+		events = append(events, &api.Event{
+			Height:   height,
+			TxHash:   ev.TxHash,
+			App:      api.AppRegistry,
+			Registry: &ev,
+		})
+	}
+
+	roothashEvents, err := s.consensus.RootHash().GetEvents(ctx, height)
+	if err != nil {
+		return err
+	}
+	for i := range roothashEvents {
+		ev := roothashEvents[i]
+		events = append(events, &api.Event{
+			Height:   height,
+			TxHash:   ev.TxHash,
+			App:      api.AppRootHash,
+			RootHash: &ev,
+		})
+	}
+
+	// Write to the sink before committing so that events are delivered at
+	// least once, even if the node crashes in between.
+	if s.sink != nil {
+		for _, ev := range events {
+			var data []byte
+			if data, err = json.Marshal(ev); err != nil {
+				return err
+			}
+			if _, err = s.sink.Write(append(data, '\n')); err != nil {
+				return fmt.Errorf("failed to write to JSON sink: %w", err)
+			}
+		}
+	}
+
+	return s.db.commit(height, events)
+}
+
+// Start starts the service.
+func (s *Service) Start() error {
+	go s.worker()
+	return nil
+}
+
+// Stop halts the service.
+func (s *Service) Stop() {
+	s.cancelCtx()
+}
+
+// Cleanup performs the service specific post-termination cleanup.
+func (s *Service) Cleanup() {
+	s.db.Close()
+	if s.sink != nil {
+		_ = s.sink.Close()
+	}
+}
+
+// Enabled returns true iff the consensus event exporter is enabled.
+func Enabled() bool {
+	return viper.GetBool(CfgEnabled)
+}
+
+// New creates a new consensus event exporter service.
+func New(dataDir string, backend consensus.Backend) (*Service, error) {
+	db, err := newDB(filepath.Join(dataDir, DbFilename))
+	if err != nil {
+		return nil, err
+	}
+
+	var sink *os.File
+	if fn := viper.GetString(CfgJSONSink); fn != "" {
+		sink, err = os.OpenFile(fn, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			db.Close()
+			return nil, fmt.Errorf("consensus/exporter: failed to open JSON sink: %w", err)
+		}
+	}
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
+	s := &Service{
+		BaseBackgroundService: *service.NewBaseBackgroundService("consensus/exporter"),
+		consensus:             backend,
+		db:                    db,
+		sink:                  sink,
+		ctx:                   ctx,
+		cancelCtx:             cancelCtx,
+	}
+	s.follower = follower.New(s.Logger, backend, db.DB, viper.GetInt64(CfgStartHeight), s.exportHeight)
+
+	return s, nil
+}
+
+func init() {
+	Flags.Bool(CfgEnabled, false, "Enable the consensus event exporter")
+	Flags.String(CfgJSONSink, "", "Path of a newline-delimited JSON file to append exported events to")
+	Flags.Int64(CfgStartHeight, 0, "Consensus height to start exporting at (default: genesis height)")
+
+	_ = viper.BindPFlags(Flags)
+}
@@ -0,0 +1,184 @@
+package follower
+
+import (
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/dgraph-io/badger/v2/options"
+
+	cmnBadger "github.com/oasislabs/oasis-core/go/common/badger"
+	"github.com/oasislabs/oasis-core/go/common/cbor"
+	"github.com/oasislabs/oasis-core/go/common/keyformat"
+	"github.com/oasislabs/oasis-core/go/common/logging"
+)
+
+// metadataKeyFmt is the metadata key format.
+//
+// Value is CBOR-serialized Metadata. Users of the database must not use
+// this key prefix for their own keys.
+var metadataKeyFmt = keyformat.New(0x01)
+
+// Metadata is the database metadata.
+type Metadata struct {
+	// Version is the database schema version.
+	Version uint64 `json:"version"`
+
+	// FirstHeight is the first processed consensus height. Data for lower
+	// heights is not available.
+	FirstHeight int64 `json:"first_height"`
+	// LastHeight is the last processed consensus height.
+	LastHeight int64 `json:"last_height"`
+}
+
+// DB is a database that keeps track of the processed consensus heights.
+type DB struct {
+	name    string
+	version uint64
+	logger  *logging.Logger
+
+	db *badger.DB
+	gc *cmnBadger.GCWorker
+}
+
+// OpenDB opens (or creates) the database with the given schema version.
+// The name is used for logging and in error messages.
+func OpenDB(fn, name string, version uint64) (*DB, error) {
+	logger := logging.GetLogger(name).With("path", fn)
+
+	opts := badger.DefaultOptions(fn)
+	opts = opts.WithLogger(cmnBadger.NewLogAdapter(logger))
+	opts = opts.WithSyncWrites(true)
+	// Allow value log truncation if required (this is needed to recover the
+	// value log file which can get corrupted in crashes).
+	opts = opts.WithTruncate(true)
+	opts = opts.WithCompression(options.None)
+	// Reduce cache size to 10 MiB as the default is 1 GiB.
+	opts = opts.WithMaxCacheSize(10 * 1024 * 1024)
+
+	db, err := badger.Open(opts)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to open database: %w", name, err)
+	}
+
+	d := &DB{
+		name:    name,
+		version: version,
+		logger:  logger,
+		db:      db,
+		gc:      cmnBadger.NewGCWorker(logger, db),
+	}
+
+	// Ensure metadata is valid.
+	if err = d.ensureMetadata(); err != nil {
+		d.Close()
+		return nil, err
+	}
+
+	return d, nil
+}
+
+func (d *DB) queryGetMetadata(tx *badger.Txn) (*Metadata, error) {
+	item, err := tx.Get(metadataKeyFmt.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	var meta Metadata
+	err = item.Value(func(val []byte) error {
+		return cbor.Unmarshal(val, &meta)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &meta, nil
+}
+
+func (d *DB) ensureMetadata() error {
+	return d.db.Update(func(tx *badger.Txn) error {
+		meta, err := d.queryGetMetadata(tx)
+		switch err {
+		case nil:
+		case badger.ErrKeyNotFound:
+			// Create new metadata section.
+			meta := Metadata{
+				Version: d.version,
+			}
+			return tx.Set(metadataKeyFmt.Encode(), cbor.Marshal(meta))
+		default:
+			return err
+		}
+
+		// Verify metadata section.
+		if meta.Version != d.version {
+			return fmt.Errorf("%s: unsupported database version (expected: %d got: %d)",
+				d.name,
+				d.version,
+				meta.Version,
+			)
+		}
+		return nil
+	})
+}
+
+// Metadata returns the database metadata.
+func (d *DB) Metadata() (*Metadata, error) {
+	var meta *Metadata
+	err := d.db.View(func(tx *badger.Txn) error {
+		var err error
+		meta, err = d.queryGetMetadata(tx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// LastHeight returns the last processed consensus height.
+func (d *DB) LastHeight() (int64, error) {
+	meta, err := d.Metadata()
+	if err != nil {
+		return 0, err
+	}
+	return meta.LastHeight, nil
+}
+
+// Commit atomically applies the updates made by the given function and
+// marks the given height as processed.
+func (d *DB) Commit(height int64, fn func(tx *badger.Txn) error) error {
+	return d.db.Update(func(tx *badger.Txn) error {
+		meta, err := d.queryGetMetadata(tx)
+		if err != nil {
+			return err
+		}
+
+		if height <= meta.LastHeight {
+			return fmt.Errorf("%s: commit at lower height (current: %d wanted: %d)",
+				d.name,
+				meta.LastHeight,
+				height,
+			)
+		}
+
+		if err = fn(tx); err != nil {
+			return err
+		}
+
+		if meta.FirstHeight == 0 {
+			meta.FirstHeight = height
+		}
+		meta.LastHeight = height
+		return tx.Set(metadataKeyFmt.Encode(), cbor.Marshal(meta))
+	})
+}
+
+// View runs the given function in a read-only transaction.
+func (d *DB) View(fn func(tx *badger.Txn) error) error {
+	return d.db.View(fn)
+}
+
+// Close closes the database.
+func (d *DB) Close() {
+	d.gc.Close()
+	d.db.Close()
+}
@@ -0,0 +1,145 @@
+// Package follower implements a consensus block follower.
+//
+// The follower processes all consensus heights in order, starting at the
+// genesis height (or a configured later height), and keeps track of the
+// processed heights in a local database so that processing can resume
+// after a restart.
+//
+// In case the start height is no longer available (e.g., because it has
+// been pruned), processing starts at the first available height which is
+// recorded as the first processed height. In case a height becomes
+// unavailable after processing started, the follower stops as the processed
+// heights could otherwise no longer be contiguous.
+package follower
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/oasislabs/oasis-core/go/common/logging"
+	consensus "github.com/oasislabs/oasis-core/go/consensus/api"
+)
+
+// HeightProcessor processes a single consensus height. It must mark the
+// height as processed by committing it to the follower database.
+type HeightProcessor func(ctx context.Context, height int64) error
+
+// Follower is a consensus block follower.
+type Follower struct {
+	logger *logging.Logger
+
+	consensus   consensus.Backend
+	db          *DB
+	startHeight int64
+	process     HeightProcessor
+}
+
+// Run follows consensus blocks and processes all heights until the context
+// is canceled or the block subscription is closed.
+func (f *Follower) Run(ctx context.Context) {
+	// Processing starts at the configured start height (or the genesis
+	// height if not configured) unless resuming.
+	genesisDoc, err := f.consensus.GetGenesisDocument(ctx)
+	if err != nil {
+		f.logger.Error("failed to get genesis document",
+			"err", err,
+		)
+		return
+	}
+	startHeight := genesisDoc.Height
+	if f.startHeight > startHeight {
+		startHeight = f.startHeight
+	}
+
+	blkCh, blkSub, err := f.consensus.WatchBlocks(ctx)
+	if err != nil {
+		f.logger.Error("failed to watch blocks",
+			"err", err,
+		)
+		return
+	}
+	defer blkSub.Close()
+
+	f.logger.Info("started following consensus blocks",
+		"start_height", startHeight,
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			f.logger.Info("stop requested, terminating follower")
+			return
+		case blk, ok := <-blkCh:
+			if !ok {
+				return
+			}
+
+			err = f.processUpTo(ctx, startHeight, blk.Height)
+			switch {
+			case err == nil:
+			case errors.Is(err, consensus.ErrVersionNotFound):
+				f.logger.Error("consensus height is no longer available, stopping (remove the database to start over)",
+					"err", err,
+					"height", blk.Height,
+				)
+				return
+			default:
+				// Processing will be retried on the next block.
+				f.logger.Error("failed to process heights",
+					"err", err,
+					"height", blk.Height,
+				)
+			}
+		}
+	}
+}
+
+func (f *Follower) processUpTo(ctx context.Context, initialHeight, height int64) error {
+	lastHeight, err := f.db.LastHeight()
+	if err != nil {
+		return err
+	}
+	from := lastHeight + 1
+	if lastHeight == 0 {
+		from = initialHeight
+	}
+
+	skipUnavailable := lastHeight == 0
+	for h := from; h <= height; h++ {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		err = f.process(ctx, h)
+		switch {
+		case err == nil:
+			skipUnavailable = false
+		case skipUnavailable && errors.Is(err, consensus.ErrVersionNotFound):
+			// Nothing has been processed yet, skip forward to the first
+			// available height.
+			f.logger.Debug("skipping unavailable height",
+				"height", h,
+			)
+		default:
+			return fmt.Errorf("failed to process height %d: %w", h, err)
+		}
+	}
+	return nil
+}
+
+// New creates a new consensus block follower.
+func New(
+	logger *logging.Logger,
+	backend consensus.Backend,
+	db *DB,
+	startHeight int64,
+	process HeightProcessor,
+) *Follower {
+	return &Follower{
+		logger:      logger,
+		consensus:   backend,
+		db:          db,
+		startHeight: startHeight,
+		process:     process,
+	}
+}
@@ -0,0 +1,104 @@
+package follower
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasislabs/oasis-core/go/common/logging"
+	consensus "github.com/oasislabs/oasis-core/go/consensus/api"
+)
+
+func TestFollower(t *testing.T) {
+	require := require.New(t)
+
+	// Create a new random temporary directory under /tmp.
+	dataDir, err := ioutil.TempDir("", "oasis-consensus-follower-test_")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dataDir)
+
+	fn := filepath.Join(dataDir, "follower.db")
+	db, err := OpenDB(fn, "consensus/follower/test", 1)
+	require.NoError(err, "OpenDB")
+
+	meta, err := db.Metadata()
+	require.NoError(err, "Metadata")
+	require.EqualValues(1, meta.Version)
+	require.EqualValues(0, meta.FirstHeight)
+	require.EqualValues(0, meta.LastHeight)
+
+	var processed []int64
+	f := New(logging.GetLogger("consensus/follower/test"), nil, db, 0, func(ctx context.Context, height int64) error {
+		processed = append(processed, height)
+		return db.Commit(height, func(tx *badger.Txn) error { return nil })
+	})
+
+	// An empty database should start processing at the initial height.
+	err = f.processUpTo(context.Background(), 10, 12)
+	require.NoError(err, "processUpTo")
+	require.Equal([]int64{10, 11, 12}, processed)
+
+	// Resuming should continue after the last processed height.
+	processed = nil
+	err = f.processUpTo(context.Background(), 1, 13)
+	require.NoError(err, "processUpTo")
+	require.Equal([]int64{13}, processed)
+
+	meta, err = db.Metadata()
+	require.NoError(err, "Metadata")
+	require.EqualValues(10, meta.FirstHeight, "first height should be the first processed height")
+	require.EqualValues(13, meta.LastHeight)
+
+	err = db.Commit(13, func(tx *badger.Txn) error { return nil })
+	require.Error(err, "Commit at same height should fail")
+
+	db.Close()
+
+	_, err = OpenDB(fn, "consensus/follower/test", 2)
+	require.Error(err, "OpenDB with a different version should fail")
+}
+
+func TestFollowerUnavailableHeights(t *testing.T) {
+	require := require.New(t)
+
+	// Create a new random temporary directory under /tmp.
+	dataDir, err := ioutil.TempDir("", "oasis-consensus-follower-test_")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dataDir)
+
+	db, err := OpenDB(filepath.Join(dataDir, "follower.db"), "consensus/follower/test", 1)
+	require.NoError(err, "OpenDB")
+	defer db.Close()
+
+	var processed []int64
+	available := int64(12)
+	f := New(logging.GetLogger("consensus/follower/test"), nil, db, 0, func(ctx context.Context, height int64) error {
+		if height < available {
+			return consensus.ErrVersionNotFound
+		}
+		processed = append(processed, height)
+		return db.Commit(height, func(tx *badger.Txn) error { return nil })
+	})
+
+	// Unavailable heights should be skipped until the first height is processed.
+	err = f.processUpTo(context.Background(), 10, 13)
+	require.NoError(err, "processUpTo")
+	require.Equal([]int64{12, 13}, processed)
+
+	meta, err := db.Metadata()
+	require.NoError(err, "Metadata")
+	require.EqualValues(12, meta.FirstHeight, "first height should be the first available height")
+
+	// Once processing started, unavailable heights should not be skipped.
+	processed = nil
+	available = 15
+	err = f.processUpTo(context.Background(), 10, 15)
+	require.True(errors.Is(err, consensus.ErrVersionNotFound), "processUpTo should fail on unavailable heights")
+	require.Empty(processed)
+}
@@ -0,0 +1,85 @@
+// Package api implements the consensus transaction indexer API.
+package api
+
+import (
+	"context"
+
+	"github.com/oasislabs/oasis-core/go/common/crypto/hash"
+	"github.com/oasislabs/oasis-core/go/common/crypto/signature"
+	"github.com/oasislabs/oasis-core/go/common/errors"
+	consensus "github.com/oasislabs/oasis-core/go/consensus/api"
+	"github.com/oasislabs/oasis-core/go/consensus/api/transaction"
+)
+
+// ModuleName is a unique module name for the transaction indexer module.
+const ModuleName = "consensus/indexer"
+
+// MaxHeightRange is the maximum number of heights that can be queried in a
+// single GetTransactionsBySigner call.
+const MaxHeightRange = 10000
+
+var (
+	// ErrNotFound is the error returned when the requested transaction
+	// has not been indexed.
+	ErrNotFound = errors.New(ModuleName, 1, "indexer: transaction not found")
+
+	// ErrInvalidRange is the error returned when the requested height range
+	// is invalid.
+	ErrInvalidRange = errors.New(ModuleName, 2, "indexer: invalid height range")
+
+	// ErrNotIndexed is the error returned when the requested heights have
+	// not been indexed yet.
+	ErrNotIndexed = errors.New(ModuleName, 3, "indexer: heights not yet indexed")
+
+	// ErrNotAvailable is the error returned when the requested heights are
+	// lower than the height at which indexing started.
+	ErrNotAvailable = errors.New(ModuleName, 4, "indexer: heights not available")
+)
+
+// Transaction is an indexed consensus transaction.
+type Transaction struct {
+	// Height is the consensus height at which the transaction was included.
+	Height int64 `json:"height"`
+	// Index is the index of the transaction within the block.
+	Index uint32 `json:"index"`
+	// Hash is the hash of the raw transaction.
+	Hash hash.Hash `json:"hash"`
+
+	// Signer is the public key of the transaction signer.
+	Signer signature.PublicKey `json:"signer"`
+	// Transaction is the decoded transaction. It is nil in case the
+	// transaction could not be decoded.
+	Transaction *transaction.Transaction `json:"transaction,omitempty"`
+
+	// Result is the result of executing the transaction.
+	Result consensus.Result `json:"result"`
+}
+
+// GetTransactionsBySignerRequest is a GetTransactionsBySigner request.
+type GetTransactionsBySignerRequest struct {
+	// Signer is the public key of the transaction signer.
+	Signer signature.PublicKey `json:"signer"`
+	// From is the first height (inclusive).
+	From int64 `json:"from"`
+	// To is the last height (inclusive).
+	To int64 `json:"to"`
+}
+
+// Backend is the transaction indexer interface.
+type Backend interface {
+	// GetLastHeight returns the last consensus height that has been
+	// indexed.
+	GetLastHeight(ctx context.Context) (int64, error)
+
+	// GetTransactionByHash returns the indexed transaction with the given
+	// hash.
+	GetTransactionByHash(ctx context.Context, txHash hash.Hash) (*Transaction, error)
+
+	// GetTransactionsBySigner returns all indexed transactions signed by
+	// the given signer in the given height range, ordered by height.
+	GetTransactionsBySigner(ctx context.Context, request *GetTransactionsBySignerRequest) ([]*Transaction, error)
+
+	// GetTransactionsWithResults returns all indexed transactions included
+	// in the block at the given height together with their results.
+	GetTransactionsWithResults(ctx context.Context, height int64) ([]*Transaction, error)
+}
@@ -0,0 +1,184 @@
+package api
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/oasislabs/oasis-core/go/common/crypto/hash"
+	cmnGrpc "github.com/oasislabs/oasis-core/go/common/grpc"
+)
+
+var (
+	// serviceName is the gRPC service name.
+	serviceName = cmnGrpc.NewServiceName("ConsensusIndexer")
+
+	// methodGetLastHeight is the GetLastHeight method.
+	methodGetLastHeight = serviceName.NewMethod("GetLastHeight", nil)
+	// methodGetTransactionByHash is the GetTransactionByHash method.
+	methodGetTransactionByHash = serviceName.NewMethod("GetTransactionByHash", hash.Hash{})
+	// methodGetTransactionsBySigner is the GetTransactionsBySigner method.
+	methodGetTransactionsBySigner = serviceName.NewMethod("GetTransactionsBySigner", GetTransactionsBySignerRequest{})
+	// methodGetTransactionsWithResults is the GetTransactionsWithResults method.
+	methodGetTransactionsWithResults = serviceName.NewMethod("GetTransactionsWithResults", int64(0))
+
+	// serviceDesc is the gRPC service descriptor.
+	serviceDesc = grpc.ServiceDesc{
+		ServiceName: string(serviceName),
+		HandlerType: (*Backend)(nil),
+		Methods: []grpc.MethodDesc{
+			{
+				MethodName: methodGetLastHeight.ShortName(),
+				Handler:    handlerGetLastHeight,
+			},
+			{
+				MethodName: methodGetTransactionByHash.ShortName(),
+				Handler:    handlerGetTransactionByHash,
+			},
+			{
+				MethodName: methodGetTransactionsBySigner.ShortName(),
+				Handler:    handlerGetTransactionsBySigner,
+			},
+			{
+				MethodName: methodGetTransactionsWithResults.ShortName(),
+				Handler:    handlerGetTransactionsWithResults,
+			},
+		},
+		Streams: []grpc.StreamDesc{},
+	}
+)
+
+func handlerGetLastHeight( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	if interceptor == nil {
+		return srv.(Backend).GetLastHeight(ctx)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetLastHeight.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetLastHeight(ctx)
+	}
+	return interceptor(ctx, nil, info, handler)
+}
+
+func handlerGetTransactionByHash( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var txHash hash.Hash
+	if err := dec(&txHash); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetTransactionByHash(ctx, txHash)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetTransactionByHash.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetTransactionByHash(ctx, *req.(*hash.Hash))
+	}
+	return interceptor(ctx, &txHash, info, handler)
+}
+
+func handlerGetTransactionsBySigner( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var req GetTransactionsBySignerRequest
+	if err := dec(&req); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetTransactionsBySigner(ctx, &req)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetTransactionsBySigner.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetTransactionsBySigner(ctx, req.(*GetTransactionsBySignerRequest))
+	}
+	return interceptor(ctx, &req, info, handler)
+}
+
+func handlerGetTransactionsWithResults( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var height int64
+	if err := dec(&height); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetTransactionsWithResults(ctx, height)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetTransactionsWithResults.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetTransactionsWithResults(ctx, req.(int64))
+	}
+	return interceptor(ctx, height, info, handler)
+}
+
+// RegisterService registers a new transaction indexer service with the
+// given gRPC server.
+func RegisterService(server cmnGrpc.ServiceRegistrar, service Backend) {
+	server.RegisterService(&serviceDesc, service)
+}
+
+type indexerClient struct {
+	conn *grpc.ClientConn
+}
+
+func (c *indexerClient) GetLastHeight(ctx context.Context) (int64, error) {
+	var rsp int64
+	if err := c.conn.Invoke(ctx, methodGetLastHeight.FullName(), nil, &rsp); err != nil {
+		return 0, err
+	}
+	return rsp, nil
+}
+
+func (c *indexerClient) GetTransactionByHash(ctx context.Context, txHash hash.Hash) (*Transaction, error) {
+	var rsp Transaction
+	if err := c.conn.Invoke(ctx, methodGetTransactionByHash.FullName(), txHash, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (c *indexerClient) GetTransactionsBySigner(ctx context.Context, request *GetTransactionsBySignerRequest) ([]*Transaction, error) {
+	var rsp []*Transaction
+	if err := c.conn.Invoke(ctx, methodGetTransactionsBySigner.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+func (c *indexerClient) GetTransactionsWithResults(ctx context.Context, height int64) ([]*Transaction, error) {
+	var rsp []*Transaction
+	if err := c.conn.Invoke(ctx, methodGetTransactionsWithResults.FullName(), height, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+// NewIndexerClient creates a new gRPC transaction indexer client service.
+func NewIndexerClient(c *grpc.ClientConn) Backend {
+	return &indexerClient{c}
+}
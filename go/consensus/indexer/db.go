@@ -0,0 +1,170 @@
+package indexer
+
+import (
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/oasislabs/oasis-core/go/common/cbor"
+	"github.com/oasislabs/oasis-core/go/common/crypto/hash"
+	"github.com/oasislabs/oasis-core/go/common/crypto/signature"
+	"github.com/oasislabs/oasis-core/go/common/keyformat"
+	"github.com/oasislabs/oasis-core/go/consensus/follower"
+	"github.com/oasislabs/oasis-core/go/consensus/indexer/api"
+)
+
+const dbVersion = 1
+
+var (
+	// txKeyFmt is the transaction key format (height, index).
+	//
+	// Value is CBOR-serialized api.Transaction.
+	txKeyFmt = keyformat.New(0x02, uint64(0), uint32(0))
+	// txHashKeyFmt is the transaction hash index key format (hash).
+	//
+	// Value is the corresponding txKeyFmt key.
+	txHashKeyFmt = keyformat.New(0x03, &hash.Hash{})
+	// txSignerKeyFmt is the transaction signer index key format
+	// (signer, height, index).
+	//
+	// Value is empty.
+	txSignerKeyFmt = keyformat.New(0x04, &signature.PublicKey{}, uint64(0), uint32(0))
+)
+
+// DB is the consensus transaction index database.
+type DB struct {
+	*follower.DB
+}
+
+func newDB(fn string) (*DB, error) {
+	db, err := follower.OpenDB(fn, "consensus/indexer", dbVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &DB{db}, nil
+}
+
+func (d *DB) queryGetTransaction(tx *badger.Txn, key []byte) (*api.Transaction, error) {
+	item, err := tx.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	var indexedTx api.Transaction
+	err = item.Value(func(val []byte) error {
+		return cbor.Unmarshal(val, &indexedTx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &indexedTx, nil
+}
+
+// commit indexes the transactions included at the given height.
+func (d *DB) commit(height int64, txs []*api.Transaction) error {
+	return d.Commit(height, func(tx *badger.Txn) error {
+		for _, indexedTx := range txs {
+			txKey := txKeyFmt.Encode(uint64(height), indexedTx.Index)
+			if err := tx.Set(txKey, cbor.Marshal(indexedTx)); err != nil {
+				return err
+			}
+			if err := tx.Set(txHashKeyFmt.Encode(&indexedTx.Hash), txKey); err != nil {
+				return err
+			}
+			// Only index signers of transactions with a valid signature.
+			if indexedTx.Transaction == nil {
+				continue
+			}
+			if err := tx.Set(txSignerKeyFmt.Encode(&indexedTx.Signer, uint64(height), indexedTx.Index), []byte{}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (d *DB) getTransactionByHash(txHash hash.Hash) (*api.Transaction, error) {
+	var indexedTx *api.Transaction
+	txErr := d.View(func(tx *badger.Txn) error {
+		item, err := tx.Get(txHashKeyFmt.Encode(&txHash))
+		switch err {
+		case nil:
+		case badger.ErrKeyNotFound:
+			return api.ErrNotFound
+		default:
+			return err
+		}
+
+		txKey, err := item.ValueCopy(nil)
+		if err != nil {
+			return err
+		}
+
+		indexedTx, err = d.queryGetTransaction(tx, txKey)
+		return err
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	return indexedTx, nil
+}
+
+func (d *DB) getTransactionsBySigner(signer signature.PublicKey, from, to int64) ([]*api.Transaction, error) {
+	txs := []*api.Transaction{}
+	txErr := d.View(func(tx *badger.Txn) error {
+		prefix := txSignerKeyFmt.Encode(&signer)
+		it := tx.NewIterator(badger.IteratorOptions{Prefix: prefix})
+		defer it.Close()
+
+		for it.Seek(txSignerKeyFmt.Encode(&signer, uint64(from))); it.Valid(); it.Next() {
+			var (
+				decSigner signature.PublicKey
+				height    uint64
+				idx       uint32
+			)
+			if !txSignerKeyFmt.Decode(it.Item().Key(), &decSigner, &height, &idx) {
+				// This should not happen as the Badger iterator should take care of it.
+				panic("consensus/indexer: bad iterator")
+			}
+			if int64(height) > to {
+				break
+			}
+
+			indexedTx, err := d.queryGetTransaction(tx, txKeyFmt.Encode(height, idx))
+			if err != nil {
+				return err
+			}
+			txs = append(txs, indexedTx)
+		}
+		return nil
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	return txs, nil
+}
+
+func (d *DB) getTransactionsAtHeight(height int64) ([]*api.Transaction, error) {
+	txs := []*api.Transaction{}
+	txErr := d.View(func(tx *badger.Txn) error {
+		it := tx.NewIterator(badger.IteratorOptions{
+			Prefix:         txKeyFmt.Encode(uint64(height)),
+			PrefetchValues: true,
+			PrefetchSize:   100,
+		})
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			var indexedTx api.Transaction
+			if err := it.Item().Value(func(val []byte) error {
+				return cbor.Unmarshal(val, &indexedTx)
+			}); err != nil {
+				return err
+			}
+			txs = append(txs, &indexedTx)
+		}
+		return nil
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	return txs, nil
+}
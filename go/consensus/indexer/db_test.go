@@ -0,0 +1,105 @@
+package indexer
+
+import (
+	"crypto/rand"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasislabs/oasis-core/go/common/crypto/hash"
+	"github.com/oasislabs/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasislabs/oasis-core/go/common/crypto/signature/signers/memory"
+	consensus "github.com/oasislabs/oasis-core/go/consensus/api"
+	"github.com/oasislabs/oasis-core/go/consensus/api/transaction"
+	"github.com/oasislabs/oasis-core/go/consensus/indexer/api"
+)
+
+func TestIndexerDB(t *testing.T) {
+	require := require.New(t)
+
+	// Create a new random temporary directory under /tmp.
+	dataDir, err := ioutil.TempDir("", "oasis-consensus-indexer-test_")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dataDir)
+
+	db, err := newDB(filepath.Join(dataDir, DbFilename))
+	require.NoError(err, "newDB")
+	defer db.Close()
+
+	signer, err := memorySigner.NewSigner(rand.Reader)
+	require.NoError(err, "NewSigner")
+	otherSigner, err := memorySigner.NewSigner(rand.Reader)
+	require.NoError(err, "NewSigner")
+
+	tx := transaction.NewTransaction(0, nil, "test.Method", nil)
+	newTx := func(height int64, index uint32, signer signature.Signer) *api.Transaction {
+		var txHash hash.Hash
+		txHash.FromBytes([]byte{byte(height), byte(index)})
+		return &api.Transaction{
+			Height:      height,
+			Index:       index,
+			Hash:        txHash,
+			Signer:      signer.Public(),
+			Transaction: tx,
+		}
+	}
+
+	tx1 := newTx(10, 0, signer)
+	tx2 := newTx(10, 1, otherSigner)
+	tx2.Result = consensus.Result{
+		Error:   consensus.TransactionError{Module: "test", Code: 1, Message: "failed"},
+		GasUsed: 10,
+	}
+	tx3 := newTx(12, 0, signer)
+
+	err = db.commit(10, []*api.Transaction{tx1, tx2})
+	require.NoError(err, "commit")
+	err = db.commit(11, nil)
+	require.NoError(err, "commit")
+	err = db.commit(12, []*api.Transaction{tx3})
+	require.NoError(err, "commit")
+
+	err = db.commit(12, nil)
+	require.Error(err, "commit at same height should fail")
+
+	lastHeight, err := db.LastHeight()
+	require.NoError(err, "LastHeight")
+	require.EqualValues(12, lastHeight)
+
+	indexedTx, err := db.getTransactionByHash(tx2.Hash)
+	require.NoError(err, "getTransactionByHash")
+	require.EqualValues(10, indexedTx.Height)
+	require.EqualValues(1, indexedTx.Index)
+	require.False(indexedTx.Result.IsSuccess())
+	require.Equal("test", indexedTx.Result.Error.Module)
+	require.EqualValues(10, indexedTx.Result.GasUsed)
+
+	var missingHash hash.Hash
+	missingHash.FromBytes([]byte("missing"))
+	_, err = db.getTransactionByHash(missingHash)
+	require.Equal(api.ErrNotFound, err, "getTransactionByHash should fail for unknown hash")
+
+	txs, err := db.getTransactionsBySigner(signer.Public(), 1, 12)
+	require.NoError(err, "getTransactionsBySigner")
+	require.Len(txs, 2)
+	require.Equal(tx1.Hash, txs[0].Hash)
+	require.Equal(tx3.Hash, txs[1].Hash)
+
+	txs, err = db.getTransactionsBySigner(signer.Public(), 11, 12)
+	require.NoError(err, "getTransactionsBySigner")
+	require.Len(txs, 1)
+	require.Equal(tx3.Hash, txs[0].Hash)
+
+	txs, err = db.getTransactionsAtHeight(10)
+	require.NoError(err, "getTransactionsAtHeight")
+	require.Len(txs, 2)
+	require.True(txs[0].Result.IsSuccess())
+	require.Equal(otherSigner.Public(), txs[1].Signer)
+
+	txs, err = db.getTransactionsAtHeight(11)
+	require.NoError(err, "getTransactionsAtHeight")
+	require.Len(txs, 0)
+}
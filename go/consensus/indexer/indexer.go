@@ -0,0 +1,182 @@
+// Package indexer implements the consensus transaction indexer.
+//
+// The indexer follows consensus blocks and stores all included transactions
+// together with their signer and execution results in a local database so
+// that they can be looked up by hash, by signer or by height.
+package indexer
+
+import (
+	"context"
+	"path/filepath"
+
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasislabs/oasis-core/go/common/cbor"
+	"github.com/oasislabs/oasis-core/go/common/crypto/hash"
+	"github.com/oasislabs/oasis-core/go/common/service"
+	consensus "github.com/oasislabs/oasis-core/go/consensus/api"
+	"github.com/oasislabs/oasis-core/go/consensus/api/transaction"
+	"github.com/oasislabs/oasis-core/go/consensus/follower"
+	"github.com/oasislabs/oasis-core/go/consensus/indexer/api"
+)
+
+const (
+	// CfgEnabled enables the consensus transaction indexer.
+	CfgEnabled = "consensus.indexer.enabled"
+	// CfgStartHeight configures the consensus height at which indexing
+	// starts when the database is empty.
+	CfgStartHeight = "consensus.indexer.start_height"
+
+	// DbFilename is the filename of the transaction index database.
+	DbFilename = "consensus-txs.db"
+)
+
+// Flags has the configuration flags.
+var Flags = flag.NewFlagSet("", flag.ContinueOnError)
+
+var _ api.Backend = (*Service)(nil)
+
+// Service is the consensus transaction indexer service.
+type Service struct {
+	service.BaseBackgroundService
+
+	consensus consensus.Backend
+	db        *DB
+	follower  *follower.Follower
+
+	ctx       context.Context
+	cancelCtx context.CancelFunc
+}
+
+// GetLastHeight implements api.Backend.
+func (s *Service) GetLastHeight(ctx context.Context) (int64, error) {
+	return s.db.LastHeight()
+}
+
+// GetTransactionByHash implements api.Backend.
+func (s *Service) GetTransactionByHash(ctx context.Context, txHash hash.Hash) (*api.Transaction, error) {
+	return s.db.getTransactionByHash(txHash)
+}
+
+// GetTransactionsBySigner implements api.Backend.
+func (s *Service) GetTransactionsBySigner(ctx context.Context, request *api.GetTransactionsBySignerRequest) ([]*api.Transaction, error) {
+	if request.From < 1 || request.To < request.From || request.To-request.From >= api.MaxHeightRange {
+		return nil, api.ErrInvalidRange
+	}
+
+	return s.db.getTransactionsBySigner(request.Signer, request.From, request.To)
+}
+
+// GetTransactionsWithResults implements api.Backend.
+func (s *Service) GetTransactionsWithResults(ctx context.Context, height int64) ([]*api.Transaction, error) {
+	meta, err := s.db.Metadata()
+	if err != nil {
+		return nil, err
+	}
+	if height < 1 || height > meta.LastHeight {
+		return nil, api.ErrNotIndexed
+	}
+	if height < meta.FirstHeight {
+		return nil, api.ErrNotAvailable
+	}
+
+	return s.db.getTransactionsAtHeight(height)
+}
+
+func (s *Service) worker() {
+	defer s.BaseBackgroundService.Stop()
+
+	s.follower.Run(s.ctx)
+}
+
+func (s *Service) indexHeight(ctx context.Context, height int64) error {
+	txsWithResults, err := s.consensus.GetTransactionsWithResults(ctx, height)
+	if err != nil {
+		return err
+	}
+
+	txs := make([]*api.Transaction, 0, len(txsWithResults.Transactions))
+	for idx, raw := range txsWithResults.Transactions {
+		indexedTx := &api.Transaction{
+			Height: height,
+			Index:  uint32(idx),
+			Hash:   hash.NewFromBytes(raw),
+			Result: *txsWithResults.Results[idx],
+		}
+		txs = append(txs, indexedTx)
+
+		var sigTx transaction.SignedTransaction
+		if err = cbor.Unmarshal(raw, &sigTx); err != nil {
+			s.Logger.Warn("failed to decode transaction",
+				"err", err,
+				"height", height,
+				"index", idx,
+			)
+			continue
+		}
+		indexedTx.Signer = sigTx.Signature.PublicKey
+
+		var tx transaction.Transaction
+		if err = sigTx.Open(&tx); err != nil {
+			s.Logger.Warn("failed to open signed transaction",
+				"err", err,
+				"height", height,
+				"index", idx,
+			)
+			continue
+		}
+		indexedTx.Transaction = &tx
+	}
+
+	return s.db.commit(height, txs)
+}
+
+// Start starts the service.
+func (s *Service) Start() error {
+	go s.worker()
+	return nil
+}
+
+// Stop halts the service.
+func (s *Service) Stop() {
+	s.cancelCtx()
+}
+
+// Cleanup performs the service specific post-termination cleanup.
+func (s *Service) Cleanup() {
+	s.db.Close()
+}
+
+// Enabled returns true iff the consensus transaction indexer is enabled.
+func Enabled() bool {
+	return viper.GetBool(CfgEnabled)
+}
+
+// New creates a new consensus transaction indexer service.
+func New(dataDir string, backend consensus.Backend) (*Service, error) {
+	db, err := newDB(filepath.Join(dataDir, DbFilename))
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
+	s := &Service{
+		BaseBackgroundService: *service.NewBaseBackgroundService("consensus/indexer"),
+		consensus:             backend,
+		db:                    db,
+		ctx:                   ctx,
+		cancelCtx:             cancelCtx,
+	}
+	s.follower = follower.New(s.Logger, backend, db.DB, viper.GetInt64(CfgStartHeight), s.indexHeight)
+
+	return s, nil
+}
+
+func init() {
+	Flags.Bool(CfgEnabled, false, "Enable the consensus transaction indexer")
+	Flags.Int64(CfgStartHeight, 0, "Consensus height to start indexing at (default: genesis height)")
+
+	_ = viper.BindPFlags(Flags)
+}
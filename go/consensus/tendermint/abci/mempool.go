@@ -0,0 +1,168 @@
+package abci
+
+import (
+	"sync"
+
+	"github.com/oasislabs/oasis-core/go/common/crypto/hash"
+	"github.com/oasislabs/oasis-core/go/common/crypto/signature"
+	"github.com/oasislabs/oasis-core/go/common/quantity"
+	"github.com/oasislabs/oasis-core/go/consensus/api/transaction"
+)
+
+type pendingTxKey struct {
+	signer signature.PublicKey
+	nonce  uint64
+}
+
+type pendingTx struct {
+	hash hash.Hash
+	fee  quantity.Quantity
+}
+
+// signerPendingTxs are the tracked transactions of a single signer.
+type signerPendingTxs struct {
+	// pending are the pending transactions indexed by nonce.
+	pending map[uint64]*pendingTx
+	// replaced are the nonces of the replaced transactions indexed by hash.
+	replaced map[hash.Hash]uint64
+}
+
+func (s *signerPendingTxs) isEmpty() bool {
+	return len(s.pending) == 0 && len(s.replaced) == 0
+}
+
+// pendingTxTracker keeps track of transactions that passed CheckTx and are
+// pending in the mempool so that a pending transaction can be replaced by
+// a transaction with the same signer and nonce that pays a higher fee.
+//
+// Since the Tendermint mempool provides no way of removing a transaction,
+// replaced transactions are evicted when they are re-checked after the next
+// block is committed. Note that a replaced transaction could still be
+// included in a block before that happens, in which case the replacement
+// will fail with an invalid nonce.
+//
+// Transactions are indexed by signer so that whenever a transaction of a
+// signer is checked or delivered, the signer's transactions whose nonce can
+// no longer be used are pruned. This way transactions that were dropped from
+// the mempool without being re-checked are not tracked forever.
+type pendingTxTracker struct {
+	sync.Mutex
+
+	bySigner map[signature.PublicKey]*signerPendingTxs
+	byHash   map[hash.Hash]pendingTxKey
+	replaced map[hash.Hash]pendingTxKey
+}
+
+// add starts tracking a transaction that passed CheckTx. In case another
+// transaction with the same signer and nonce is already pending, it is
+// replaced iff the new transaction pays a higher fee.
+func (t *pendingTxTracker) add(txHash hash.Hash, signer signature.PublicKey, tx *transaction.Transaction) error {
+	t.Lock()
+	defer t.Unlock()
+
+	var fee quantity.Quantity
+	if tx.Fee != nil {
+		fee = *tx.Fee.Amount.Clone()
+	}
+
+	key := pendingTxKey{signer: signer, nonce: tx.Nonce}
+	txs := t.bySigner[signer]
+	if txs == nil {
+		txs = &signerPendingTxs{
+			pending:  make(map[uint64]*pendingTx),
+			replaced: make(map[hash.Hash]uint64),
+		}
+		t.bySigner[signer] = txs
+	}
+	if prev, ok := txs.pending[tx.Nonce]; ok {
+		if prev.hash.Equal(&txHash) {
+			return nil
+		}
+		if fee.Cmp(&prev.fee) <= 0 {
+			return transaction.ErrReplacementUnderpriced
+		}
+
+		delete(t.byHash, prev.hash)
+		t.replaced[prev.hash] = key
+		txs.replaced[prev.hash] = tx.Nonce
+	}
+
+	txs.pending[tx.Nonce] = &pendingTx{hash: txHash, fee: fee}
+	t.byHash[txHash] = key
+
+	return nil
+}
+
+// isReplaced returns true iff the given pending transaction has been
+// replaced.
+func (t *pendingTxTracker) isReplaced(txHash hash.Hash) bool {
+	t.Lock()
+	defer t.Unlock()
+
+	_, replaced := t.replaced[txHash]
+	return replaced
+}
+
+// remove stops tracking the given transaction, either because it has been
+// included in a block or because it has been evicted from the mempool.
+func (t *pendingTxTracker) remove(txHash hash.Hash) {
+	t.Lock()
+	defer t.Unlock()
+
+	if key, ok := t.replaced[txHash]; ok {
+		delete(t.replaced, txHash)
+		txs := t.bySigner[key.signer]
+		delete(txs.replaced, txHash)
+		t.maybeRemoveSignerLocked(key.signer, txs)
+	}
+
+	key, ok := t.byHash[txHash]
+	if !ok {
+		return
+	}
+	delete(t.byHash, txHash)
+	txs := t.bySigner[key.signer]
+	if prev, ok := txs.pending[key.nonce]; ok && prev.hash.Equal(&txHash) {
+		delete(txs.pending, key.nonce)
+	}
+	t.maybeRemoveSignerLocked(key.signer, txs)
+}
+
+// prune stops tracking all transactions of the given signer with a nonce
+// lower than the given nonce, as they can no longer be included in a block.
+func (t *pendingTxTracker) prune(signer signature.PublicKey, nonce uint64) {
+	t.Lock()
+	defer t.Unlock()
+
+	txs, ok := t.bySigner[signer]
+	if !ok {
+		return
+	}
+	for txNonce, tx := range txs.pending {
+		if txNonce < nonce {
+			delete(t.byHash, tx.hash)
+			delete(txs.pending, txNonce)
+		}
+	}
+	for txHash, txNonce := range txs.replaced {
+		if txNonce < nonce {
+			delete(t.replaced, txHash)
+			delete(txs.replaced, txHash)
+		}
+	}
+	t.maybeRemoveSignerLocked(signer, txs)
+}
+
+func (t *pendingTxTracker) maybeRemoveSignerLocked(signer signature.PublicKey, txs *signerPendingTxs) {
+	if txs.isEmpty() {
+		delete(t.bySigner, signer)
+	}
+}
+
+func newPendingTxTracker() *pendingTxTracker {
+	return &pendingTxTracker{
+		bySigner: make(map[signature.PublicKey]*signerPendingTxs),
+		byHash:   make(map[hash.Hash]pendingTxKey),
+		replaced: make(map[hash.Hash]pendingTxKey),
+	}
+}
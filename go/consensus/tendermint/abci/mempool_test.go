@@ -0,0 +1,103 @@
+package abci
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasislabs/oasis-core/go/common/crypto/hash"
+	"github.com/oasislabs/oasis-core/go/common/crypto/signature"
+	"github.com/oasislabs/oasis-core/go/consensus/api/transaction"
+)
+
+func TestPendingTxTracker(t *testing.T) {
+	require := require.New(t)
+
+	tracker := newPendingTxTracker()
+
+	var signer signature.PublicKey
+	newTx := func(nonce, fee uint64, method transaction.MethodName) (hash.Hash, *transaction.Transaction) {
+		tx := transaction.NewTransaction(nonce, &transaction.Fee{}, method, nil)
+		_ = tx.Fee.Amount.FromUint64(fee)
+		return hash.NewFrom(tx), tx
+	}
+
+	hash1, tx1 := newTx(0, 10, "test.Method")
+	err := tracker.add(hash1, signer, tx1)
+	require.NoError(err, "add")
+	err = tracker.add(hash1, signer, tx1)
+	require.NoError(err, "adding the same transaction again should not fail")
+
+	// A transaction with the same nonce and a lower or equal fee should be rejected.
+	hash2, tx2 := newTx(0, 10, "test.Other")
+	err = tracker.add(hash2, signer, tx2)
+	require.Equal(transaction.ErrReplacementUnderpriced, err, "add with equal fee")
+	require.False(tracker.isReplaced(hash1))
+
+	// A transaction with the same nonce and a higher fee should replace the pending one.
+	hash3, tx3 := newTx(0, 11, "test.Method")
+	err = tracker.add(hash3, signer, tx3)
+	require.NoError(err, "add with higher fee")
+	require.True(tracker.isReplaced(hash1))
+	require.False(tracker.isReplaced(hash3))
+
+	// Removing the replaced transaction should not affect the replacement.
+	tracker.remove(hash1)
+	require.False(tracker.isReplaced(hash1))
+	err = tracker.add(hash2, signer, tx2)
+	require.Equal(transaction.ErrReplacementUnderpriced, err, "add with lower fee than replacement")
+
+	// Once the replacement is removed, a new transaction with the same nonce is accepted.
+	tracker.remove(hash3)
+	err = tracker.add(hash2, signer, tx2)
+	require.NoError(err, "add after removal")
+
+	// Transactions with different nonces are independent.
+	hash4, tx4 := newTx(1, 1, "test.Method")
+	err = tracker.add(hash4, signer, tx4)
+	require.NoError(err, "add with different nonce")
+	require.False(tracker.isReplaced(hash2))
+}
+
+func TestPendingTxTrackerPrune(t *testing.T) {
+	require := require.New(t)
+
+	tracker := newPendingTxTracker()
+
+	var signer, otherSigner signature.PublicKey
+	otherSigner[0] = 1
+	newTx := func(nonce, fee uint64) (hash.Hash, *transaction.Transaction) {
+		tx := transaction.NewTransaction(nonce, &transaction.Fee{}, "test.Method", nil)
+		_ = tx.Fee.Amount.FromUint64(fee)
+		return hash.NewFrom(tx), tx
+	}
+
+	hash1, tx1 := newTx(0, 10)
+	require.NoError(tracker.add(hash1, signer, tx1), "add")
+	hash2, tx2 := newTx(0, 11)
+	require.NoError(tracker.add(hash2, signer, tx2), "add replacement")
+	hash3, tx3 := newTx(1, 10)
+	require.NoError(tracker.add(hash3, signer, tx3), "add")
+	hash4, tx4 := newTx(0, 12)
+	require.NoError(tracker.add(hash4, otherSigner, tx4), "add other signer")
+
+	// Transactions with a nonce lower than the account nonce should be pruned.
+	tracker.prune(signer, 1)
+	require.False(tracker.isReplaced(hash1), "replaced transactions should be pruned")
+	require.Len(tracker.bySigner[signer].pending, 1, "only transactions with a used nonce should be pruned")
+	require.Len(tracker.byHash, 2, "only transactions with a used nonce should be pruned")
+
+	// A new transaction with a pruned nonce should not be rejected as underpriced.
+	hash5, tx5 := newTx(0, 1)
+	require.NoError(tracker.add(hash5, signer, tx5), "add after prune")
+
+	// Transactions of other signers should not be affected.
+	hash6, tx6 := newTx(0, 1)
+	require.Equal(transaction.ErrReplacementUnderpriced, tracker.add(hash6, otherSigner, tx6), "add other signer underpriced")
+
+	// Signers without any tracked transactions should not be tracked.
+	tracker.prune(signer, 2)
+	tracker.remove(hash4)
+	require.Empty(tracker.bySigner, "signers without transactions should be removed")
+	require.Empty(tracker.byHash, "all transactions should be removed")
+}
@@ -263,6 +263,18 @@ func (a *ApplicationServer) EstimateGas(caller signature.PublicKey, tx *transact
 	return a.mux.EstimateGas(caller, tx)
 }
 
+// SetMinGasPrice changes the minimum gas price that transactions must pay
+// in order to be accepted into the local mempool.
+func (a *ApplicationServer) SetMinGasPrice(price uint64) error {
+	return a.mux.state.setMinGasPrice(price)
+}
+
+// IsTxReplaced returns true iff the pending transaction with the given hash
+// has been replaced by a transaction with the same nonce and a higher fee.
+func (a *ApplicationServer) IsTxReplaced(txHash hash.Hash) bool {
+	return a.mux.pendingTxs.isReplaced(txHash)
+}
+
 // BlockHeight returns the last committed block height.
 func (a *ApplicationServer) BlockHeight() int64 {
 	return a.mux.state.BlockHeight()
@@ -310,6 +322,8 @@ type abciMux struct {
 	// debugExpiringTxs maps transaction hashes to the time at which they were created. This is only
 	// used in case CheckTx is disabled (for debug purposes only).
 	debugExpiringTxs map[hash.Hash]time.Time
+	// pendingTxs keeps track of transactions pending in the mempool.
+	pendingTxs *pendingTxTracker
 }
 
 type invalidatedTxSubscription struct {
@@ -660,7 +674,14 @@ func (mux *abciMux) executeTx(ctx *api.Context, rawTx []byte) error {
 	// Set authenticated transaction signer.
 	ctx.SetTxSigner(sigTx.Signature.PublicKey)
 
-	return mux.processTx(ctx, tx, len(rawTx))
+	if err = mux.processTx(ctx, tx, len(rawTx)); err != nil {
+		return err
+	}
+
+	// The nonce has been used, so pending transactions of the signer with the
+	// same or a lower nonce can no longer be included in a block.
+	mux.pendingTxs.prune(sigTx.Signature.PublicKey, tx.Nonce+1)
+	return nil
 }
 
 func (mux *abciMux) EstimateGas(caller signature.PublicKey, tx *transaction.Transaction) (transaction.Gas, error) {
@@ -709,6 +730,35 @@ func (mux *abciMux) notifyInvalidatedCheckTx(txHash hash.Hash, err error) {
 	}
 }
 
+func (mux *abciMux) checkTx(ctx *api.Context, req types.RequestCheckTx, txHash hash.Hash) error {
+	// Evict pending transactions that have been replaced.
+	if req.Type == types.CheckTxType_Recheck && mux.pendingTxs.isReplaced(txHash) {
+		return transaction.ErrReplaced
+	}
+
+	tx, sigTx, err := mux.decodeTx(ctx, req.Tx)
+	if err != nil {
+		return err
+	}
+
+	// Set authenticated transaction signer.
+	ctx.SetTxSigner(sigTx.Signature.PublicKey)
+
+	if err = mux.processTx(ctx, tx, len(req.Tx)); err != nil {
+		return err
+	}
+
+	// The transaction nonce is the signer's account nonce, so any pending
+	// transactions of the signer with a lower nonce can no longer be included
+	// in a block.
+	mux.pendingTxs.prune(sigTx.Signature.PublicKey, tx.Nonce)
+
+	if req.Type == types.CheckTxType_New {
+		return mux.pendingTxs.add(txHash, sigTx.Signature.PublicKey, tx)
+	}
+	return nil
+}
+
 func (mux *abciMux) CheckTx(req types.RequestCheckTx) types.ResponseCheckTx {
 	if mux.state.disableCheckTx {
 		// Blindly accept all transactions if configured to do so. We still need to periodically
@@ -742,7 +792,8 @@ func (mux *abciMux) CheckTx(req types.RequestCheckTx) types.ResponseCheckTx {
 	ctx := mux.state.NewContext(api.ContextCheckTx, mux.currentTime)
 	defer ctx.Close()
 
-	if err := mux.executeTx(ctx, req.Tx); err != nil {
+	txHash := hash.NewFromBytes(req.Tx)
+	if err := mux.checkTx(ctx, req, txHash); err != nil {
 		module, code := errors.Code(err)
 
 		if req.Type == types.CheckTxType_Recheck {
@@ -752,8 +803,7 @@ func (mux *abciMux) CheckTx(req types.RequestCheckTx) types.ResponseCheckTx {
 
 			// XXX: The Tendermint mempool should have provisions for this instead
 			//      of us hacking our way through this here.
-			txHash := hash.NewFromBytes(req.Tx)
-
+			mux.pendingTxs.remove(txHash)
 			mux.notifyInvalidatedCheckTx(txHash, err)
 		}
 
@@ -777,6 +827,9 @@ func (mux *abciMux) DeliverTx(req types.RequestDeliverTx) types.ResponseDeliverT
 	ctx := mux.state.NewContext(api.ContextDeliverTx, mux.currentTime)
 	defer ctx.Close()
 
+	// The transaction is no longer pending, regardless of the outcome.
+	mux.pendingTxs.remove(hash.NewFromBytes(req.Tx))
+
 	if err := mux.executeTx(ctx, req.Tx); err != nil {
 		if api.IsUnavailableStateError(err) {
 			// Make sure to not commit any transactions which include results based on unavailable
@@ -961,6 +1014,7 @@ func newABCIMux(ctx context.Context, upgrader upgrade.Backend, cfg *ApplicationC
 		appsByName:     make(map[string]Application),
 		appsByMethod:   make(map[transaction.MethodName]Application),
 		lastBeginBlock: -1,
+		pendingTxs:     newPendingTxTracker(),
 	}
 
 	// Create a map of expiring transactions if CheckTx is disabled (debug only).
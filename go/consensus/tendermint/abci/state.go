@@ -59,7 +59,9 @@ type applicationState struct { // nolint: maligned
 	haltMode        bool
 	haltEpochHeight epochtime.EpochTime
 
-	minGasPrice    quantity.Quantity
+	minGasPriceLock sync.RWMutex
+	minGasPrice     quantity.Quantity
+
 	ownTxSigner    signature.PublicKey
 	disableCheckTx bool
 
@@ -195,7 +197,24 @@ func (s *applicationState) EpochChanged(ctx *api.Context) (bool, epochtime.Epoch
 }
 
 func (s *applicationState) MinGasPrice() *quantity.Quantity {
-	return &s.minGasPrice
+	s.minGasPriceLock.RLock()
+	defer s.minGasPriceLock.RUnlock()
+
+	return s.minGasPrice.Clone()
+}
+
+func (s *applicationState) setMinGasPrice(price uint64) error {
+	var minGasPrice quantity.Quantity
+	if err := minGasPrice.FromUint64(price); err != nil {
+		return fmt.Errorf("state: invalid minimum gas price: %w", err)
+	}
+
+	s.minGasPriceLock.Lock()
+	defer s.minGasPriceLock.Unlock()
+
+	s.minGasPrice = minGasPrice
+
+	return nil
 }
 
 func (s *applicationState) OwnTxSigner() signature.PublicKey {
@@ -9,10 +9,15 @@ import (
 	"github.com/tendermint/tendermint/abci/types"
 	"golang.org/x/crypto/sha3"
 
+	beacon "github.com/oasislabs/oasis-core/go/beacon/api"
+	"github.com/oasislabs/oasis-core/go/common/cbor"
+	"github.com/oasislabs/oasis-core/go/common/crypto/signature"
 	"github.com/oasislabs/oasis-core/go/consensus/api/transaction"
 	"github.com/oasislabs/oasis-core/go/consensus/tendermint/abci"
 	"github.com/oasislabs/oasis-core/go/consensus/tendermint/api"
 	beaconState "github.com/oasislabs/oasis-core/go/consensus/tendermint/apps/beacon/state"
+	registryapp "github.com/oasislabs/oasis-core/go/consensus/tendermint/apps/registry"
+	stakingapp "github.com/oasislabs/oasis-core/go/consensus/tendermint/apps/staking"
 	epochtime "github.com/oasislabs/oasis-core/go/epochtime/api"
 )
 
@@ -36,7 +41,7 @@ func (app *beaconApplication) ID() uint8 {
 }
 
 func (app *beaconApplication) Methods() []transaction.MethodName {
-	return nil
+	return beacon.Methods
 }
 
 func (app *beaconApplication) Blessed() bool {
@@ -44,7 +49,7 @@ func (app *beaconApplication) Blessed() bool {
 }
 
 func (app *beaconApplication) Dependencies() []string {
-	return nil
+	return []string{registryapp.AppName, stakingapp.AppName}
 }
 
 func (app *beaconApplication) OnRegister(state api.ApplicationState) {
@@ -62,7 +67,26 @@ func (app *beaconApplication) BeginBlock(ctx *api.Context, req types.RequestBegi
 }
 
 func (app *beaconApplication) ExecuteTx(ctx *api.Context, tx *transaction.Transaction) error {
-	return fmt.Errorf("beacon: unexpected transaction")
+	state := beaconState.NewMutableState(ctx.State())
+
+	switch tx.Method {
+	case beacon.MethodCommit:
+		var commit beacon.Commit
+		if err := cbor.Unmarshal(tx.Body, &commit); err != nil {
+			return beacon.ErrInvalidArgument
+		}
+
+		return app.commit(ctx, state, &commit)
+	case beacon.MethodReveal:
+		var reveal beacon.Reveal
+		if err := cbor.Unmarshal(tx.Body, &reveal); err != nil {
+			return beacon.ErrInvalidArgument
+		}
+
+		return app.reveal(ctx, state, &reveal)
+	default:
+		return beacon.ErrInvalidArgument
+	}
 }
 
 func (app *beaconApplication) ForeignExecuteTx(ctx *api.Context, other abci.Application, tx *transaction.Transaction) error {
@@ -89,9 +113,44 @@ func (app *beaconApplication) onBeaconEpochChange(ctx *api.Context, epoch epocht
 		return err
 	}
 
+	ev := &beacon.Evidence{
+		Epoch: epoch,
+	}
+	if params.CommitReveal != nil {
+		if err = app.processCommitReveal(ctx, state, params.CommitReveal, ev); err != nil {
+			return err
+		}
+		switch {
+		case ev.Failed:
+			b := beacon.DeriveFailedRoundBeacon(epoch, ev.PreviousBeacon)
+
+			ctx.Logger().Warn("onBeaconEpochChange: generated fallback beacon for failed commit-reveal round",
+				"epoch", epoch,
+				"beacon", hex.EncodeToString(b),
+				"round", ev.Round,
+				"height", ctx.BlockHeight(),
+			)
+
+			return app.onNewBeacon(ctx, b, ev)
+		case ev.Source == beacon.EntropySourceCommitReveal:
+			b := beacon.DeriveCommitRevealBeacon(epoch, ev.PreviousBeacon, ev.Reveals)
+
+			ctx.Logger().Debug("onBeaconEpochChange: generated commit-reveal beacon",
+				"epoch", epoch,
+				"beacon", hex.EncodeToString(b),
+				"round", ev.Round,
+				"num_reveals", len(ev.Reveals),
+				"height", ctx.BlockHeight(),
+			)
+
+			return app.onNewBeacon(ctx, b, ev)
+		}
+	}
+
 	switch params.DebugDeterministic {
 	case false:
 		entropyCtx = prodEntropyCtx
+		ev.Source = beacon.EntropySourceBlock
 
 		height := ctx.BlockHeight()
 		if height <= 1 {
@@ -105,9 +164,9 @@ func (app *beaconApplication) onBeaconEpochChange(ctx *api.Context, epoch epocht
 			// block is harder for any single validator to game than the block
 			// hash.
 			//
-			// TODO: This still isn't ideal, and an entirely different beacon
-			// entropy source should be written, be it based around SCRAPE,
-			// a VDF, naive commit-reveal, or even just calling an SGX enclave.
+			// Note: This can still be biased by the proposer of the epoch
+			// transition block, the commit-reveal beacon should be used
+			// instead where this matters.
 			ctx.Logger().Debug("onBeaconEpochChange: using commit hash as entropy")
 			entropy = req.Header.GetLastCommitHash()
 		}
@@ -117,6 +176,7 @@ func (app *beaconApplication) onBeaconEpochChange(ctx *api.Context, epoch epocht
 	case true:
 		// UNSAFE/DEBUG - Deterministic beacon.
 		entropyCtx = DebugEntropyCtx
+		ev.Source = beacon.EntropySourceDebug
 		// We're setting this random seed so that we have suitable committee schedules for Byzantine E2E scenarios,
 		// where we want nodes to be scheduled for only one committee. The permutations derived from this on the first
 		// epoch need to have (i) an index that's compute worker only and (ii) an index that's merge worker only. See
@@ -134,20 +194,130 @@ func (app *beaconApplication) onBeaconEpochChange(ctx *api.Context, epoch epocht
 		"height", ctx.BlockHeight(),
 	)
 
-	return app.onNewBeacon(ctx, b)
+	return app.onNewBeacon(ctx, b, ev)
 }
 
-func (app *beaconApplication) onNewBeacon(ctx *api.Context, beacon []byte) error {
+// processCommitReveal processes the commitments that were made two epochs ago
+// and revealed in the previous epoch, penalizes any nodes that failed to
+// reveal and prunes stale commitments.
+//
+// If enough secrets have been revealed, the evidence is updated so that the
+// beacon is derived from the revealed secrets, otherwise the round is marked
+// as failed and the beacon is deterministically derived from the previous
+// beacon. Only during the bootstrap period, when no round can have been
+// completed yet, is the evidence left untouched so that the beacon is derived
+// from block data.
+func (app *beaconApplication) processCommitReveal(
+	ctx *api.Context,
+	state *beaconState.MutableState,
+	params *beacon.CommitRevealParameters,
+	ev *beacon.Evidence,
+) error {
+	baseEpoch, err := app.state.GetBaseEpoch()
+	if err != nil {
+		return fmt.Errorf("tendermint/beacon: failed to get base epoch: %w", err)
+	}
+	if ev.Epoch < baseEpoch+2 {
+		return nil
+	}
+	round := ev.Epoch - 2
+
+	// Secrets could only have been revealed in full if the previous epoch
+	// was actually observed (epochs may be skipped when using the mock
+	// epochtime backend).
+	var eligible bool
+	prevEv, err := state.Evidence(ctx)
+	switch err {
+	case nil:
+		eligible = prevEv.Epoch == ev.Epoch-1
+	case beacon.ErrBeaconNotAvailable:
+	default:
+		return fmt.Errorf("tendermint/beacon: failed to get beacon evidence: %w", err)
+	}
+
+	entries, err := state.Commitments(ctx, round)
+	if err != nil {
+		return fmt.Errorf("tendermint/beacon: failed to get commitments: %w", err)
+	}
+
+	var (
+		reveals      []beacon.RevealEvidence
+		nonRevealers []signature.PublicKey
+	)
+	for _, entry := range entries {
+		if eligible && entry.Epoch == round {
+			var secret []byte
+			if secret, err = state.Reveal(ctx, entry.Epoch, entry.NodeID); err != nil {
+				return fmt.Errorf("tendermint/beacon: failed to get reveal: %w", err)
+			}
+			if secret != nil {
+				reveals = append(reveals, beacon.RevealEvidence{
+					NodeID:     entry.NodeID,
+					Commitment: entry.Commitment,
+					Secret:     secret,
+				})
+			} else {
+				nonRevealers = append(nonRevealers, entry.NodeID)
+			}
+		}
+
+		if err = state.RemoveCommitment(ctx, entry.Epoch, entry.NodeID); err != nil {
+			return fmt.Errorf("tendermint/beacon: failed to remove commitment: %w", err)
+		}
+	}
+
+	for _, nodeID := range nonRevealers {
+		if err = onNonReveal(ctx, nodeID); err != nil {
+			return err
+		}
+	}
+
+	prevBeacon, err := state.Beacon(ctx)
+	switch err {
+	case nil:
+	case beacon.ErrBeaconNotAvailable:
+	default:
+		return fmt.Errorf("tendermint/beacon: failed to get previous beacon: %w", err)
+	}
+
+	ev.Source = beacon.EntropySourceCommitReveal
+	ev.Round = round
+	ev.PreviousBeacon = prevBeacon
+	ev.NonRevealers = nonRevealers
+
+	if len(reveals) == 0 || uint64(len(reveals)) < params.MinParticipants {
+		ctx.Logger().Warn("onBeaconEpochChange: not enough reveals, commit-reveal round failed",
+			"epoch", ev.Epoch,
+			"round", round,
+			"num_reveals", len(reveals),
+			"min_participants", params.MinParticipants,
+		)
+		ev.Failed = true
+		return nil
+	}
+
+	ev.Reveals = reveals
+
+	return nil
+}
+
+func (app *beaconApplication) onNewBeacon(ctx *api.Context, b []byte, ev *beacon.Evidence) error {
 	state := beaconState.NewMutableState(ctx.State())
 
-	if err := state.SetBeacon(ctx, beacon); err != nil {
+	if err := state.SetBeacon(ctx, b); err != nil {
 		ctx.Logger().Error("onNewBeacon: failed to set beacon",
 			"err", err,
 		)
 		return fmt.Errorf("tendermint/beacon: failed to set beacon: %w", err)
 	}
+	if err := state.SetEvidence(ctx, ev); err != nil {
+		ctx.Logger().Error("onNewBeacon: failed to set beacon evidence",
+			"err", err,
+		)
+		return fmt.Errorf("tendermint/beacon: failed to set beacon evidence: %w", err)
+	}
 
-	ctx.EmitEvent(api.NewEventBuilder(app.Name()).Attribute(KeyGenerated, beacon))
+	ctx.EmitEvent(api.NewEventBuilder(app.Name()).Attribute(KeyGenerated, b))
 
 	return nil
 }
@@ -0,0 +1,195 @@
+package beacon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	beacon "github.com/oasislabs/oasis-core/go/beacon/api"
+	"github.com/oasislabs/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasislabs/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasislabs/oasis-core/go/common/entity"
+	"github.com/oasislabs/oasis-core/go/common/node"
+	abciAPI "github.com/oasislabs/oasis-core/go/consensus/tendermint/api"
+	beaconState "github.com/oasislabs/oasis-core/go/consensus/tendermint/apps/beacon/state"
+	registryState "github.com/oasislabs/oasis-core/go/consensus/tendermint/apps/registry/state"
+	stakingState "github.com/oasislabs/oasis-core/go/consensus/tendermint/apps/staking/state"
+	registry "github.com/oasislabs/oasis-core/go/registry/api"
+	staking "github.com/oasislabs/oasis-core/go/staking/api"
+)
+
+func TestCommitReveal(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1580461674, 0)
+	appState := abciAPI.NewMockApplicationState(abciAPI.MockApplicationStateConfig{
+		CurrentEpoch: 10,
+	})
+	ctx := appState.NewContext(abciAPI.ContextDeliverTx, now)
+	defer ctx.Close()
+
+	app := &beaconApplication{state: appState}
+	state := beaconState.NewMutableState(ctx.State())
+	regState := registryState.NewMutableState(ctx.State())
+
+	params := &beacon.ConsensusParameters{
+		CommitReveal: &beacon.CommitRevealParameters{
+			MinParticipants: 1,
+		},
+	}
+	err := state.SetConsensusParameters(ctx, &beacon.ConsensusParameters{})
+	require.NoError(err, "SetConsensusParameters")
+
+	// Register a validator node.
+	ent, _, _ := entity.TestEntity()
+	nodeSigner := memorySigner.NewTestSigner("beacon commit-reveal test signer")
+	nod := &node.Node{
+		DescriptorVersion: node.LatestNodeDescriptorVersion,
+		ID:                nodeSigner.Public(),
+		EntityID:          ent.ID,
+		Expiration:        100,
+		Roles:             node.RoleValidator,
+	}
+	sigNode, err := node.MultiSignNode([]signature.Signer{nodeSigner}, registry.RegisterNodeSignatureContext, nod)
+	require.NoError(err, "MultiSignNode")
+	err = regState.SetNode(ctx, nil, nod, sigNode)
+	require.NoError(err, "SetNode")
+
+	secret := make([]byte, beacon.SecretSize)
+	secret[0] = 0x42
+	commit := &beacon.Commit{
+		Epoch:      10,
+		Commitment: beacon.NewCommitment(nod.ID, 10, secret),
+	}
+
+	// Commitments should be rejected when commit-reveal is disabled.
+	ctx.SetTxSigner(nod.ID)
+	err = app.commit(ctx, state, commit)
+	require.Equal(beacon.ErrCommitRevealDisabled, err, "commit should fail when commit-reveal is disabled")
+
+	err = state.SetConsensusParameters(ctx, params)
+	require.NoError(err, "SetConsensusParameters")
+
+	// Commitments should be rejected for the wrong epoch.
+	err = app.commit(ctx, state, &beacon.Commit{Epoch: 9, Commitment: commit.Commitment})
+	require.Equal(beacon.ErrInvalidEpoch, err, "commit should fail for the wrong epoch")
+
+	// Commitments should be rejected from non-validators.
+	otherSigner := memorySigner.NewTestSigner("beacon commit-reveal other signer")
+	ctx.SetTxSigner(otherSigner.Public())
+	err = app.commit(ctx, state, commit)
+	require.Equal(beacon.ErrNotValidator, err, "commit should fail for non-validators")
+
+	ctx.SetTxSigner(nod.ID)
+	err = app.commit(ctx, state, commit)
+	require.NoError(err, "commit")
+	err = app.commit(ctx, state, commit)
+	require.Equal(beacon.ErrInvalidArgument, err, "duplicate commit should fail")
+
+	// Reveals are made for commitments from the previous epoch.
+	err = state.SetCommitment(ctx, 9, nod.ID, beacon.NewCommitment(nod.ID, 9, secret))
+	require.NoError(err, "SetCommitment")
+	err = state.SetCommitment(ctx, 9, otherSigner.Public(), beacon.NewCommitment(otherSigner.Public(), 9, secret))
+	require.NoError(err, "SetCommitment")
+
+	err = app.reveal(ctx, state, &beacon.Reveal{Epoch: 10, Secret: secret})
+	require.Equal(beacon.ErrInvalidEpoch, err, "reveal should fail for the wrong epoch")
+	badSecret := make([]byte, beacon.SecretSize)
+	err = app.reveal(ctx, state, &beacon.Reveal{Epoch: 9, Secret: badSecret})
+	require.Equal(beacon.ErrInvalidReveal, err, "reveal should fail for a secret not matching the commitment")
+	err = app.reveal(ctx, state, &beacon.Reveal{Epoch: 9, Secret: secret[:16]})
+	require.Equal(beacon.ErrInvalidArgument, err, "reveal should fail for a malformed secret")
+	err = app.reveal(ctx, state, &beacon.Reveal{Epoch: 9, Secret: secret})
+	require.NoError(err, "reveal")
+	err = app.reveal(ctx, state, &beacon.Reveal{Epoch: 9, Secret: secret})
+	require.Equal(beacon.ErrInvalidArgument, err, "duplicate reveal should fail")
+
+	// Beacon for epoch 11 should be derived from the reveals of round 9.
+	err = state.SetBeacon(ctx, make([]byte, beacon.BeaconSize))
+	require.NoError(err, "SetBeacon")
+	err = state.SetEvidence(ctx, &beacon.Evidence{Epoch: 10})
+	require.NoError(err, "SetEvidence")
+
+	ev := &beacon.Evidence{Epoch: 11}
+	err = app.processCommitReveal(ctx, state, params.CommitReveal, ev)
+	require.NoError(err, "processCommitReveal")
+	require.Equal(beacon.EntropySourceCommitReveal, ev.Source, "beacon should be derived from reveals")
+	require.EqualValues(9, ev.Round, "evidence round")
+	require.Len(ev.Reveals, 1, "evidence should contain all reveals")
+	require.Equal(nod.ID, ev.Reveals[0].NodeID, "evidence reveal node")
+	require.Equal([]signature.PublicKey{otherSigner.Public()}, ev.NonRevealers, "evidence should contain non-revealers")
+
+	b := beacon.DeriveCommitRevealBeacon(ev.Epoch, ev.PreviousBeacon, ev.Reveals)
+	require.NoError(ev.Verify(b), "evidence should verify")
+	require.Error(ev.Verify(make([]byte, beacon.BeaconSize)), "evidence should not verify a different beacon")
+
+	// Processed commitments should be removed while current ones are kept.
+	entries, err := state.Commitments(ctx, 10)
+	require.NoError(err, "Commitments")
+	require.Len(entries, 1, "only the current commitment should remain")
+	require.EqualValues(10, entries[0].Epoch, "remaining commitment epoch")
+
+	// Without enough reveals the round should fail and nodes that failed to
+	// reveal should be frozen.
+	otherNode := &node.Node{
+		DescriptorVersion: node.LatestNodeDescriptorVersion,
+		ID:                otherSigner.Public(),
+		EntityID:          ent.ID,
+		Expiration:        100,
+		Roles:             node.RoleValidator,
+	}
+	sigOtherNode, err := node.MultiSignNode([]signature.Signer{otherSigner}, registry.RegisterNodeSignatureContext, otherNode)
+	require.NoError(err, "MultiSignNode")
+	err = regState.SetNode(ctx, nil, otherNode, sigOtherNode)
+	require.NoError(err, "SetNode")
+	err = regState.SetNodeStatus(ctx, otherNode.ID, &registry.NodeStatus{})
+	require.NoError(err, "SetNodeStatus")
+	err = stakingState.NewMutableState(ctx.State()).SetConsensusParameters(ctx, &staking.ConsensusParameters{})
+	require.NoError(err, "SetConsensusParameters")
+
+	err = state.SetCommitment(ctx, 10, otherSigner.Public(), beacon.NewCommitment(otherSigner.Public(), 10, secret))
+	require.NoError(err, "SetCommitment")
+	err = state.SetReveal(ctx, 10, nod.ID, secret)
+	require.NoError(err, "SetReveal")
+	err = state.SetEvidence(ctx, &beacon.Evidence{Epoch: 11})
+	require.NoError(err, "SetEvidence")
+
+	ev = &beacon.Evidence{Epoch: 12}
+	err = app.processCommitReveal(ctx, state, &beacon.CommitRevealParameters{MinParticipants: 2}, ev)
+	require.NoError(err, "processCommitReveal")
+	require.True(ev.Failed, "round should fail without enough reveals")
+	require.Empty(ev.Reveals, "failed round evidence should not contain reveals")
+	require.Equal([]signature.PublicKey{otherSigner.Public()}, ev.NonRevealers, "evidence should contain non-revealers")
+
+	status, err := regState.NodeStatus(ctx, otherNode.ID)
+	require.NoError(err, "NodeStatus")
+	require.True(status.IsFrozen(), "non-revealing node should be frozen")
+	require.EqualValues(11, status.FreezeEndTime, "non-revealing node should be frozen for at least one epoch")
+
+	// A failed round should still produce a beacon.
+	b = beacon.DeriveFailedRoundBeacon(ev.Epoch, ev.PreviousBeacon)
+	require.NoError(ev.Verify(b), "failed round evidence should verify the fallback beacon")
+	require.Error(ev.Verify(beacon.DeriveCommitRevealBeacon(ev.Epoch, ev.PreviousBeacon, nil)), "failed round evidence should not verify a different beacon")
+
+	// Without observing the previous epoch, commitments should be dropped
+	// and the round should fail.
+	err = state.SetCommitment(ctx, 12, nod.ID, beacon.NewCommitment(nod.ID, 12, secret))
+	require.NoError(err, "SetCommitment")
+	ev = &beacon.Evidence{Epoch: 15}
+	err = app.processCommitReveal(ctx, state, params.CommitReveal, ev)
+	require.NoError(err, "processCommitReveal")
+	require.True(ev.Failed, "round should fail when the reveal epoch was skipped")
+	require.Empty(ev.NonRevealers, "skipped rounds should not record non-revealers")
+	entries, err = state.Commitments(ctx, 100)
+	require.NoError(err, "Commitments")
+	require.Empty(entries, "stale commitments should be removed")
+
+	// During the bootstrap period the beacon should be derived from block
+	// data.
+	ev = &beacon.Evidence{Epoch: 1}
+	err = app.processCommitReveal(ctx, state, params.CommitReveal, ev)
+	require.NoError(err, "processCommitReveal")
+	require.Equal(beacon.EntropySourceBlock, ev.Source, "bootstrap beacon should be derived from block data")
+	require.False(ev.Failed, "bootstrap round should not fail")
+}
@@ -4,13 +4,18 @@ import (
 	"context"
 
 	beacon "github.com/oasislabs/oasis-core/go/beacon/api"
+	"github.com/oasislabs/oasis-core/go/common/crypto/hash"
+	"github.com/oasislabs/oasis-core/go/common/crypto/signature"
 	abciAPI "github.com/oasislabs/oasis-core/go/consensus/tendermint/api"
 	beaconState "github.com/oasislabs/oasis-core/go/consensus/tendermint/apps/beacon/state"
+	epochtime "github.com/oasislabs/oasis-core/go/epochtime/api"
 )
 
 // Query is the beacon query interface.
 type Query interface {
 	Beacon(context.Context) ([]byte, error)
+	Evidence(context.Context) (*beacon.Evidence, error)
+	Commitment(context.Context, epochtime.EpochTime, signature.PublicKey) (*hash.Hash, error)
 	Genesis(context.Context) (*beacon.Genesis, error)
 }
 
@@ -36,6 +41,14 @@ func (bq *beaconQuerier) Beacon(ctx context.Context) ([]byte, error) {
 	return bq.state.Beacon(ctx)
 }
 
+func (bq *beaconQuerier) Evidence(ctx context.Context) (*beacon.Evidence, error) {
+	return bq.state.Evidence(ctx)
+}
+
+func (bq *beaconQuerier) Commitment(ctx context.Context, epoch epochtime.EpochTime, nodeID signature.PublicKey) (*hash.Hash, error) {
+	return bq.state.Commitment(ctx, epoch, nodeID)
+}
+
 func (app *beaconApplication) QueryFactory() interface{} {
 	return &QueryFactory{app.state}
 }
@@ -0,0 +1,112 @@
+package beacon
+
+import (
+	"context"
+	"math"
+
+	"github.com/oasislabs/oasis-core/go/common/crypto/signature"
+	abciAPI "github.com/oasislabs/oasis-core/go/consensus/tendermint/api"
+	registryState "github.com/oasislabs/oasis-core/go/consensus/tendermint/apps/registry/state"
+	stakingState "github.com/oasislabs/oasis-core/go/consensus/tendermint/apps/staking/state"
+	registry "github.com/oasislabs/oasis-core/go/registry/api"
+	staking "github.com/oasislabs/oasis-core/go/staking/api"
+)
+
+// onNonReveal penalizes a node that committed to a beacon secret but failed
+// to reveal it.
+//
+// The node is always frozen for at least one epoch so that it is excluded
+// from the next elections, as otherwise the last node to reveal could
+// withhold its secret for free in order to bias the beacon. The node's entity
+// is additionally slashed according to the beacon non-reveal slashing
+// procedure, if configured.
+//
+// Failing to slash a node is not fatal as it must not prevent the beacon from
+// being generated.
+func onNonReveal(ctx *abciAPI.Context, nodeID signature.PublicKey) error {
+	regState := registryState.NewMutableState(ctx.State())
+	stakeState := stakingState.NewMutableState(ctx.State())
+
+	node, err := regState.Node(ctx, nodeID)
+	if err != nil {
+		ctx.Logger().Warn("failed to get non-revealing node",
+			"err", err,
+			"node_id", nodeID,
+		)
+		return nil
+	}
+
+	nodeStatus, err := regState.NodeStatus(ctx, node.ID)
+	if err != nil {
+		ctx.Logger().Warn("failed to get non-revealing node status",
+			"err", err,
+			"node_id", node.ID,
+		)
+		return nil
+	}
+
+	// Do not slash a frozen node.
+	if nodeStatus.IsFrozen() {
+		ctx.Logger().Debug("not slashing frozen node",
+			"node_id", node.ID,
+			"entity_id", node.EntityID,
+			"freeze_end_time", nodeStatus.FreezeEndTime,
+		)
+		return nil
+	}
+
+	// Retrieve the slash procedure for not revealing.
+	st, err := stakeState.Slashing(ctx)
+	if err != nil {
+		ctx.Logger().Error("failed to get slashing table entry for beacon non-reveal",
+			"err", err,
+		)
+		return err
+	}
+	penalty := st[staking.SlashBeaconNonReveal]
+
+	// Freeze node to prevent it being scheduled in the next epoch.
+	freezeInterval := penalty.FreezeInterval
+	if freezeInterval == 0 {
+		freezeInterval = 1
+	}
+	epoch, err := ctx.AppState().GetEpoch(context.Background(), ctx.BlockHeight()+1)
+	if err != nil {
+		return err
+	}
+
+	// Check for overflow.
+	if math.MaxUint64-freezeInterval < epoch {
+		nodeStatus.FreezeEndTime = registry.FreezeForever
+	} else {
+		nodeStatus.FreezeEndTime = epoch + freezeInterval
+	}
+
+	// Slash node entity. The node is frozen even if slashing fails.
+	if !penalty.Amount.IsZero() {
+		if _, err = stakeState.SlashEscrow(ctx, node.EntityID, &penalty.Amount); err != nil {
+			ctx.Logger().Warn("failed to slash non-revealing node entity",
+				"err", err,
+				"node_id", node.ID,
+				"entity_id", node.EntityID,
+			)
+		}
+	}
+
+	if err = regState.SetNodeStatus(ctx, node.ID, nodeStatus); err != nil {
+		ctx.Logger().Error("failed to set non-revealing node status",
+			"err", err,
+			"node_id", node.ID,
+			"entity_id", node.EntityID,
+		)
+		return err
+	}
+
+	ctx.Logger().Warn("penalized node for not revealing beacon secret",
+		"node_id", node.ID,
+		"entity_id", node.EntityID,
+		"freeze_end_time", nodeStatus.FreezeEndTime,
+	)
+
+	return nil
+}
@@ -7,8 +7,11 @@ import (
 
 	beacon "github.com/oasislabs/oasis-core/go/beacon/api"
 	"github.com/oasislabs/oasis-core/go/common/cbor"
+	"github.com/oasislabs/oasis-core/go/common/crypto/hash"
+	"github.com/oasislabs/oasis-core/go/common/crypto/signature"
 	"github.com/oasislabs/oasis-core/go/common/keyformat"
 	abciAPI "github.com/oasislabs/oasis-core/go/consensus/tendermint/api"
+	epochtime "github.com/oasislabs/oasis-core/go/epochtime/api"
 	"github.com/oasislabs/oasis-core/go/storage/mkvs"
 )
 
@@ -21,8 +24,29 @@ var (
 	//
 	// Value is CBOR-serialized beacon.ConsensusParameters.
 	parametersKeyFmt = keyformat.New(0x41)
+	// commitmentKeyFmt is the commit-reveal commitment key format (epoch,
+	// node id).
+	//
+	// Value is the raw commitment hash.
+	commitmentKeyFmt = keyformat.New(0x42, uint64(0), &signature.PublicKey{})
+	// revealKeyFmt is the commit-reveal reveal key format (epoch, node id).
+	//
+	// Value is the raw revealed secret.
+	revealKeyFmt = keyformat.New(0x43, uint64(0), &signature.PublicKey{})
+	// evidenceKeyFmt is the key format used for the evidence of the current
+	// random beacon.
+	//
+	// Value is CBOR-serialized beacon.Evidence.
+	evidenceKeyFmt = keyformat.New(0x44)
 )
 
+// CommitmentEntry is a commit-reveal commitment entry.
+type CommitmentEntry struct {
+	Epoch      epochtime.EpochTime
+	NodeID     signature.PublicKey
+	Commitment hash.Hash
+}
+
 // ImmutableState is the immutable beacon state wrapper.
 type ImmutableState struct {
 	is *abciAPI.ImmutableState
@@ -65,6 +89,84 @@ func (s *ImmutableState) ConsensusParameters(ctx context.Context) (*beacon.Conse
 	return &params, nil
 }
 
+// Evidence gets the evidence used to derive the current random beacon.
+func (s *ImmutableState) Evidence(ctx context.Context) (*beacon.Evidence, error) {
+	data, err := s.is.Get(ctx, evidenceKeyFmt.Encode())
+	if err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	if data == nil {
+		return nil, beacon.ErrBeaconNotAvailable
+	}
+
+	var ev beacon.Evidence
+	if err = cbor.Unmarshal(data, &ev); err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	return &ev, nil
+}
+
+// Commitment gets the commit-reveal commitment of a node in the given epoch.
+//
+// Returns nil if the node has not committed in the given epoch.
+func (s *ImmutableState) Commitment(ctx context.Context, epoch epochtime.EpochTime, nodeID signature.PublicKey) (*hash.Hash, error) {
+	data, err := s.is.Get(ctx, commitmentKeyFmt.Encode(uint64(epoch), &nodeID))
+	if err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	if data == nil {
+		return nil, nil
+	}
+
+	var h hash.Hash
+	if err = h.UnmarshalBinary(data); err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	return &h, nil
+}
+
+// Reveal gets the commit-reveal secret revealed by a node for the given epoch.
+//
+// Returns nil if the node has not revealed its secret.
+func (s *ImmutableState) Reveal(ctx context.Context, epoch epochtime.EpochTime, nodeID signature.PublicKey) ([]byte, error) {
+	data, err := s.is.Get(ctx, revealKeyFmt.Encode(uint64(epoch), &nodeID))
+	if err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	return data, nil
+}
+
+// Commitments returns all commit-reveal commitments made in epochs up to and
+// including the given epoch, ordered by epoch and node identifier.
+func (s *ImmutableState) Commitments(ctx context.Context, epoch epochtime.EpochTime) ([]*CommitmentEntry, error) {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	var entries []*CommitmentEntry
+	for it.Seek(commitmentKeyFmt.Encode()); it.Valid(); it.Next() {
+		var (
+			decEpoch uint64
+			nodeID   signature.PublicKey
+		)
+		if !commitmentKeyFmt.Decode(it.Key(), &decEpoch, &nodeID) || decEpoch > uint64(epoch) {
+			break
+		}
+
+		entry := &CommitmentEntry{
+			Epoch:  epochtime.EpochTime(decEpoch),
+			NodeID: nodeID,
+		}
+		if err := entry.Commitment.UnmarshalBinary(it.Value()); err != nil {
+			return nil, abciAPI.UnavailableStateError(err)
+		}
+		entries = append(entries, entry)
+	}
+	if it.Err() != nil {
+		return nil, abciAPI.UnavailableStateError(it.Err())
+	}
+	return entries, nil
+}
+
 // MutableState is a mutable beacon state wrapper.
 type MutableState struct {
 	*ImmutableState
@@ -86,6 +188,31 @@ func (s *MutableState) SetConsensusParameters(ctx context.Context, params *beaco
 	return abciAPI.UnavailableStateError(err)
 }
 
+func (s *MutableState) SetEvidence(ctx context.Context, ev *beacon.Evidence) error {
+	err := s.ms.Insert(ctx, evidenceKeyFmt.Encode(), cbor.Marshal(ev))
+	return abciAPI.UnavailableStateError(err)
+}
+
+func (s *MutableState) SetCommitment(ctx context.Context, epoch epochtime.EpochTime, nodeID signature.PublicKey, commitment hash.Hash) error {
+	err := s.ms.Insert(ctx, commitmentKeyFmt.Encode(uint64(epoch), &nodeID), commitment[:])
+	return abciAPI.UnavailableStateError(err)
+}
+
+func (s *MutableState) SetReveal(ctx context.Context, epoch epochtime.EpochTime, nodeID signature.PublicKey, secret []byte) error {
+	err := s.ms.Insert(ctx, revealKeyFmt.Encode(uint64(epoch), &nodeID), secret)
+	return abciAPI.UnavailableStateError(err)
+}
+
+// RemoveCommitment removes the commit-reveal commitment of a node together
+// with the corresponding reveal (if any).
+func (s *MutableState) RemoveCommitment(ctx context.Context, epoch epochtime.EpochTime, nodeID signature.PublicKey) error {
+	if err := s.ms.Remove(ctx, commitmentKeyFmt.Encode(uint64(epoch), &nodeID)); err != nil {
+		return abciAPI.UnavailableStateError(err)
+	}
+	err := s.ms.Remove(ctx, revealKeyFmt.Encode(uint64(epoch), &nodeID))
+	return abciAPI.UnavailableStateError(err)
+}
+
 // NewMutableState creates a new mutable beacon state wrapper.
 func NewMutableState(tree mkvs.KeyValueTree) *MutableState {
 	return &MutableState{
@@ -0,0 +1,156 @@
+package beacon
+
+import (
+	beacon "github.com/oasislabs/oasis-core/go/beacon/api"
+	"github.com/oasislabs/oasis-core/go/common/node"
+	"github.com/oasislabs/oasis-core/go/consensus/tendermint/api"
+	beaconState "github.com/oasislabs/oasis-core/go/consensus/tendermint/apps/beacon/state"
+	registryState "github.com/oasislabs/oasis-core/go/consensus/tendermint/apps/registry/state"
+	registry "github.com/oasislabs/oasis-core/go/registry/api"
+)
+
+func (app *beaconApplication) commit(
+	ctx *api.Context,
+	state *beaconState.MutableState,
+	commit *beacon.Commit,
+) error {
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		ctx.Logger().Error("Commit: failed to fetch consensus parameters",
+			"err", err,
+		)
+		return err
+	}
+	if params.CommitReveal == nil {
+		return beacon.ErrCommitRevealDisabled
+	}
+
+	if ctx.IsCheckOnly() {
+		return nil
+	}
+
+	// Charge gas for this transaction.
+	if err = ctx.Gas().UseGas(1, beacon.GasOpCommit, params.GasCosts); err != nil {
+		return err
+	}
+
+	// Commitments can only be made for the current epoch.
+	epoch, err := app.state.GetEpoch(ctx, ctx.BlockHeight()+1)
+	if err != nil {
+		return err
+	}
+	if commit.Epoch != epoch {
+		return beacon.ErrInvalidEpoch
+	}
+
+	// Only validator nodes may participate.
+	nodeID := ctx.TxSigner()
+	regState := registryState.NewMutableState(ctx.State())
+	n, err := regState.Node(ctx, nodeID)
+	switch err {
+	case nil:
+	case registry.ErrNoSuchNode:
+		return beacon.ErrNotValidator
+	default:
+		return err
+	}
+	if !n.HasRoles(node.RoleValidator) || n.IsExpired(uint64(epoch)) {
+		return beacon.ErrNotValidator
+	}
+
+	existing, err := state.Commitment(ctx, epoch, nodeID)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		ctx.Logger().Error("Commit: node already committed",
+			"node_id", nodeID,
+			"epoch", epoch,
+		)
+		return beacon.ErrInvalidArgument
+	}
+
+	if err = state.SetCommitment(ctx, epoch, nodeID, commit.Commitment); err != nil {
+		return err
+	}
+
+	ctx.Logger().Debug("Commit: node committed",
+		"node_id", nodeID,
+		"epoch", epoch,
+	)
+
+	return nil
+}
+
+func (app *beaconApplication) reveal(
+	ctx *api.Context,
+	state *beaconState.MutableState,
+	reveal *beacon.Reveal,
+) error {
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		ctx.Logger().Error("Reveal: failed to fetch consensus parameters",
+			"err", err,
+		)
+		return err
+	}
+	if params.CommitReveal == nil {
+		return beacon.ErrCommitRevealDisabled
+	}
+	if len(reveal.Secret) != beacon.SecretSize {
+		return beacon.ErrInvalidArgument
+	}
+
+	if ctx.IsCheckOnly() {
+		return nil
+	}
+
+	// Charge gas for this transaction.
+	if err = ctx.Gas().UseGas(1, beacon.GasOpReveal, params.GasCosts); err != nil {
+		return err
+	}
+
+	// Secrets committed in the previous epoch are revealed in the current
+	// epoch.
+	epoch, err := app.state.GetEpoch(ctx, ctx.BlockHeight()+1)
+	if err != nil {
+		return err
+	}
+	if epoch == 0 || reveal.Epoch != epoch-1 {
+		return beacon.ErrInvalidEpoch
+	}
+
+	nodeID := ctx.TxSigner()
+	commitment, err := state.Commitment(ctx, reveal.Epoch, nodeID)
+	if err != nil {
+		return err
+	}
+	if commitment == nil {
+		return beacon.ErrInvalidReveal
+	}
+	existing, err := state.Reveal(ctx, reveal.Epoch, nodeID)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		ctx.Logger().Error("Reveal: node already revealed",
+			"node_id", nodeID,
+			"epoch", reveal.Epoch,
+		)
+		return beacon.ErrInvalidArgument
+	}
+	if cm := beacon.NewCommitment(nodeID, reveal.Epoch, reveal.Secret); !cm.Equal(commitment) {
+		return beacon.ErrInvalidReveal
+	}
+
+	if err = state.SetReveal(ctx, reveal.Epoch, nodeID, reveal.Secret); err != nil {
+		return err
+	}
+
+	ctx.Logger().Debug("Reveal: node revealed",
+		"node_id", nodeID,
+		"epoch", reveal.Epoch,
+	)
+
+	return nil
+}
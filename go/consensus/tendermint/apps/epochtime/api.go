@@ -0,0 +1,32 @@
+package epochtime
+
+import (
+	"github.com/oasislabs/oasis-core/go/consensus/api/transaction"
+	"github.com/oasislabs/oasis-core/go/consensus/tendermint/api"
+)
+
+const (
+	// AppID is the unique application identifier.
+	AppID uint8 = 0x08
+
+	// AppName is the ABCI application name.
+	//
+	// Note: It must be lexographically before any application that
+	// uses time keeping.
+	AppName string = "000_epochtime"
+)
+
+var (
+	// EventType is the ABCI event type for epochtime events.
+	EventType = api.EventTypeForApp(AppName)
+
+	// QueryApp is a query for filtering events processed by
+	// the epochtime application.
+	QueryApp = api.QueryForApp(AppName)
+
+	// KeyEpoch is an ABCI event attribute for specifying the new epoch.
+	KeyEpoch = []byte("epoch")
+
+	// Methods is a list of all methods supported by the epochtime application.
+	Methods = []transaction.MethodName{}
+)
@@ -0,0 +1,165 @@
+// Package epochtime implements the time-targeted epochtime application.
+package epochtime
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tendermint/tendermint/abci/types"
+
+	"github.com/oasislabs/oasis-core/go/common/cbor"
+	"github.com/oasislabs/oasis-core/go/consensus/api/transaction"
+	"github.com/oasislabs/oasis-core/go/consensus/tendermint/abci"
+	"github.com/oasislabs/oasis-core/go/consensus/tendermint/api"
+	epochtime "github.com/oasislabs/oasis-core/go/epochtime/api"
+	genesis "github.com/oasislabs/oasis-core/go/genesis/api"
+)
+
+var _ abci.Application = (*epochTimeApplication)(nil)
+
+type epochTimeApplication struct {
+	state api.ApplicationState
+
+	params *epochtime.ConsensusParameters
+}
+
+func (app *epochTimeApplication) Name() string {
+	return AppName
+}
+
+func (app *epochTimeApplication) ID() uint8 {
+	return AppID
+}
+
+func (app *epochTimeApplication) Methods() []transaction.MethodName {
+	return Methods
+}
+
+func (app *epochTimeApplication) Blessed() bool {
+	return false
+}
+
+func (app *epochTimeApplication) Dependencies() []string {
+	return nil
+}
+
+func (app *epochTimeApplication) OnRegister(state api.ApplicationState) {
+	app.state = state
+}
+
+func (app *epochTimeApplication) OnCleanup() {
+}
+
+func (app *epochTimeApplication) InitChain(ctx *api.Context, request types.RequestInitChain, doc *genesis.Document) error {
+	state := newMutableState(ctx.State())
+
+	// The base epoch starts with the first block, at genesis time.
+	epochState := &epochTimeState{
+		Epoch:     doc.EpochTime.Base,
+		Height:    ctx.BlockHeight() + 1,
+		StartTime: doc.Time.UnixNano(),
+	}
+
+	ctx.Logger().Info("InitChain: setting base epoch",
+		"epoch", epochState.Epoch,
+		"height", epochState.Height,
+		"start_time", doc.Time,
+	)
+
+	if err := state.setEpochState(ctx, epochState); err != nil {
+		return fmt.Errorf("epochtime: failed to set base epoch: %w", err)
+	}
+	return nil
+}
+
+func (app *epochTimeApplication) BeginBlock(ctx *api.Context, request types.RequestBeginBlock) error {
+	state := newMutableState(ctx.State())
+
+	current, err := state.getEpochState(ctx)
+	if err != nil {
+		return fmt.Errorf("epochtime: failed to get epoch state: %w", err)
+	}
+
+	height := ctx.BlockHeight() + 1
+	next := nextEpochState(app.params, current, height, ctx.Now())
+	if next == nil {
+		return nil
+	}
+
+	ctx.Logger().Info("epoch transition",
+		"epoch", next.Epoch,
+		"height", height,
+		"block_time", ctx.Now(),
+		"start_time", time.Unix(0, next.StartTime),
+	)
+
+	if err = state.setEpochState(ctx, next); err != nil {
+		return fmt.Errorf("epochtime: failed to set epoch: %w", err)
+	}
+
+	ctx.EmitEvent(api.NewEventBuilder(app.Name()).Attribute(KeyEpoch, cbor.Marshal(next.Epoch)))
+
+	return nil
+}
+
+func (app *epochTimeApplication) ExecuteTx(ctx *api.Context, tx *transaction.Transaction) error {
+	return fmt.Errorf("epochtime: invalid method: %s", tx.Method)
+}
+
+func (app *epochTimeApplication) ForeignExecuteTx(ctx *api.Context, other abci.Application, tx *transaction.Transaction) error {
+	return nil
+}
+
+func (app *epochTimeApplication) EndBlock(ctx *api.Context, request types.RequestEndBlock) (types.ResponseEndBlock, error) {
+	return types.ResponseEndBlock{}, nil
+}
+
+func (app *epochTimeApplication) FireTimer(ctx *api.Context, timer *abci.Timer) error {
+	return fmt.Errorf("tendermint/epochtime: unexpected timer")
+}
+
+// nextEpochState applies the epoch transition rules to the current epoch
+// state, given the height and (consensus) time of the block that is being
+// processed. It returns nil if no transition should take place.
+//
+// An epoch ends at the first block which is both at least TargetDuration
+// after the epoch's nominal start time and at least Interval blocks after
+// the epoch's first block. At most one transition happens per block. The
+// next epoch's nominal start time is the previous one advanced by
+// TargetDuration so that epoch boundaries do not drift with block times,
+// unless the chain has fallen a whole epoch behind (e.g., after a halt),
+// in which case the schedule is reset to the current block time to avoid
+// a burst of short epochs.
+func nextEpochState(
+	params *epochtime.ConsensusParameters,
+	current *epochTimeState,
+	height int64,
+	now time.Time,
+) *epochTimeState {
+	if height-current.Height < params.Interval {
+		return nil
+	}
+	nowNanos := now.UnixNano()
+	duration := int64(params.TargetDuration)
+	if nowNanos-current.StartTime < duration {
+		return nil
+	}
+
+	startTime := current.StartTime + duration
+	if nowNanos-startTime >= duration {
+		startTime = nowNanos
+	}
+
+	return &epochTimeState{
+		Epoch:     current.Epoch + 1,
+		Height:    height,
+		StartTime: startTime,
+	}
+}
+
+// New constructs a new time-targeted epochtime application instance.
+func New(params *epochtime.ConsensusParameters) abci.Application {
+	return &epochTimeApplication{
+		params: params,
+	}
+}
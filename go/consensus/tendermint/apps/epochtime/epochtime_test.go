@@ -0,0 +1,47 @@
+package epochtime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	epochtime "github.com/oasislabs/oasis-core/go/epochtime/api"
+)
+
+func TestNextEpochState(t *testing.T) {
+	require := require.New(t)
+
+	params := &epochtime.ConsensusParameters{
+		Interval:       10,
+		TargetDuration: time.Hour,
+	}
+	genesisTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	current := &epochTimeState{
+		Epoch:     5,
+		Height:    1,
+		StartTime: genesisTime.UnixNano(),
+	}
+
+	// Not enough time has passed.
+	next := nextEpochState(params, current, 100, genesisTime.Add(59*time.Minute))
+	require.Nil(next, "no transition before target duration")
+
+	// Not enough blocks have passed.
+	next = nextEpochState(params, current, 5, genesisTime.Add(2*time.Hour))
+	require.Nil(next, "no transition before minimum interval")
+
+	// Both conditions satisfied, start time should not drift with block time.
+	next = nextEpochState(params, current, 100, genesisTime.Add(time.Hour+time.Minute))
+	require.NotNil(next, "transition after target duration and interval")
+	require.EqualValues(6, next.Epoch)
+	require.EqualValues(100, next.Height)
+	require.EqualValues(genesisTime.Add(time.Hour).UnixNano(), next.StartTime, "start time should not drift")
+
+	// Falling a whole epoch behind should reset the schedule.
+	now := genesisTime.Add(5*time.Hour + time.Minute)
+	next = nextEpochState(params, current, 100, now)
+	require.NotNil(next, "transition after a long pause")
+	require.EqualValues(6, next.Epoch, "at most one transition per block")
+	require.EqualValues(now.UnixNano(), next.StartTime, "start time should be reset")
+}
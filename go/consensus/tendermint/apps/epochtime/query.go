@@ -0,0 +1,50 @@
+package epochtime
+
+import (
+	"context"
+
+	abciAPI "github.com/oasislabs/oasis-core/go/consensus/tendermint/api"
+	epochtime "github.com/oasislabs/oasis-core/go/epochtime/api"
+)
+
+// Query is the epochtime query interface.
+type Query interface {
+	Epoch(context.Context) (epochtime.EpochTime, int64, error)
+	EpochBlock(context.Context, epochtime.EpochTime) (int64, error)
+}
+
+// QueryFactory is the epochtime query factory.
+type QueryFactory struct {
+	state abciAPI.ApplicationQueryState
+}
+
+// QueryAt returns the epochtime query interface for a specific height.
+func (sf *QueryFactory) QueryAt(ctx context.Context, height int64) (Query, error) {
+	state, err := newImmutableState(ctx, sf.state, height)
+	if err != nil {
+		return nil, err
+	}
+	return &epochtimeQuerier{state}, nil
+}
+
+type epochtimeQuerier struct {
+	state *immutableState
+}
+
+func (eq *epochtimeQuerier) Epoch(ctx context.Context) (epochtime.EpochTime, int64, error) {
+	return eq.state.getEpoch(ctx)
+}
+
+func (eq *epochtimeQuerier) EpochBlock(ctx context.Context, epoch epochtime.EpochTime) (int64, error) {
+	return eq.state.getEpochBlock(ctx, epoch)
+}
+
+func (app *epochTimeApplication) QueryFactory() interface{} {
+	return &QueryFactory{app.state}
+}
+
+// NewQueryFactory returns a new QueryFactory backed by the given state
+// instance.
+func NewQueryFactory(state abciAPI.ApplicationQueryState) *QueryFactory {
+	return &QueryFactory{state}
+}
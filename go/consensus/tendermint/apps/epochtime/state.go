@@ -0,0 +1,111 @@
+package epochtime
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oasislabs/oasis-core/go/common/cbor"
+	"github.com/oasislabs/oasis-core/go/common/keyformat"
+	abciAPI "github.com/oasislabs/oasis-core/go/consensus/tendermint/api"
+	"github.com/oasislabs/oasis-core/go/epochtime/api"
+	"github.com/oasislabs/oasis-core/go/storage/mkvs"
+)
+
+var (
+	// epochCurrentKeyFmt is the current epoch key format.
+	//
+	// Value is CBOR-serialized epoch time state.
+	epochCurrentKeyFmt = keyformat.New(0x32)
+	// epochBlockKeyFmt is the epoch start block key format.
+	//
+	// Key format is: 0x33 <epoch (uint64)>.
+	// Value is the big-endian encoded height of the first block of the epoch.
+	epochBlockKeyFmt = keyformat.New(0x33, uint64(0))
+)
+
+// epochTimeState is the state of the current epoch.
+type epochTimeState struct {
+	// Epoch is the current epoch.
+	Epoch api.EpochTime `json:"epoch"`
+	// Height is the height of the first block of the current epoch.
+	Height int64 `json:"height"`
+	// StartTime is the nominal start time of the current epoch
+	// (in nanoseconds since the UNIX epoch).
+	StartTime int64 `json:"start_time"`
+}
+
+type immutableState struct {
+	is *abciAPI.ImmutableState
+}
+
+func (s *immutableState) getEpochState(ctx context.Context) (*epochTimeState, error) {
+	data, err := s.is.Get(ctx, epochCurrentKeyFmt.Encode())
+	if err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	if data == nil {
+		return nil, fmt.Errorf("epochtime: epoch state not initialized")
+	}
+
+	var state epochTimeState
+	if err = cbor.Unmarshal(data, &state); err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	return &state, nil
+}
+
+func (s *immutableState) getEpoch(ctx context.Context) (api.EpochTime, int64, error) {
+	state, err := s.getEpochState(ctx)
+	if err != nil {
+		return api.EpochInvalid, 0, err
+	}
+	return state.Epoch, state.Height, nil
+}
+
+func (s *immutableState) getEpochBlock(ctx context.Context, epoch api.EpochTime) (int64, error) {
+	data, err := s.is.Get(ctx, epochBlockKeyFmt.Encode(uint64(epoch)))
+	if err != nil {
+		return 0, abciAPI.UnavailableStateError(err)
+	}
+	if data == nil {
+		return 0, fmt.Errorf("epochtime: no block for epoch %d", epoch)
+	}
+
+	var height int64
+	if err = cbor.Unmarshal(data, &height); err != nil {
+		return 0, abciAPI.UnavailableStateError(err)
+	}
+	return height, nil
+}
+
+func newImmutableState(ctx context.Context, state abciAPI.ApplicationQueryState, version int64) (*immutableState, error) {
+	is, err := abciAPI.NewImmutableState(ctx, state, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return &immutableState{is}, nil
+}
+
+type mutableState struct {
+	*immutableState
+
+	ms mkvs.KeyValueTree
+}
+
+func (s *mutableState) setEpochState(ctx context.Context, state *epochTimeState) error {
+	if err := s.ms.Insert(ctx, epochCurrentKeyFmt.Encode(), cbor.Marshal(state)); err != nil {
+		return abciAPI.UnavailableStateError(err)
+	}
+	err := s.ms.Insert(ctx, epochBlockKeyFmt.Encode(uint64(state.Epoch)), cbor.Marshal(state.Height))
+	return abciAPI.UnavailableStateError(err)
+}
+
+func newMutableState(tree mkvs.KeyValueTree) *mutableState {
+	return &mutableState{
+		immutableState: &immutableState{
+			&abciAPI.ImmutableState{ImmutableKeyValueTree: tree},
+		},
+		ms: tree,
+	}
+}
@@ -26,6 +26,16 @@ var (
 	// descriptor).
 	KeyRuntimeRegistered = []byte("runtime.registered")
 
+	// KeyRuntimeSuspended is the ABCI event attribute for runtimes being
+	// suspended by their owner (value is a CBOR serialized
+	// registry.RuntimeEvent).
+	KeyRuntimeSuspended = []byte("runtime.suspended")
+
+	// KeyRuntimeOwnershipTransferred is the ABCI event attribute for
+	// runtime ownership transfers (value is a CBOR serialized
+	// registry.RuntimeEvent).
+	KeyRuntimeOwnershipTransferred = []byte("runtime.ownership_transferred")
+
 	// KeyEntityRegistered is the ABCI event attribute for new entity
 	// registrations (value is the CBOR serialized entity descriptor).
 	KeyEntityRegistered = []byte("entity.registered")
@@ -34,6 +44,10 @@ var (
 	// deregistrations (value is a CBOR serialized EntityDeregistration).
 	KeyEntityDeregistered = []byte("entity.deregistered")
 
+	// KeyEntityMetadata is the ABCI event attribute for entity metadata
+	// changes (value is a CBOR serialized EntityMetadataEvent).
+	KeyEntityMetadata = []byte("entity.metadata")
+
 	// KeyNodeRegistered is the ABCI event attribute for new node
 	// registrations (value is the CBOR serialized node descriptor).
 	KeyNodeRegistered = []byte("nodes.registered")
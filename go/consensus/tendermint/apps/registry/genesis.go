@@ -7,6 +7,7 @@ import (
 
 	"github.com/tendermint/tendermint/abci/types"
 
+	"github.com/oasislabs/oasis-core/go/common"
 	"github.com/oasislabs/oasis-core/go/common/cbor"
 	"github.com/oasislabs/oasis-core/go/common/crypto/signature"
 	"github.com/oasislabs/oasis-core/go/common/node"
@@ -44,6 +45,18 @@ func (app *registryApplication) InitChain(ctx *abciAPI.Context, request types.Re
 			return fmt.Errorf("registry: genesis entity registration failure: %w", err)
 		}
 	}
+	for i, v := range st.EntityMetadata {
+		if v == nil {
+			return fmt.Errorf("registry: genesis entity metadata index %d is nil", i)
+		}
+		if err := app.setEntityMetadata(ctx, state, v); err != nil {
+			ctx.Logger().Error("InitChain: failed to set entity metadata",
+				"err", err,
+				"entity", v.Signature.PublicKey,
+			)
+			return fmt.Errorf("registry: genesis entity metadata set failure: %w", err)
+		}
+	}
 	// Register runtimes. First key manager and then compute runtime(s).
 	for _, k := range []registry.RuntimeKind{registry.KindKeyManager, registry.KindCompute} {
 		for i, v := range st.Runtimes {
@@ -91,6 +104,17 @@ func (app *registryApplication) InitChain(ctx *abciAPI.Context, request types.Re
 			return fmt.Errorf("registry: failed to suspend runtime at genesis: %w", err)
 		}
 	}
+	for id, status := range st.RuntimeStatuses {
+		if status == nil {
+			return fmt.Errorf("registry: genesis runtime status %s is nil", id)
+		}
+		if err := state.SetRuntimeStatus(ctx, id, status); err != nil {
+			ctx.Logger().Error("InitChain: failed to set runtime status",
+				"err", err,
+			)
+			return fmt.Errorf("registry: genesis runtime status set failure: %w", err)
+		}
+	}
 	for i, v := range st.Nodes {
 		if v == nil {
 			return fmt.Errorf("registry: genesis node index %d is nil", i)
@@ -169,6 +193,30 @@ func (rq *registryQuerier) Genesis(ctx context.Context) (*registry.Genesis, erro
 		nodeStatuses[n.ID] = status
 	}
 
+	// Only keep the runtime statuses that have any owner-controlled state.
+	allRuntimes, err := rq.state.AllRuntimes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	runtimeStatuses := make(map[common.Namespace]*registry.RuntimeStatus)
+	for _, rt := range allRuntimes {
+		var status *registry.RuntimeStatus
+		status, err = rq.state.RuntimeStatus(ctx, rt.ID)
+		if err != nil {
+			return nil, err
+		}
+		if status.IsEmpty() {
+			continue
+		}
+		status.Suspended = false
+		runtimeStatuses[rt.ID] = status
+	}
+
+	entityMetadata, err := rq.state.AllEntityMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	params, err := rq.state.ConsensusParameters(ctx)
 	if err != nil {
 		return nil, err
@@ -177,10 +225,12 @@ func (rq *registryQuerier) Genesis(ctx context.Context) (*registry.Genesis, erro
 	gen := registry.Genesis{
 		Parameters:        *params,
 		Entities:          signedEntities,
+		EntityMetadata:    entityMetadata,
 		Runtimes:          signedRuntimes,
 		SuspendedRuntimes: suspendedRuntimes,
 		Nodes:             validatorNodes,
 		NodeStatuses:      nodeStatuses,
+		RuntimeStatuses:   runtimeStatuses,
 	}
 	return &gen, nil
 }
@@ -17,11 +17,14 @@ import (
 type Query interface {
 	Entity(context.Context, signature.PublicKey) (*entity.Entity, error)
 	Entities(context.Context) ([]*entity.Entity, error)
+	EntityMetadata(context.Context, signature.PublicKey) (*registry.SignedEntityMetadata, error)
 	Node(context.Context, signature.PublicKey) (*node.Node, error)
 	NodeStatus(context.Context, signature.PublicKey) (*registry.NodeStatus, error)
 	Nodes(context.Context) ([]*node.Node, error)
 	Runtime(context.Context, common.Namespace) (*registry.Runtime, error)
+	RuntimeStatus(context.Context, common.Namespace) (*registry.RuntimeStatus, error)
 	Runtimes(context.Context) ([]*registry.Runtime, error)
+	AllRuntimes(context.Context) ([]*registry.Runtime, error)
 	Genesis(context.Context) (*registry.Genesis, error)
 }
 
@@ -53,6 +56,10 @@ func (rq *registryQuerier) Entities(ctx context.Context) ([]*entity.Entity, erro
 	return rq.state.Entities(ctx)
 }
 
+func (rq *registryQuerier) EntityMetadata(ctx context.Context, id signature.PublicKey) (*registry.SignedEntityMetadata, error) {
+	return rq.state.EntityMetadata(ctx, id)
+}
+
 func (rq *registryQuerier) Node(ctx context.Context, id signature.PublicKey) (*node.Node, error) {
 	epoch, err := rq.queryState.GetEpoch(ctx, rq.height)
 	if err != nil {
@@ -105,6 +112,14 @@ func (rq *registryQuerier) Runtimes(ctx context.Context) ([]*registry.Runtime, e
 	return rq.state.Runtimes(ctx)
 }
 
+func (rq *registryQuerier) AllRuntimes(ctx context.Context) ([]*registry.Runtime, error) {
+	return rq.state.AllRuntimes(ctx)
+}
+
+func (rq *registryQuerier) RuntimeStatus(ctx context.Context, id common.Namespace) (*registry.RuntimeStatus, error) {
+	return rq.state.RuntimeStatus(ctx, id)
+}
+
 func (app *registryApplication) QueryFactory() interface{} {
 	return &QueryFactory{app.state}
 }
@@ -74,6 +74,13 @@ func (app *registryApplication) ExecuteTx(ctx *api.Context, tx *transaction.Tran
 		return app.registerEntity(ctx, state, &sigEnt)
 	case registry.MethodDeregisterEntity:
 		return app.deregisterEntity(ctx, state)
+	case registry.MethodSetEntityMetadata:
+		var sigMeta registry.SignedEntityMetadata
+		if err := cbor.Unmarshal(tx.Body, &sigMeta); err != nil {
+			return err
+		}
+
+		return app.setEntityMetadata(ctx, state, &sigMeta)
 	case registry.MethodRegisterNode:
 		var sigNode node.MultiSignedNode
 		if err := cbor.Unmarshal(tx.Body, &sigNode); err != nil {
@@ -88,6 +95,13 @@ func (app *registryApplication) ExecuteTx(ctx *api.Context, tx *transaction.Tran
 		}
 
 		return app.unfreezeNode(ctx, state, &unfreeze)
+	case registry.MethodDeregisterNode:
+		var dereg registry.DeregisterNode
+		if err := cbor.Unmarshal(tx.Body, &dereg); err != nil {
+			return err
+		}
+
+		return app.deregisterNode(ctx, state, &dereg)
 	case registry.MethodRegisterRuntime:
 		var sigRt registry.SignedRuntime
 		if err := cbor.Unmarshal(tx.Body, &sigRt); err != nil {
@@ -95,6 +109,34 @@ func (app *registryApplication) ExecuteTx(ctx *api.Context, tx *transaction.Tran
 		}
 
 		return app.registerRuntime(ctx, state, &sigRt)
+	case registry.MethodSuspendRuntime:
+		var suspend registry.SuspendRuntime
+		if err := cbor.Unmarshal(tx.Body, &suspend); err != nil {
+			return err
+		}
+
+		return app.suspendRuntime(ctx, state, &suspend)
+	case registry.MethodResumeRuntime:
+		var resume registry.ResumeRuntime
+		if err := cbor.Unmarshal(tx.Body, &resume); err != nil {
+			return err
+		}
+
+		return app.resumeRuntime(ctx, state, &resume)
+	case registry.MethodTransferRuntimeOwnership:
+		var transfer registry.TransferRuntimeOwnership
+		if err := cbor.Unmarshal(tx.Body, &transfer); err != nil {
+			return err
+		}
+
+		return app.transferRuntimeOwnership(ctx, state, &transfer)
+	case registry.MethodAcceptRuntimeOwnership:
+		var sigRt registry.SignedRuntime
+		if err := cbor.Unmarshal(tx.Body, &sigRt); err != nil {
+			return err
+		}
+
+		return app.acceptRuntimeOwnership(ctx, state, &sigRt)
 	default:
 		return registry.ErrInvalidArgument
 	}
@@ -152,12 +194,12 @@ func (app *registryApplication) onRegistryEpochChanged(ctx *api.Context, registr
 	// period and then removed. This is required so that expired nodes
 	// can still get slashed while inside the debonding interval as
 	// otherwise the nodes could not be resolved.
+	//
+	// Nodes that explicitly requested deregistration are treated as if
+	// they expired in the epoch of the request, except that their stake
+	// claims are released as soon as the expiration is processed.
 	var expiredNodes []*node.Node
 	for _, node := range nodes {
-		if !node.IsExpired(uint64(registryEpoch)) {
-			continue
-		}
-
 		// Fetch node status to check whether we have already processed the
 		// node expiration (this is required so that we don't emit expiration
 		// events every epoch).
@@ -167,20 +209,32 @@ func (app *registryApplication) onRegistryEpochChanged(ctx *api.Context, registr
 			return fmt.Errorf("registry: onRegistryEpochChanged: couldn't get node status: %w", err)
 		}
 
+		expiration := status.Expiration(node)
+		if expiration >= uint64(registryEpoch) {
+			continue
+		}
+
 		if !status.ExpirationProcessed {
 			expiredNodes = append(expiredNodes, node)
 			status.ExpirationProcessed = true
 			if err = state.SetNodeStatus(ctx, node.ID, status); err != nil {
 				return fmt.Errorf("registry: onRegistryEpochChanged: couldn't set node status: %w", err)
 			}
+
+			// Remove the stake claim for the deregistered node.
+			if status.DeregistrationRequested && !params.DebugBypassStake {
+				if err = stakeAcc.RemoveStakeClaim(node.EntityID, registry.StakeClaimForNode(node.ID)); err != nil {
+					return fmt.Errorf("registry: onRegistryEpochChanged: couldn't remove stake claim: %w", err)
+				}
+			}
 		}
 
 		// If node has been expired for the debonding interval, finally remove it.
-		if math.MaxUint64-node.Expiration < uint64(debondingInterval) {
+		if math.MaxUint64-expiration < uint64(debondingInterval) {
 			// Overflow, the node will never be removed.
 			continue
 		}
-		if epochtime.EpochTime(node.Expiration)+debondingInterval < registryEpoch {
+		if epochtime.EpochTime(expiration)+debondingInterval < registryEpoch {
 			ctx.Logger().Debug("removing expired node",
 				"node_id", node.ID,
 			)
@@ -188,8 +242,9 @@ func (app *registryApplication) onRegistryEpochChanged(ctx *api.Context, registr
 				return fmt.Errorf("registry: onRegistryEpochChanged: couldn't remove node: %w", err)
 			}
 
-			// Remove the stake claim for the given node.
-			if !params.DebugBypassStake {
+			// Remove the stake claim for the given node (unless it has
+			// already been removed on deregistration).
+			if !status.DeregistrationRequested && !params.DebugBypassStake {
 				if err = stakeAcc.RemoveStakeClaim(node.EntityID, registry.StakeClaimForNode(node.ID)); err != nil {
 					return fmt.Errorf("registry: onRegistryEpochChanged: couldn't remove stake claim: %w", err)
 				}
@@ -69,6 +69,15 @@ var (
 	//
 	// Value is empty.
 	signedRuntimeByEntityKeyFmt = keyformat.New(0x19, keyformat.H(&signature.PublicKey{}), keyformat.H(&common.Namespace{}))
+	// runtimeStatusKeyFmt is the key format used for owner-controlled runtime
+	// statuses.
+	//
+	// Value is CBOR-serialized runtime status.
+	runtimeStatusKeyFmt = keyformat.New(0x1a, keyformat.H(&common.Namespace{}))
+	// entityMetadataKeyFmt is the key format used for signed entity metadata.
+	//
+	// Value is CBOR-serialized signed entity metadata.
+	entityMetadataKeyFmt = keyformat.New(0x1b, keyformat.H(&signature.PublicKey{}))
 )
 
 // ImmutableState is the immutable registry state wrapper.
@@ -443,6 +452,74 @@ func (s *ImmutableState) NodeStatus(ctx context.Context, id signature.PublicKey)
 	return &status, nil
 }
 
+// RuntimeStatus returns a specific runtime status.
+func (s *ImmutableState) RuntimeStatus(ctx context.Context, id common.Namespace) (*registry.RuntimeStatus, error) {
+	var status registry.RuntimeStatus
+	_, err := s.getSignedRuntime(ctx, signedRuntimeKeyFmt, id)
+	switch err {
+	case nil:
+	case registry.ErrNoSuchRuntime:
+		if _, err = s.getSignedRuntime(ctx, suspendedRuntimeKeyFmt, id); err != nil {
+			return nil, err
+		}
+		status.Suspended = true
+	default:
+		return nil, err
+	}
+
+	value, err := s.is.Get(ctx, runtimeStatusKeyFmt.Encode(&id))
+	if err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	if value != nil {
+		if err = cbor.Unmarshal(value, &status); err != nil {
+			return nil, abciAPI.UnavailableStateError(err)
+		}
+	}
+	return &status, nil
+}
+
+// EntityMetadata looks up the signed metadata of an entity.
+func (s *ImmutableState) EntityMetadata(ctx context.Context, id signature.PublicKey) (*registry.SignedEntityMetadata, error) {
+	value, err := s.is.Get(ctx, entityMetadataKeyFmt.Encode(&id))
+	if err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	if value == nil {
+		return nil, registry.ErrNoSuchEntityMetadata
+	}
+
+	var sigMeta registry.SignedEntityMetadata
+	if err = cbor.Unmarshal(value, &sigMeta); err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	return &sigMeta, nil
+}
+
+// AllEntityMetadata returns a list of all signed entity metadata documents.
+func (s *ImmutableState) AllEntityMetadata(ctx context.Context) ([]*registry.SignedEntityMetadata, error) {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	var metadata []*registry.SignedEntityMetadata
+	for it.Seek(entityMetadataKeyFmt.Encode()); it.Valid(); it.Next() {
+		if !entityMetadataKeyFmt.Decode(it.Key()) {
+			break
+		}
+
+		var sigMeta registry.SignedEntityMetadata
+		if err := cbor.Unmarshal(it.Value(), &sigMeta); err != nil {
+			return nil, abciAPI.UnavailableStateError(err)
+		}
+
+		metadata = append(metadata, &sigMeta)
+	}
+	if it.Err() != nil {
+		return nil, abciAPI.UnavailableStateError(it.Err())
+	}
+	return metadata, nil
+}
+
 // HasEntityNodes checks whether an entity has any registered nodes.
 func (s *ImmutableState) HasEntityNodes(ctx context.Context, id signature.PublicKey) (bool, error) {
 	it := s.is.NewIterator(ctx)
@@ -551,6 +628,22 @@ func (s *MutableState) RemoveEntity(ctx context.Context, id signature.PublicKey)
 	return nil, registry.ErrNoSuchEntity
 }
 
+// SetEntityMetadata sets the signed metadata of a registered entity.
+func (s *MutableState) SetEntityMetadata(ctx context.Context, id signature.PublicKey, sigMeta *registry.SignedEntityMetadata) error {
+	err := s.ms.Insert(ctx, entityMetadataKeyFmt.Encode(&id), cbor.Marshal(sigMeta))
+	return abciAPI.UnavailableStateError(err)
+}
+
+// RemoveEntityMetadata removes the signed metadata of an entity and returns
+// true iff the metadata existed.
+func (s *MutableState) RemoveEntityMetadata(ctx context.Context, id signature.PublicKey) (bool, error) {
+	data, err := s.ms.RemoveExisting(ctx, entityMetadataKeyFmt.Encode(&id))
+	if err != nil {
+		return false, abciAPI.UnavailableStateError(err)
+	}
+	return data != nil, nil
+}
+
 // SetNode sets a signed node descriptor for a registered node.
 func (s *MutableState) SetNode(ctx context.Context, existingNode *node.Node, node *node.Node, signedNode *node.MultiSignedNode) error {
 	rawNodeID, err := node.ID.MarshalBinary()
@@ -682,6 +775,37 @@ func (s *MutableState) ResumeRuntime(ctx context.Context, id common.Namespace) e
 	return abciAPI.UnavailableStateError(err)
 }
 
+// TransferRuntime sets a signed runtime descriptor for a registered runtime
+// whose owner has changed.
+func (s *MutableState) TransferRuntime(
+	ctx context.Context,
+	previousOwner signature.PublicKey,
+	rt *registry.Runtime,
+	sigRt *registry.SignedRuntime,
+	suspended bool,
+) error {
+	if err := s.ms.Remove(ctx, signedRuntimeByEntityKeyFmt.Encode(&previousOwner, &rt.ID)); err != nil {
+		return abciAPI.UnavailableStateError(err)
+	}
+	return s.SetRuntime(ctx, rt, sigRt, suspended)
+}
+
+// SetRuntimeStatus sets the owner-controlled status of a registered runtime.
+//
+// The Suspended flag is derived from the runtime's suspension state and is
+// not persisted.
+func (s *MutableState) SetRuntimeStatus(ctx context.Context, id common.Namespace, status *registry.RuntimeStatus) error {
+	if status.IsEmpty() {
+		err := s.ms.Remove(ctx, runtimeStatusKeyFmt.Encode(&id))
+		return abciAPI.UnavailableStateError(err)
+	}
+
+	st := *status
+	st.Suspended = false
+	err := s.ms.Insert(ctx, runtimeStatusKeyFmt.Encode(&id), cbor.Marshal(st))
+	return abciAPI.UnavailableStateError(err)
+}
+
 // SetNodeStatus sets a status for a registered node.
 func (s *MutableState) SetNodeStatus(ctx context.Context, id signature.PublicKey, status *registry.NodeStatus) error {
 	err := s.ms.Insert(ctx, nodeStatusKeyFmt.Encode(&id), cbor.Marshal(status))
@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/oasislabs/oasis-core/go/common"
 	"github.com/oasislabs/oasis-core/go/common/crypto/signature"
 	memorySigner "github.com/oasislabs/oasis-core/go/common/crypto/signature/signers/memory"
 	"github.com/oasislabs/oasis-core/go/common/node"
@@ -143,3 +144,131 @@ func TestNodeUpdate(t *testing.T) {
 	require.Error(err, "TLS mapping should be gone")
 	require.Equal(registry.ErrNoSuchNode, err, "TLS mapping should be gone")
 }
+
+func TestRuntimeStatusAndTransfer(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1580461674, 0)
+	appState := abciAPI.NewMockApplicationState(abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextBeginBlock, now)
+	defer ctx.Close()
+
+	s := NewMutableState(ctx.State())
+
+	owner1 := memorySigner.NewTestSigner("consensus/tendermint/apps/registry/state: runtime owner 1")
+	owner2 := memorySigner.NewTestSigner("consensus/tendermint/apps/registry/state: runtime owner 2")
+
+	var rtID common.Namespace
+	rt := registry.Runtime{
+		ID:       rtID,
+		EntityID: owner1.Public(),
+	}
+	sigRt, err := registry.SignRuntime(owner1, registry.RegisterRuntimeSignatureContext, &rt)
+	require.NoError(err, "SignRuntime")
+	err = s.SetRuntime(ctx, &rt, sigRt, false)
+	require.NoError(err, "SetRuntime")
+
+	_, err = s.RuntimeStatus(ctx, common.Namespace{1})
+	require.Equal(registry.ErrNoSuchRuntime, err, "status of unknown runtime")
+
+	status, err := s.RuntimeStatus(ctx, rtID)
+	require.NoError(err, "RuntimeStatus")
+	require.True(status.IsEmpty(), "status should be empty")
+	require.False(status.Suspended, "runtime should not be suspended")
+
+	// Suspend the runtime by owner.
+	status.SuspendedByOwner = true
+	err = s.SetRuntimeStatus(ctx, rtID, status)
+	require.NoError(err, "SetRuntimeStatus")
+	err = s.SuspendRuntime(ctx, rtID)
+	require.NoError(err, "SuspendRuntime")
+
+	status, err = s.RuntimeStatus(ctx, rtID)
+	require.NoError(err, "RuntimeStatus")
+	require.True(status.Suspended, "runtime should be suspended")
+	require.True(status.SuspendedByOwner, "runtime should be suspended by owner")
+
+	// Transfer the runtime to another owner.
+	hasRuntimes, err := s.HasEntityRuntimes(ctx, owner1.Public())
+	require.NoError(err, "HasEntityRuntimes")
+	require.True(hasRuntimes, "previous owner should have runtimes")
+
+	rt.EntityID = owner2.Public()
+	sigRt, err = registry.SignRuntime(owner2, registry.RegisterRuntimeSignatureContext, &rt)
+	require.NoError(err, "SignRuntime")
+	err = s.TransferRuntime(ctx, owner1.Public(), &rt, sigRt, status.Suspended)
+	require.NoError(err, "TransferRuntime")
+
+	hasRuntimes, err = s.HasEntityRuntimes(ctx, owner1.Public())
+	require.NoError(err, "HasEntityRuntimes")
+	require.False(hasRuntimes, "previous owner should not have runtimes")
+	hasRuntimes, err = s.HasEntityRuntimes(ctx, owner2.Public())
+	require.NoError(err, "HasEntityRuntimes")
+	require.True(hasRuntimes, "new owner should have runtimes")
+
+	resRt, err := s.SuspendedRuntime(ctx, rtID)
+	require.NoError(err, "SuspendedRuntime")
+	require.EqualValues(owner2.Public(), resRt.EntityID, "runtime should have the new owner")
+
+	// Clearing the status should remove it.
+	status.SuspendedByOwner = false
+	err = s.SetRuntimeStatus(ctx, rtID, status)
+	require.NoError(err, "SetRuntimeStatus")
+	status, err = s.RuntimeStatus(ctx, rtID)
+	require.NoError(err, "RuntimeStatus")
+	require.True(status.IsEmpty(), "status should be empty")
+	require.True(status.Suspended, "runtime should still be suspended")
+}
+
+func TestNodeDeregistrationStatus(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1580461674, 0)
+	appState := abciAPI.NewMockApplicationState(abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextBeginBlock, now)
+	defer ctx.Close()
+
+	s := NewMutableState(ctx.State())
+
+	n := node.Node{
+		DescriptorVersion: node.LatestNodeDescriptorVersion,
+		ID:                nodeSigner.Public(),
+		P2P: node.P2PInfo{
+			ID: p2pSigner1.Public(),
+		},
+		Consensus: node.ConsensusInfo{
+			ID: consensusSigner1.Public(),
+		},
+		TLS: node.TLSInfo{
+			PubKey: tlsSigner1.Public(),
+		},
+		Expiration: 10,
+	}
+	err := s.SetNode(ctx, nil, &n, mustMultiSignNode(t, &n))
+	require.NoError(err, "SetNode")
+	err = s.SetNodeStatus(ctx, n.ID, &registry.NodeStatus{})
+	require.NoError(err, "SetNodeStatus")
+
+	// Request deregistration.
+	status, err := s.NodeStatus(ctx, n.ID)
+	require.NoError(err, "NodeStatus")
+	require.False(status.DeregistrationRequested, "deregistration should not be requested by default")
+	require.EqualValues(10, status.Expiration(&n), "expiration should be the descriptor expiration")
+	status.DeregistrationRequested = true
+	status.DeregistrationEpoch = 5
+	err = s.SetNodeStatus(ctx, n.ID, status)
+	require.NoError(err, "SetNodeStatus")
+
+	status, err = s.NodeStatus(ctx, n.ID)
+	require.NoError(err, "NodeStatus")
+	require.True(status.DeregistrationRequested, "deregistration should be requested")
+	require.EqualValues(5, status.Expiration(&n), "expiration should be lowered to the deregistration epoch")
+
+	// Removing the node should also remove its status.
+	err = s.RemoveNode(ctx, &n)
+	require.NoError(err, "RemoveNode")
+	_, err = s.Node(ctx, n.ID)
+	require.Equal(registry.ErrNoSuchNode, err, "node should be gone")
+	_, err = s.NodeStatus(ctx, n.ID)
+	require.Equal(registry.ErrNoSuchNode, err, "node status should be gone")
+}
@@ -1,14 +1,17 @@
 package registry
 
 import (
+	"bytes"
 	"fmt"
 
+	"github.com/oasislabs/oasis-core/go/common"
 	"github.com/oasislabs/oasis-core/go/common/cbor"
 	"github.com/oasislabs/oasis-core/go/common/entity"
 	"github.com/oasislabs/oasis-core/go/common/node"
 	"github.com/oasislabs/oasis-core/go/consensus/tendermint/api"
 	registryState "github.com/oasislabs/oasis-core/go/consensus/tendermint/apps/registry/state"
 	stakingState "github.com/oasislabs/oasis-core/go/consensus/tendermint/apps/staking/state"
+	epochtime "github.com/oasislabs/oasis-core/go/epochtime/api"
 	registry "github.com/oasislabs/oasis-core/go/registry/api"
 	staking "github.com/oasislabs/oasis-core/go/staking/api"
 )
@@ -142,7 +145,96 @@ func (app *registryApplication) deregisterEntity(ctx *api.Context, state *regist
 	tagV := &EntityDeregistration{
 		Entity: *removedEntity,
 	}
-	ctx.EmitEvent(api.NewEventBuilder(app.Name()).Attribute(KeyEntityDeregistered, cbor.Marshal(tagV)))
+	evb := api.NewEventBuilder(app.Name()).Attribute(KeyEntityDeregistered, cbor.Marshal(tagV))
+
+	// Remove any entity metadata as well.
+	hadMetadata, err := state.RemoveEntityMetadata(ctx, id)
+	if err != nil {
+		return fmt.Errorf("DeregisterEntity: failed to remove entity metadata: %w", err)
+	}
+	if hadMetadata {
+		evb = evb.Attribute(KeyEntityMetadata, cbor.Marshal(&registry.EntityMetadataEvent{EntityID: id}))
+	}
+
+	ctx.EmitEvent(evb)
+
+	return nil
+}
+
+func (app *registryApplication) setEntityMetadata(
+	ctx *api.Context,
+	state *registryState.MutableState,
+	sigMeta *registry.SignedEntityMetadata,
+) error {
+	// Verify metadata signature and validity.
+	id, metadata, err := registry.VerifyEntityMetadataArgs(sigMeta)
+	if err != nil {
+		ctx.Logger().Error("SetEntityMetadata: invalid metadata",
+			"err", err,
+		)
+		return err
+	}
+
+	if ctx.IsCheckOnly() {
+		return nil
+	}
+
+	// Charge gas for this transaction.
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		ctx.Logger().Error("SetEntityMetadata: failed to fetch consensus parameters",
+			"err", err,
+		)
+		return err
+	}
+	if err = ctx.Gas().UseGas(1, registry.GasOpSetEntityMetadata, params.GasCosts); err != nil {
+		return err
+	}
+
+	// Only registered entities may have metadata.
+	if _, err = state.Entity(ctx, id); err != nil {
+		ctx.Logger().Error("SetEntityMetadata: failed to fetch entity",
+			"err", err,
+			"entity_id", id,
+		)
+		return err
+	}
+
+	// Prevent replays of older metadata documents.
+	existing, err := state.EntityMetadata(ctx, id)
+	switch err {
+	case nil:
+		var existingMeta registry.EntityMetadata
+		if err = cbor.Unmarshal(existing.Blob, &existingMeta); err != nil {
+			return fmt.Errorf("SetEntityMetadata: corrupted entity metadata: %w", err)
+		}
+		if metadata.Serial <= existingMeta.Serial {
+			ctx.Logger().Error("SetEntityMetadata: serial number not increased",
+				"entity_id", id,
+				"serial", metadata.Serial,
+				"existing_serial", existingMeta.Serial,
+			)
+			return registry.ErrInvalidArgument
+		}
+	case registry.ErrNoSuchEntityMetadata:
+	default:
+		return err
+	}
+
+	if err = state.SetEntityMetadata(ctx, id, sigMeta); err != nil {
+		return fmt.Errorf("SetEntityMetadata: failed to set entity metadata: %w", err)
+	}
+
+	ctx.Logger().Debug("SetEntityMetadata: complete",
+		"entity_id", id,
+		"serial", metadata.Serial,
+	)
+
+	ev := &registry.EntityMetadataEvent{
+		EntityID: id,
+		Metadata: sigMeta,
+	}
+	ctx.EmitEvent(api.NewEventBuilder(app.Name()).Attribute(KeyEntityMetadata, cbor.Marshal(ev)))
 
 	return nil
 }
@@ -230,14 +322,10 @@ func (app *registryApplication) registerNode( // nolint: gocyclo
 		}
 	}
 
-	// Check runtime's whitelist.
+	// Check runtime admission policies.
 	for _, rt := range paidRuntimes {
-		if rt.AdmissionPolicy.EntityWhitelist != nil && !rt.AdmissionPolicy.EntityWhitelist.Entities[newNode.EntityID] {
-			ctx.Logger().Error("RegisterNode: node's entity not in a runtime's whitelist",
-				"entity", newNode.EntityID,
-				"runtime", rt.ID,
-			)
-			return registry.ErrForbidden
+		if err = verifyRuntimeAdmissionPolicy(ctx, state, rt, newNode, epoch); err != nil {
+			return err
 		}
 	}
 
@@ -343,6 +431,8 @@ func (app *registryApplication) registerNode( // nolint: gocyclo
 
 			// Reset expiration processed flag as the node is live again.
 			status.ExpirationProcessed = false
+			status.DeregistrationRequested = false
+			status.DeregistrationEpoch = 0
 		} else {
 			// Node doesn't exist, create empty status.
 			status = &registry.NodeStatus{}
@@ -373,6 +463,28 @@ func (app *registryApplication) registerNode( // nolint: gocyclo
 			)
 			return fmt.Errorf("failed to set node: %w", err)
 		}
+
+		// A node that re-registers after requesting deregistration wants
+		// to stay in the registry, cancel the deregistration. The stake
+		// claim has been re-added above in case it was already released.
+		var status *registry.NodeStatus
+		if status, err = state.NodeStatus(ctx, newNode.ID); err != nil {
+			ctx.Logger().Error("RegisterNode: failed to get node status",
+				"err", err,
+			)
+			return registry.ErrInvalidArgument
+		}
+		if status.DeregistrationRequested {
+			status.ExpirationProcessed = false
+			status.DeregistrationRequested = false
+			status.DeregistrationEpoch = 0
+			if err = state.SetNodeStatus(ctx, newNode.ID, status); err != nil {
+				ctx.Logger().Error("RegisterNode: failed to set node status",
+					"err", err,
+				)
+				return fmt.Errorf("failed to set node status: %w", err)
+			}
+		}
 	}
 
 	// If a runtime was previously suspended and this node now paid maintenance
@@ -385,8 +497,16 @@ func (app *registryApplication) registerNode( // nolint: gocyclo
 				continue
 			}
 		}
+		// Runtimes suspended by their owner can only be resumed by the owner.
+		rtStatus, err := state.RuntimeStatus(ctx, rt.ID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch runtime status: %w", err)
+		}
+		if rtStatus.SuspendedByOwner {
+			continue
+		}
 
-		err := state.ResumeRuntime(ctx, rt.ID)
+		err = state.ResumeRuntime(ctx, rt.ID)
 		switch err {
 		case nil:
 			ctx.Logger().Debug("RegisterNode: resumed runtime",
@@ -487,6 +607,83 @@ func (app *registryApplication) unfreezeNode(
 	return nil
 }
 
+func (app *registryApplication) deregisterNode(
+	ctx *api.Context,
+	state *registryState.MutableState,
+	dereg *registry.DeregisterNode,
+) error {
+	if ctx.IsCheckOnly() {
+		return nil
+	}
+
+	// Charge gas for this transaction.
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		ctx.Logger().Error("DeregisterNode: failed to fetch consensus parameters",
+			"err", err,
+		)
+		return err
+	}
+	if err = ctx.Gas().UseGas(1, registry.GasOpDeregisterNode, params.GasCosts); err != nil {
+		return err
+	}
+
+	// Fetch node descriptor.
+	node, err := state.Node(ctx, dereg.NodeID)
+	if err != nil {
+		ctx.Logger().Error("DeregisterNode: failed to fetch node",
+			"err", err,
+			"node_id", dereg.NodeID,
+		)
+		return err
+	}
+	// Make sure that the deregistration request was signed by either the
+	// node itself or the owning entity.
+	if !ctx.TxSigner().Equal(node.ID) && !ctx.TxSigner().Equal(node.EntityID) {
+		return registry.ErrBadEntityForNode
+	}
+
+	// Fetch node status.
+	status, err := state.NodeStatus(ctx, dereg.NodeID)
+	if err != nil {
+		ctx.Logger().Error("DeregisterNode: failed to fetch node status",
+			"err", err,
+			"node_id", dereg.NodeID,
+			"entity_id", node.EntityID,
+		)
+		return err
+	}
+	// Frozen nodes must not be able to escape by deregistering.
+	if status.IsFrozen() {
+		return registry.ErrForbidden
+	}
+
+	// Expired (or already deregistered) nodes are already on their way out
+	// of the registry.
+	epoch, err := app.state.GetEpoch(ctx, ctx.BlockHeight()+1)
+	if err != nil {
+		return err
+	}
+	if status.Expiration(node) < uint64(epoch) {
+		return registry.ErrNodeExpired
+	}
+
+	// Treat the node as expired in the current epoch. The node is kept in
+	// the registry for the debonding interval so that it can still be
+	// slashed, while its stake claim is released at the next epoch.
+	status.DeregistrationRequested = true
+	status.DeregistrationEpoch = epoch
+	if err = state.SetNodeStatus(ctx, node.ID, status); err != nil {
+		return fmt.Errorf("failed to set node status: %w", err)
+	}
+
+	ctx.Logger().Debug("DeregisterNode: deregistration requested",
+		"node_id", node.ID,
+	)
+
+	return nil
+}
+
 func (app *registryApplication) registerRuntime( // nolint: gocyclo
 	ctx *api.Context,
 	state *registryState.MutableState,
@@ -608,3 +805,360 @@ func (app *registryApplication) registerRuntime( // nolint: gocyclo
 
 	return nil
 }
+
+// verifyRuntimeAdmissionPolicy verifies that the given node is allowed to
+// register for the given runtime.
+func verifyRuntimeAdmissionPolicy(
+	ctx *api.Context,
+	state *registryState.MutableState,
+	rt *registry.Runtime,
+	newNode *node.Node,
+	epoch epochtime.EpochTime,
+) error {
+	policy := rt.AdmissionPolicy
+	switch {
+	case policy.EntityWhitelist != nil:
+		if !policy.EntityWhitelist.Entities[newNode.EntityID] {
+			ctx.Logger().Error("RegisterNode: node's entity not in a runtime's whitelist",
+				"entity", newNode.EntityID,
+				"runtime", rt.ID,
+			)
+			return registry.ErrForbidden
+		}
+	case policy.MinEscrow != nil:
+		acct, err := stakingState.NewMutableState(ctx.State()).Account(ctx, newNode.EntityID)
+		if err != nil {
+			return fmt.Errorf("failed to query entity account: %w", err)
+		}
+		if acct.Escrow.Active.Balance.Cmp(&policy.MinEscrow.MinEscrow) < 0 {
+			ctx.Logger().Error("RegisterNode: node's entity has insufficient escrow for a runtime",
+				"entity", newNode.EntityID,
+				"runtime", rt.ID,
+				"escrow", acct.Escrow.Active.Balance,
+				"min_escrow", policy.MinEscrow.MinEscrow,
+			)
+			return registry.ErrForbidden
+		}
+	case policy.PerEntityNodeLimit != nil:
+		// Determine the limited roles that the node has.
+		var roles []node.RolesMask
+		for role := range policy.PerEntityNodeLimit.Limits {
+			if newNode.HasRoles(role) {
+				roles = append(roles, role)
+			}
+		}
+		if len(roles) == 0 {
+			return nil
+		}
+
+		// Count other live nodes of the same entity registered for the runtime.
+		nodes, err := state.Nodes(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to query nodes: %w", err)
+		}
+		counts := make(map[node.RolesMask]uint64)
+		for _, n := range nodes {
+			if !n.EntityID.Equal(newNode.EntityID) || n.ID.Equal(newNode.ID) || n.IsExpired(uint64(epoch)) {
+				continue
+			}
+			if n.GetRuntime(rt.ID) == nil {
+				continue
+			}
+			for _, role := range roles {
+				if n.HasRoles(role) {
+					counts[role]++
+				}
+			}
+		}
+		for _, role := range roles {
+			if counts[role] >= policy.PerEntityNodeLimit.Limits[role] {
+				ctx.Logger().Error("RegisterNode: node's entity has too many nodes for a runtime",
+					"entity", newNode.EntityID,
+					"runtime", rt.ID,
+					"role", role,
+					"limit", policy.PerEntityNodeLimit.Limits[role],
+				)
+				return registry.ErrForbidden
+			}
+		}
+	}
+	return nil
+}
+
+func (app *registryApplication) suspendRuntime(
+	ctx *api.Context,
+	state *registryState.MutableState,
+	suspend *registry.SuspendRuntime,
+) error {
+	if ctx.IsCheckOnly() {
+		return nil
+	}
+
+	rt, status, err := app.prepareRuntimeLifecycleTx(ctx, state, suspend.RuntimeID)
+	if err != nil {
+		return err
+	}
+	if status.SuspendedByOwner {
+		ctx.Logger().Error("SuspendRuntime: runtime already suspended by owner",
+			"runtime_id", rt.ID,
+		)
+		return registry.ErrInvalidArgument
+	}
+
+	status.SuspendedByOwner = true
+	if err = state.SetRuntimeStatus(ctx, rt.ID, status); err != nil {
+		return fmt.Errorf("failed to set runtime status: %w", err)
+	}
+	// The runtime may have already been suspended due to unpaid maintenance
+	// fees in which case it only needs to be marked as suspended by owner.
+	if !status.Suspended {
+		if err = state.SuspendRuntime(ctx, rt.ID); err != nil {
+			return fmt.Errorf("failed to suspend runtime: %w", err)
+		}
+	}
+
+	ctx.Logger().Debug("SuspendRuntime: suspended",
+		"runtime_id", rt.ID,
+	)
+
+	ctx.EmitEvent(api.NewEventBuilder(app.Name()).Attribute(KeyRuntimeSuspended, cbor.Marshal(&registry.RuntimeEvent{
+		Runtime:   rt,
+		Suspended: true,
+	})))
+
+	return nil
+}
+
+func (app *registryApplication) resumeRuntime(
+	ctx *api.Context,
+	state *registryState.MutableState,
+	resume *registry.ResumeRuntime,
+) error {
+	if ctx.IsCheckOnly() {
+		return nil
+	}
+
+	rt, status, err := app.prepareRuntimeLifecycleTx(ctx, state, resume.RuntimeID)
+	if err != nil {
+		return err
+	}
+	if !status.SuspendedByOwner {
+		ctx.Logger().Error("ResumeRuntime: runtime not suspended by owner",
+			"runtime_id", rt.ID,
+		)
+		return registry.ErrInvalidArgument
+	}
+
+	// Only resume a runtime if the entity has enough stake to avoid having the runtime be
+	// suspended again on the next epoch transition.
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		return err
+	}
+	if !params.DebugBypassStake {
+		if err = stakingState.CheckStakeClaims(ctx, rt.EntityID); err != nil {
+			ctx.Logger().Error("ResumeRuntime: insufficient stake",
+				"err", err,
+				"entity_id", rt.EntityID,
+			)
+			return err
+		}
+	}
+
+	status.SuspendedByOwner = false
+	if err = state.SetRuntimeStatus(ctx, rt.ID, status); err != nil {
+		return fmt.Errorf("failed to set runtime status: %w", err)
+	}
+	if err = state.ResumeRuntime(ctx, rt.ID); err != nil {
+		return fmt.Errorf("failed to resume runtime: %w", err)
+	}
+
+	ctx.Logger().Debug("ResumeRuntime: resumed",
+		"runtime_id", rt.ID,
+	)
+
+	ctx.EmitEvent(api.NewEventBuilder(app.Name()).Attribute(KeyRuntimeRegistered, cbor.Marshal(rt)))
+
+	return nil
+}
+
+func (app *registryApplication) transferRuntimeOwnership(
+	ctx *api.Context,
+	state *registryState.MutableState,
+	transfer *registry.TransferRuntimeOwnership,
+) error {
+	if ctx.IsCheckOnly() {
+		return nil
+	}
+
+	rt, status, err := app.prepareRuntimeLifecycleTx(ctx, state, transfer.RuntimeID)
+	if err != nil {
+		return err
+	}
+
+	if transfer.NewOwner.Equal(rt.EntityID) {
+		// Transferring to the current owner cancels any pending transfer.
+		status.PendingOwner = nil
+	} else {
+		// Make sure the new owner is a registered entity.
+		if _, err = state.Entity(ctx, transfer.NewOwner); err != nil {
+			ctx.Logger().Error("TransferRuntimeOwnership: failed to fetch new owner",
+				"err", err,
+				"new_owner", transfer.NewOwner,
+			)
+			return err
+		}
+		newOwner := transfer.NewOwner
+		status.PendingOwner = &newOwner
+	}
+	if err = state.SetRuntimeStatus(ctx, rt.ID, status); err != nil {
+		return fmt.Errorf("failed to set runtime status: %w", err)
+	}
+
+	ctx.Logger().Debug("TransferRuntimeOwnership: pending acceptance",
+		"runtime_id", rt.ID,
+		"pending_owner", status.PendingOwner,
+	)
+
+	return nil
+}
+
+func (app *registryApplication) acceptRuntimeOwnership(
+	ctx *api.Context,
+	state *registryState.MutableState,
+	sigRt *registry.SignedRuntime,
+) error {
+	if ctx.IsCheckOnly() {
+		return nil
+	}
+
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		ctx.Logger().Error("AcceptRuntimeOwnership: failed to fetch consensus parameters",
+			"err", err,
+		)
+		return err
+	}
+	if err = ctx.Gas().UseGas(1, registry.GasOpRuntimeLifecycle, params.GasCosts); err != nil {
+		return err
+	}
+
+	var rt registry.Runtime
+	if err = sigRt.Open(registry.RegisterRuntimeSignatureContext, &rt); err != nil {
+		ctx.Logger().Error("AcceptRuntimeOwnership: invalid signature",
+			"signed_runtime", sigRt,
+		)
+		return registry.ErrInvalidSignature
+	}
+	// The new owner must sign both the transaction and the descriptor.
+	if !sigRt.Signature.PublicKey.Equal(ctx.TxSigner()) || !rt.EntityID.Equal(ctx.TxSigner()) {
+		return registry.ErrIncorrectTxSigner
+	}
+	if _, err = state.Entity(ctx, rt.EntityID); err != nil {
+		ctx.Logger().Error("AcceptRuntimeOwnership: failed to fetch new owner",
+			"err", err,
+			"new_owner", rt.EntityID,
+		)
+		return err
+	}
+
+	existingRt, err := state.AnyRuntime(ctx, rt.ID)
+	if err != nil {
+		return err
+	}
+	status, err := state.RuntimeStatus(ctx, rt.ID)
+	if err != nil {
+		return err
+	}
+	if status.PendingOwner == nil || !status.PendingOwner.Equal(rt.EntityID) {
+		return registry.ErrNoPendingOwnershipTransfer
+	}
+
+	// Make sure that the owner is the only thing that changes.
+	cmpRt := rt
+	cmpRt.EntityID = existingRt.EntityID
+	if !bytes.Equal(cbor.Marshal(&cmpRt), cbor.Marshal(existingRt)) {
+		ctx.Logger().Error("AcceptRuntimeOwnership: runtime descriptor changed",
+			"runtime_id", rt.ID,
+		)
+		return registry.ErrRuntimeUpdateNotAllowed
+	}
+
+	// Move the runtime stake claim to the new owner.
+	if !params.DebugBypassStake {
+		claim := registry.StakeClaimForRuntime(rt.ID)
+		thresholds := registry.StakeThresholdsForRuntime(&rt)
+
+		if err = stakingState.AddStakeClaim(ctx, rt.EntityID, claim, thresholds); err != nil {
+			ctx.Logger().Error("AcceptRuntimeOwnership: insufficent stake",
+				"err", err,
+				"entity_id", rt.EntityID,
+			)
+			return err
+		}
+		if err = stakingState.RemoveStakeClaim(ctx, existingRt.EntityID, claim); err != nil {
+			return fmt.Errorf("failed to remove runtime stake claim: %w", err)
+		}
+	}
+
+	status.PendingOwner = nil
+	if err = state.SetRuntimeStatus(ctx, rt.ID, status); err != nil {
+		return fmt.Errorf("failed to set runtime status: %w", err)
+	}
+	if err = state.TransferRuntime(ctx, existingRt.EntityID, &rt, sigRt, status.Suspended); err != nil {
+		return fmt.Errorf("failed to transfer runtime: %w", err)
+	}
+
+	ctx.Logger().Debug("AcceptRuntimeOwnership: transferred",
+		"runtime_id", rt.ID,
+		"previous_owner", existingRt.EntityID,
+		"new_owner", rt.EntityID,
+	)
+
+	ctx.EmitEvent(api.NewEventBuilder(app.Name()).Attribute(KeyRuntimeOwnershipTransferred, cbor.Marshal(&registry.RuntimeEvent{
+		Runtime:       &rt,
+		Suspended:     status.Suspended,
+		PreviousOwner: &existingRt.EntityID,
+	})))
+
+	return nil
+}
+
+// prepareRuntimeLifecycleTx charges gas for a runtime lifecycle transaction
+// and returns the runtime and its status after verifying that the
+// transaction has been signed by the runtime owner.
+func (app *registryApplication) prepareRuntimeLifecycleTx(
+	ctx *api.Context,
+	state *registryState.MutableState,
+	id common.Namespace,
+) (*registry.Runtime, *registry.RuntimeStatus, error) {
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		ctx.Logger().Error("failed to fetch consensus parameters",
+			"err", err,
+		)
+		return nil, nil, err
+	}
+	if err = ctx.Gas().UseGas(1, registry.GasOpRuntimeLifecycle, params.GasCosts); err != nil {
+		return nil, nil, err
+	}
+
+	rt, err := state.AnyRuntime(ctx, id)
+	if err != nil {
+		ctx.Logger().Error("failed to fetch runtime",
+			"err", err,
+			"runtime_id", id,
+		)
+		return nil, nil, err
+	}
+	// Make sure that the request was signed by the owning entity.
+	if !ctx.TxSigner().Equal(rt.EntityID) {
+		return nil, nil, registry.ErrIncorrectTxSigner
+	}
+
+	status, err := state.RuntimeStatus(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rt, status, nil
+}
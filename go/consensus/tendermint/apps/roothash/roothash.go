@@ -362,6 +362,19 @@ func (app *rootHashApplication) ForeignExecuteTx(ctx *tmapi.Context, other abci.
 					if err := app.onNewRuntime(ctx, &rt, nil); err != nil {
 						return err
 					}
+				} else if bytes.Equal(pair.GetKey(), registryapp.KeyRuntimeSuspended) {
+					var rtEv registry.RuntimeEvent
+					if err := cbor.Unmarshal(pair.GetValue(), &rtEv); err != nil {
+						return fmt.Errorf("roothash: failed to deserialize suspended runtime event: %w", err)
+					}
+
+					ctx.Logger().Debug("ForeignDeliverTx: runtime suspended",
+						"runtime", rtEv.Runtime.ID,
+					)
+
+					if err := app.onRuntimeSuspended(ctx, rtEv.Runtime); err != nil {
+						return err
+					}
 				}
 			}
 		}
@@ -370,6 +383,33 @@ func (app *rootHashApplication) ForeignExecuteTx(ctx *tmapi.Context, other abci.
 	return nil
 }
 
+func (app *rootHashApplication) onRuntimeSuspended(ctx *tmapi.Context, runtime *registry.Runtime) error {
+	if !runtime.IsCompute() {
+		return nil
+	}
+
+	state := roothashState.NewMutableState(ctx.State())
+	rtState, err := state.RuntimeState(ctx, runtime.ID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch runtime state: %w", err)
+	}
+	if rtState.Suspended {
+		// Runtime has already been suspended.
+		return nil
+	}
+
+	rtState.Suspended = true
+	rtState.Round = nil
+
+	// Emit an empty block signalling that the runtime was suspended.
+	app.emitEmptyBlock(ctx, rtState, block.Suspended)
+
+	if err = state.SetRuntimeState(ctx, rtState); err != nil {
+		return fmt.Errorf("failed to set runtime state: %w", err)
+	}
+	return nil
+}
+
 func (app *rootHashApplication) onNewRuntime(ctx *tmapi.Context, runtime *registry.Runtime, genesis *roothash.Genesis) error {
 	if !runtime.IsCompute() {
 		ctx.Logger().Warn("onNewRuntime: ignoring non-compute runtime",
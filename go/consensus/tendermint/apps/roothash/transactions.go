@@ -53,6 +53,26 @@ func (sv *roothashSignatureVerifier) VerifyCommitteeSignatures(kind scheduler.Co
 	return nil
 }
 
+// CommitteePublicKeys returns the public keys of the current committee
+// members of the given kind, in committee order.
+//
+// Implements commitment.SignatureVerifier.
+func (sv *roothashSignatureVerifier) CommitteePublicKeys(kind scheduler.CommitteeKind) ([]signature.PublicKey, error) {
+	committee, err := sv.scheduler.Committee(sv.ctx, kind, sv.runtimeID)
+	if err != nil {
+		return nil, err
+	}
+	if committee == nil {
+		return nil, roothash.ErrInvalidRuntime
+	}
+
+	pks := make([]signature.PublicKey, 0, len(committee.Members))
+	for _, m := range committee.Members {
+		pks = append(pks, m.PublicKey)
+	}
+	return pks, nil
+}
+
 // getRuntimeState fetches the current runtime state and performs common
 // processing and error handling.
 func (app *rootHashApplication) getRuntimeState(
@@ -31,6 +31,12 @@ func (app *schedulerApplication) InitChain(ctx *abciAPI.Context, req types.Reque
 		return fmt.Errorf("failed to set consensus parameters: %w", err)
 	}
 
+	for _, js := range doc.Scheduler.JailedNodes {
+		if err = state.SetJailStatus(ctx, js); err != nil {
+			return fmt.Errorf("failed to set jail status: %w", err)
+		}
+	}
+
 	if doc.Scheduler.Parameters.DebugStaticValidators {
 		ctx.Logger().Warn("static validators are configured")
 
@@ -211,8 +217,14 @@ func (sq *schedulerQuerier) Genesis(ctx context.Context) (*scheduler.Genesis, er
 		return nil, err
 	}
 
+	jailedNodes, err := sq.state.JailedNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	genesis := &scheduler.Genesis{
-		Parameters: *params,
+		Parameters:  *params,
+		JailedNodes: jailedNodes,
 	}
 	return genesis, nil
 }
@@ -0,0 +1,308 @@
+package scheduler
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"github.com/tendermint/tendermint/abci/types"
+
+	"github.com/oasislabs/oasis-core/go/common/crypto/signature"
+	"github.com/oasislabs/oasis-core/go/common/node"
+	"github.com/oasislabs/oasis-core/go/consensus/tendermint/api"
+	registryState "github.com/oasislabs/oasis-core/go/consensus/tendermint/apps/registry/state"
+	schedulerState "github.com/oasislabs/oasis-core/go/consensus/tendermint/apps/scheduler/state"
+	registry "github.com/oasislabs/oasis-core/go/registry/api"
+	scheduler "github.com/oasislabs/oasis-core/go/scheduler/api"
+)
+
+// trackValidatorLiveness records the validators that failed to sign the
+// previous block and jails validator nodes that missed too many blocks at
+// the end of each liveness window. Nodes are not jailed if that would leave
+// fewer than the minimum number of validators eligible for election.
+func (app *schedulerApplication) trackValidatorLiveness(ctx *api.Context, request types.RequestBeginBlock) error {
+	state := schedulerState.NewMutableState(ctx.State())
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch consensus parameters: %w", err)
+	}
+	if params.ValidatorLivenessWindow == 0 || params.DebugStaticValidators {
+		return nil
+	}
+
+	height := ctx.BlockHeight() + 1
+	window, err := state.LivenessWindow(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch liveness window: %w", err)
+	}
+	if window == nil {
+		window = &schedulerState.LivenessWindow{StartHeight: height}
+	}
+
+	regState := registryState.NewMutableState(ctx.State())
+	for _, v := range request.GetLastCommitInfo().Votes {
+		if v.SignedLastBlock {
+			continue
+		}
+
+		// Map address to node.
+		n, lookupErr := regState.NodeByConsensusAddress(ctx, v.Validator.Address)
+		if lookupErr != nil {
+			ctx.Logger().Warn("failed to get validator node",
+				"err", lookupErr,
+				"address", hex.EncodeToString(v.Validator.Address),
+			)
+			continue
+		}
+
+		if window.Missed == nil {
+			window.Missed = make(map[signature.PublicKey]int64)
+		}
+		window.Missed[n.ID]++
+	}
+
+	if height-window.StartHeight+1 < params.ValidatorLivenessWindow {
+		return state.SetLivenessWindow(ctx, window)
+	}
+
+	// End of the liveness window, jail validators that missed too many blocks.
+	epoch, err := app.state.GetCurrentEpoch(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current epoch: %w", err)
+	}
+	maxMissed := params.ValidatorLivenessWindow * int64(params.ValidatorLivenessMaxMissedPercent)
+	nodeIDs := make([]signature.PublicKey, 0, len(window.Missed))
+	for id, missed := range window.Missed {
+		if missed*100 <= maxMissed {
+			continue
+		}
+		nodeIDs = append(nodeIDs, id)
+	}
+	if len(nodeIDs) == 0 {
+		return state.SetLivenessWindow(ctx, &schedulerState.LivenessWindow{StartHeight: height + 1})
+	}
+
+	// Jail the nodes that missed the most blocks first, so that if not all
+	// of them can be jailed the worst offenders are.
+	sort.Slice(nodeIDs, func(i, j int) bool {
+		mi, mj := window.Missed[nodeIDs[i]], window.Missed[nodeIDs[j]]
+		if mi != mj {
+			return mi > mj
+		}
+		return bytes.Compare(nodeIDs[i][:], nodeIDs[j][:]) < 0
+	})
+
+	eligible, err := newEligibleValidators(ctx, state, regState, params)
+	if err != nil {
+		return err
+	}
+	for _, id := range nodeIDs {
+		missed := window.Missed[id]
+
+		var status *scheduler.JailStatus
+		status, err = state.JailStatus(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to fetch jail status: %w", err)
+		}
+		if status != nil {
+			continue
+		}
+
+		var n *node.Node
+		n, err = regState.Node(ctx, id)
+		switch err {
+		case nil:
+		case registry.ErrNoSuchNode:
+			continue
+		default:
+			return fmt.Errorf("failed to fetch node %s: %w", id, err)
+		}
+
+		// Never jail so many validators that the validator set could no
+		// longer be elected.
+		if !eligible.canRemove(n) {
+			ctx.Logger().Warn("not jailing validator node, too few eligible validators would remain",
+				"node_id", id,
+				"missed", missed,
+				"window", params.ValidatorLivenessWindow,
+				"min_validators", params.MinValidators,
+			)
+			continue
+		}
+
+		ctx.Logger().Info("jailing validator node for missing too many blocks",
+			"node_id", id,
+			"missed", missed,
+			"window", params.ValidatorLivenessWindow,
+			"epoch", epoch,
+		)
+
+		if err = state.SetJailStatus(ctx, &scheduler.JailStatus{NodeID: id, JailedAt: epoch}); err != nil {
+			return fmt.Errorf("failed to set jail status: %w", err)
+		}
+		eligible.remove(n)
+	}
+
+	return state.SetLivenessWindow(ctx, &schedulerState.LivenessWindow{StartHeight: height + 1})
+}
+
+// eligibleValidators tracks the number of validators that can be elected
+// from the registered validator nodes that are not jailed.
+type eligibleValidators struct {
+	perEntity    map[signature.PublicKey]int
+	maxPerEntity int
+	minTotal     int
+	total        int
+}
+
+func newEligibleValidators(
+	ctx *api.Context,
+	state *schedulerState.MutableState,
+	regState *registryState.MutableState,
+	params *scheduler.ConsensusParameters,
+) (*eligibleValidators, error) {
+	nodes, err := regState.Nodes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch nodes: %w", err)
+	}
+
+	ev := &eligibleValidators{
+		perEntity:    make(map[signature.PublicKey]int),
+		maxPerEntity: params.MaxValidatorsPerEntity,
+		minTotal:     params.MinValidators,
+	}
+	for _, n := range nodes {
+		if !n.HasRoles(node.RoleValidator) {
+			continue
+		}
+		status, err := state.JailStatus(ctx, n.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch jail status for node %s: %w", n.ID, err)
+		}
+		if status != nil {
+			continue
+		}
+		if ev.perEntity[n.EntityID] < ev.maxPerEntity {
+			ev.total++
+		}
+		ev.perEntity[n.EntityID]++
+	}
+	return ev, nil
+}
+
+// canRemove returns true iff the given node can be removed from the eligible
+// validators without the number of validators that can be elected dropping
+// below the minimum.
+func (ev *eligibleValidators) canRemove(n *node.Node) bool {
+	if !n.HasRoles(node.RoleValidator) || ev.perEntity[n.EntityID] > ev.maxPerEntity {
+		return true
+	}
+	return ev.total > ev.minTotal
+}
+
+func (ev *eligibleValidators) remove(n *node.Node) {
+	if !n.HasRoles(node.RoleValidator) {
+		return
+	}
+	if ev.perEntity[n.EntityID] <= ev.maxPerEntity {
+		ev.total--
+	}
+	ev.perEntity[n.EntityID]--
+}
+
+// removeStaleJailStatuses removes the jail statuses of nodes that no longer
+// exist in the registry (e.g., because they have expired).
+func (app *schedulerApplication) removeStaleJailStatuses(ctx *api.Context) error {
+	state := schedulerState.NewMutableState(ctx.State())
+	statuses, err := state.JailedNodes(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch jailed nodes: %w", err)
+	}
+
+	regState := registryState.NewMutableState(ctx.State())
+	for _, status := range statuses {
+		_, err = regState.Node(ctx, status.NodeID)
+		switch err {
+		case nil:
+			continue
+		case registry.ErrNoSuchNode:
+		default:
+			return fmt.Errorf("failed to fetch node %s: %w", status.NodeID, err)
+		}
+
+		ctx.Logger().Debug("removing jail status of removed node",
+			"node_id", status.NodeID,
+		)
+
+		if err = state.RemoveJailStatus(ctx, status.NodeID); err != nil {
+			return fmt.Errorf("failed to remove jail status: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (app *schedulerApplication) unjail(
+	ctx *api.Context,
+	state *schedulerState.MutableState,
+	unjail *scheduler.Unjail,
+) error {
+	if ctx.IsCheckOnly() {
+		return nil
+	}
+
+	// Charge gas for this transaction.
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		ctx.Logger().Error("Unjail: failed to fetch consensus parameters",
+			"err", err,
+		)
+		return err
+	}
+	if err = ctx.Gas().UseGas(1, scheduler.GasOpUnjail, params.GasCosts); err != nil {
+		return err
+	}
+
+	// Fetch node descriptor.
+	regState := registryState.NewMutableState(ctx.State())
+	node, err := regState.Node(ctx, unjail.NodeID)
+	if err != nil {
+		ctx.Logger().Error("Unjail: failed to fetch node",
+			"err", err,
+			"node_id", unjail.NodeID,
+		)
+		return err
+	}
+	// Make sure that the unjail request was signed by either the node
+	// itself or the owning entity.
+	if !ctx.TxSigner().Equal(node.ID) && !ctx.TxSigner().Equal(node.EntityID) {
+		return scheduler.ErrForbidden
+	}
+
+	status, err := state.JailStatus(ctx, node.ID)
+	if err != nil {
+		return err
+	}
+	if status == nil {
+		return scheduler.ErrNotJailed
+	}
+
+	epoch, err := app.state.GetEpoch(ctx, ctx.BlockHeight()+1)
+	if err != nil {
+		return err
+	}
+	if !status.CanUnjail(params, epoch) {
+		return scheduler.ErrJailed
+	}
+
+	if err = state.RemoveJailStatus(ctx, node.ID); err != nil {
+		return fmt.Errorf("failed to remove jail status: %w", err)
+	}
+
+	ctx.Logger().Debug("Unjail: node unjailed",
+		"node_id", node.ID,
+	)
+
+	return nil
+}
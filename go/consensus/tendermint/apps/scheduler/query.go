@@ -15,6 +15,7 @@ type Query interface {
 	Validators(context.Context) ([]*scheduler.Validator, error)
 	AllCommittees(context.Context) ([]*scheduler.Committee, error)
 	KindsCommittees(context.Context, []scheduler.CommitteeKind) ([]*scheduler.Committee, error)
+	JailedNodes(context.Context) ([]*scheduler.JailStatus, error)
 	Genesis(context.Context) (*scheduler.Genesis, error)
 }
 
@@ -107,3 +108,7 @@ func (app *schedulerApplication) QueryFactory() interface{} {
 func NewQueryFactory(state abciAPI.ApplicationQueryState) *QueryFactory {
 	return &QueryFactory{state}
 }
+
+func (sq *schedulerQuerier) JailedNodes(ctx context.Context) ([]*scheduler.JailStatus, error) {
+	return sq.state.JailedNodes(ctx)
+}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"crypto"
 	"fmt"
+	"io"
+	"math/big"
 	"math/rand"
 	"sort"
 
@@ -58,7 +60,7 @@ func (app *schedulerApplication) ID() uint8 {
 }
 
 func (app *schedulerApplication) Methods() []transaction.MethodName {
-	return nil
+	return scheduler.Methods
 }
 
 func (app *schedulerApplication) Blessed() bool {
@@ -76,6 +78,11 @@ func (app *schedulerApplication) OnRegister(state api.ApplicationState) {
 func (app *schedulerApplication) OnCleanup() {}
 
 func (app *schedulerApplication) BeginBlock(ctx *api.Context, request types.RequestBeginBlock) error {
+	// Track validator liveness.
+	if err := app.trackValidatorLiveness(ctx, request); err != nil {
+		return fmt.Errorf("tendermint/scheduler: failed to track validator liveness: %w", err)
+	}
+
 	// Check if any stake slashing has occurred in the staking layer.
 	// NOTE: This will NOT trigger for any slashing that happens as part of
 	//       any transactions being submitted to the chain.
@@ -84,6 +91,14 @@ func (app *schedulerApplication) BeginBlock(ctx *api.Context, request types.Requ
 	// TODO: We'll later have this for each type of committee.
 	epochChanged, epoch := app.state.EpochChanged(ctx)
 
+	if epochChanged {
+		// Jail statuses of nodes that have been removed from the registry
+		// are no longer needed.
+		if err := app.removeStaleJailStatuses(ctx); err != nil {
+			return fmt.Errorf("tendermint/scheduler: failed to remove stale jail statuses: %w", err)
+		}
+	}
+
 	if epochChanged || slashed {
 		// The 0th epoch will not have suitable entropy for elections, nor
 		// will it have useful node registrations.
@@ -123,6 +138,11 @@ func (app *schedulerApplication) BeginBlock(ctx *api.Context, request types.Requ
 			if status.IsFrozen() {
 				continue
 			}
+			// Nodes which requested deregistration are kept in the registry
+			// so that they can still be slashed, but cannot be scheduled.
+			if status.DeregistrationRequested {
+				continue
+			}
 			// Expired nodes cannot be scheduled (nodes can be expired and not yet removed).
 			if node.IsExpired(uint64(epoch)) {
 				continue
@@ -205,7 +225,19 @@ func (app *schedulerApplication) BeginBlock(ctx *api.Context, request types.Requ
 }
 
 func (app *schedulerApplication) ExecuteTx(ctx *api.Context, tx *transaction.Transaction) error {
-	return fmt.Errorf("tendermint/scheduler: unexpected transaction")
+	state := schedulerState.NewMutableState(ctx.State())
+
+	switch tx.Method {
+	case scheduler.MethodUnjail:
+		var unjail scheduler.Unjail
+		if err := cbor.Unmarshal(tx.Body, &unjail); err != nil {
+			return scheduler.ErrInvalidArgument
+		}
+
+		return app.unjail(ctx, state, &unjail)
+	default:
+		return fmt.Errorf("tendermint/scheduler: invalid method: %s", tx.Method)
+	}
 }
 
 func (app *schedulerApplication) ForeignExecuteTx(ctx *api.Context, other abci.Application, tx *transaction.Transaction) error {
@@ -320,6 +352,61 @@ func (app *schedulerApplication) isSuitableExecutorWorker(ctx *api.Context, n *n
 	return false
 }
 
+// isAdmitted checks whether the node is admitted by the runtime's admission
+// policy for a committee with the given role. The admitted map tracks the
+// number of nodes already admitted per entity and is updated on success.
+func (app *schedulerApplication) isAdmitted(
+	ctx *api.Context,
+	stakeAcc *stakingState.StakeAccumulatorCache,
+	n *node.Node,
+	rt *registry.Runtime,
+	role node.RolesMask,
+	admitted map[signature.PublicKey]uint64,
+) bool {
+	policy := rt.AdmissionPolicy
+	switch {
+	case policy.EntityWhitelist != nil:
+		if !policy.EntityWhitelist.Entities[n.EntityID] {
+			return false
+		}
+	case policy.MinEscrow != nil:
+		var escrow *quantity.Quantity
+		if stakeAcc != nil {
+			var err error
+			if escrow, err = stakeAcc.GetEscrowBalance(n.EntityID); err != nil {
+				ctx.Logger().Error("failed to query escrow balance",
+					"err", err,
+					"entity", n.EntityID,
+				)
+				return false
+			}
+		} else {
+			acct, err := stakingState.NewMutableState(ctx.State()).Account(ctx, n.EntityID)
+			if err != nil {
+				ctx.Logger().Error("failed to query entity account",
+					"err", err,
+					"entity", n.EntityID,
+				)
+				return false
+			}
+			escrow = &acct.Escrow.Active.Balance
+		}
+		if escrow.Cmp(&policy.MinEscrow.MinEscrow) < 0 {
+			return false
+		}
+	case policy.PerEntityNodeLimit != nil:
+		limit, ok := policy.PerEntityNodeLimit.Limits[role]
+		if !ok {
+			break
+		}
+		if admitted[n.EntityID] >= limit {
+			return false
+		}
+	}
+	admitted[n.EntityID]++
+	return true
+}
+
 func (app *schedulerApplication) isSuitableStorageWorker(ctx *api.Context, n *node.Node, rt *registry.Runtime) bool {
 	if !n.HasRoles(node.RoleStorageWorker) {
 		return false
@@ -369,6 +456,136 @@ func GetPerm(beacon []byte, runtimeID common.Namespace, rngCtx []byte, nrNodes i
 	return rng.Perm(nrNodes), nil
 }
 
+// electUniform elects up to wantedNodes nodes uniformly at random from the
+// given list of nodes, electing at most maxNodesPerEntity nodes of any single
+// entity (zero means no limit).
+func electUniform(
+	beacon []byte,
+	runtimeID common.Namespace,
+	rngCtx []byte,
+	nodeList []*node.Node,
+	wantedNodes int,
+	maxNodesPerEntity int,
+) ([]*node.Node, error) {
+	idxs, err := GetPerm(beacon, runtimeID, rngCtx, len(nodeList))
+	if err != nil {
+		return nil, err
+	}
+
+	var elected []*node.Node
+	perEntity := make(map[signature.PublicKey]int)
+	for _, idx := range idxs {
+		n := nodeList[idx]
+		if maxNodesPerEntity > 0 && perEntity[n.EntityID] >= maxNodesPerEntity {
+			continue
+		}
+		perEntity[n.EntityID]++
+		elected = append(elected, n)
+		if len(elected) >= wantedNodes {
+			break
+		}
+	}
+	return elected, nil
+}
+
+// electStakeWeighted elects up to wantedNodes nodes from the given list of
+// nodes such that the probability of an entity's node being elected for each
+// seat is proportional to the entity's escrow balance. At most
+// maxNodesPerEntity nodes of any single entity are elected (zero means no
+// limit) and entities without any escrow are never elected.
+func electStakeWeighted(
+	beacon []byte,
+	runtimeID common.Namespace,
+	rngCtx []byte,
+	escrowFn func(signature.PublicKey) (*quantity.Quantity, error),
+	nodeList []*node.Node,
+	wantedNodes int,
+	maxNodesPerEntity int,
+) ([]*node.Node, error) {
+	drbg, err := drbg.New(crypto.SHA512, beacon, runtimeID[:], rngCtx)
+	if err != nil {
+		return nil, fmt.Errorf("tendermint/scheduler: couldn't instantiate DRBG: %w", err)
+	}
+	rng := rand.New(mathrand.New(drbg))
+
+	// Group nodes by entity, preserving the (deterministic) node order.
+	type entityNodes struct {
+		id     signature.PublicKey
+		escrow *big.Int
+		nodes  []*node.Node
+		seats  int
+	}
+	var entities []*entityNodes
+	entityMap := make(map[signature.PublicKey]*entityNodes)
+	for _, n := range nodeList {
+		ent, ok := entityMap[n.EntityID]
+		if !ok {
+			var escrow *quantity.Quantity
+			if escrow, err = escrowFn(n.EntityID); err != nil {
+				return nil, fmt.Errorf("tendermint/scheduler: failed to get escrow balance for %s: %w", n.EntityID, err)
+			}
+			ent = &entityNodes{
+				id:     n.EntityID,
+				escrow: escrow.ToBigInt(),
+			}
+			entityMap[n.EntityID] = ent
+			if ent.escrow.Sign() > 0 {
+				entities = append(entities, ent)
+			}
+		}
+		ent.nodes = append(ent.nodes, n)
+	}
+
+	var elected []*node.Node
+	for len(elected) < wantedNodes {
+		// Determine entities that can still get a seat.
+		total := new(big.Int)
+		var candidates []*entityNodes
+		for _, ent := range entities {
+			if len(ent.nodes) == 0 || (maxNodesPerEntity > 0 && ent.seats >= maxNodesPerEntity) {
+				continue
+			}
+			candidates = append(candidates, ent)
+			total.Add(total, ent.escrow)
+		}
+		if len(candidates) == 0 {
+			break
+		}
+
+		// Pick an entity proportional to its escrow.
+		var target *big.Int
+		if target, err = drawBigInt(drbg, total); err != nil {
+			return nil, fmt.Errorf("tendermint/scheduler: failed to draw entity: %w", err)
+		}
+		var ent *entityNodes
+		for _, ent = range candidates {
+			if target.Cmp(ent.escrow) < 0 {
+				break
+			}
+			target.Sub(target, ent.escrow)
+		}
+
+		// Pick one of the entity's remaining nodes uniformly.
+		i := rng.Intn(len(ent.nodes))
+		elected = append(elected, ent.nodes[i])
+		ent.nodes = append(ent.nodes[:i], ent.nodes[i+1:]...)
+		ent.seats++
+	}
+	return elected, nil
+}
+
+// drawBigInt draws an integer in [0, bound) from the given DRBG. Only the raw DRBG output is used
+// so that the result does not depend on the Go release. 64 additional bits are drawn in order to
+// make the modulo bias negligible.
+func drawBigInt(drbg io.Reader, bound *big.Int) (*big.Int, error) {
+	buf := make([]byte, (bound.BitLen()+7)/8+8)
+	if _, err := io.ReadFull(drbg, buf); err != nil {
+		return nil, err
+	}
+	v := new(big.Int).SetBytes(buf)
+	return v.Mod(v, bound), nil
+}
+
 // Operates on consensus connection.
 // Return error if node should crash.
 // For non-fatal problems, save a problem condition to the state and return successfully.
@@ -396,8 +613,26 @@ func (app *schedulerApplication) electCommittee(
 		isSuitableFn func(*api.Context, *node.Node, *registry.Runtime) bool
 
 		workerSize, backupSize int
+
+		electionMode      registry.CommitteeElectionMode
+		maxNodesPerEntity int
+
+		role node.RolesMask
 	)
 
+	switch kind {
+	case scheduler.KindComputeExecutor, scheduler.KindComputeMerge, scheduler.KindComputeTxnScheduler:
+		// Election parameters for all compute committees are configured
+		// in the executor parameters.
+		electionMode = rt.Executor.ElectionMode
+		maxNodesPerEntity = int(rt.Executor.MaxNodesPerEntity)
+		role = node.RoleComputeWorker
+	case scheduler.KindStorage:
+		electionMode = rt.Storage.ElectionMode
+		maxNodesPerEntity = int(rt.Storage.MaxNodesPerEntity)
+		role = node.RoleStorageWorker
+	}
+
 	switch kind {
 	case scheduler.KindComputeExecutor:
 		rngCtx = RNGContextExecutor
@@ -426,6 +661,7 @@ func (app *schedulerApplication) electCommittee(
 		return fmt.Errorf("tendermint/scheduler: error while calling needsLeader() on kind %v: %w", kind, err)
 	}
 
+	admitted := make(map[signature.PublicKey]uint64)
 	for _, n := range nodes {
 		// Check if an entity has enough stake.
 		if stakeAcc != nil {
@@ -433,7 +669,7 @@ func (app *schedulerApplication) electCommittee(
 				continue
 			}
 		}
-		if isSuitableFn(ctx, n, rt) {
+		if isSuitableFn(ctx, n, rt) && app.isAdmitted(ctx, stakeAcc, n, rt, role, admitted) {
 			nodeList = append(nodeList, n)
 			if entitiesEligibleForReward != nil {
 				entitiesEligibleForReward[n.EntityID] = true
@@ -469,13 +705,25 @@ func (app *schedulerApplication) electCommittee(
 	}
 
 	// Do the actual election.
-	idxs, err := GetPerm(beacon, rt.ID, rngCtx, nrNodes)
+	var elected []*node.Node
+	switch {
+	case electionMode == registry.ElectionModeStakeWeighted && stakeAcc != nil:
+		elected, err = electStakeWeighted(beacon, rt.ID, rngCtx, stakeAcc.GetEscrowBalance, nodeList, wantedNodes, maxNodesPerEntity)
+	default:
+		if electionMode == registry.ElectionModeStakeWeighted {
+			ctx.Logger().Warn("stake checks are disabled, falling back to uniform election",
+				"kind", kind,
+				"runtime_id", rt.ID,
+			)
+		}
+		elected, err = electUniform(beacon, rt.ID, rngCtx, nodeList, wantedNodes, maxNodesPerEntity)
+	}
 	if err != nil {
 		return err
 	}
 
 	var members []*scheduler.CommitteeNode
-	for i := 0; i < len(idxs); i++ {
+	for i, n := range elected {
 		role := scheduler.Worker
 		if i == 0 && needsLeader {
 			role = scheduler.Leader
@@ -484,11 +732,8 @@ func (app *schedulerApplication) electCommittee(
 		}
 		members = append(members, &scheduler.CommitteeNode{
 			Role:      role,
-			PublicKey: nodeList[idxs[i]].ID,
+			PublicKey: n.ID,
 		})
-		if len(members) >= wantedNodes {
-			break
-		}
 	}
 
 	if len(members) != wantedNodes {
@@ -545,14 +790,24 @@ func (app *schedulerApplication) electValidators(
 	nodes []*node.Node,
 	params *scheduler.ConsensusParameters,
 ) error {
-	// Filter the node list based on eligibility and minimum required
-	// entity stake.
+	state := schedulerState.NewMutableState(ctx.State())
+
+	// Filter the node list based on eligibility, minimum required
+	// entity stake and jailing.
 	var nodeList []*node.Node
 	entMap := make(map[signature.PublicKey]bool)
+	eligible := make(map[signature.PublicKey]signature.PublicKey)
 	for _, n := range nodes {
 		if !n.HasRoles(node.RoleValidator) {
 			continue
 		}
+		jailStatus, err := state.JailStatus(ctx, n.ID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch jail status for node %s: %w", n.ID, err)
+		}
+		if jailStatus != nil {
+			continue
+		}
 		if stakeAcc != nil {
 			if err := stakeAcc.CheckStakeClaims(n.EntityID); err != nil {
 				continue
@@ -560,6 +815,7 @@ func (app *schedulerApplication) electValidators(
 		}
 		nodeList = append(nodeList, n)
 		entMap[n.EntityID] = true
+		eligible[n.Consensus.ID] = n.EntityID
 	}
 
 	// Sort all of the entities that are actually running eligible validator
@@ -638,6 +894,20 @@ electLoop:
 		}
 	}
 
+	// Limit how much the voting power can change in a single election.
+	if params.MaxVotingPowerChangePercent > 0 {
+		var currentValidators map[signature.PublicKey]int64
+		currentValidators, err = state.CurrentValidators(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to fetch current validators: %w", err)
+		}
+		newValidators = limitVotingPowerChange(currentValidators, eligible, newValidators, params.MaxVotingPowerChangePercent)
+
+		// Limiting may keep validators that are being phased out, make sure
+		// that the set still respects the validator caps.
+		newValidators = limitValidatorSetSize(eligible, newValidators, params.MaxValidators, params.MaxValidatorsPerEntity)
+	}
+
 	if len(newValidators) == 0 {
 		return fmt.Errorf("tendermint/scheduler: failed to elect any validators")
 	}
@@ -647,7 +917,6 @@ electLoop:
 
 	// Set the new pending validator set in the ABCI state.  It needs to be
 	// applied in EndBlock.
-	state := schedulerState.NewMutableState(ctx.State())
 	if err = state.PutPendingValidators(ctx, newValidators); err != nil {
 		return fmt.Errorf("failed to set pending validators: %w", err)
 	}
@@ -655,6 +924,105 @@ electLoop:
 	return nil
 }
 
+// limitVotingPowerChange limits the total voting power change between the
+// current and the newly elected validator set to the given percentage of the
+// current total voting power.
+//
+// Current validators that are no longer eligible (e.g., because they have
+// been jailed or their node has expired) are always removed and do not
+// count towards the limit. If the remaining changes exceed the limit, each
+// validator's change is scaled down proportionally, so validators that are
+// leaving the set may be phased out over multiple elections.
+//
+// The eligible map contains the entity of each eligible validator.
+func limitVotingPowerChange(
+	current map[signature.PublicKey]int64,
+	eligible map[signature.PublicKey]signature.PublicKey,
+	pending map[signature.PublicKey]int64,
+	maxChangePercent uint8,
+) map[signature.PublicKey]int64 {
+	if maxChangePercent == 0 || len(current) == 0 {
+		return pending
+	}
+
+	var totalCurrent, totalChange big.Int
+	deltas := make(map[signature.PublicKey]int64)
+	for v, power := range current {
+		totalCurrent.Add(&totalCurrent, big.NewInt(power))
+		if _, ok := eligible[v]; !ok {
+			continue
+		}
+		deltas[v] = pending[v] - power
+	}
+	for v, power := range pending {
+		if _, ok := current[v]; !ok {
+			deltas[v] = power
+		}
+	}
+	for _, delta := range deltas {
+		totalChange.Add(&totalChange, new(big.Int).Abs(big.NewInt(delta)))
+	}
+
+	var budget big.Int
+	budget.Mul(&totalCurrent, big.NewInt(int64(maxChangePercent)))
+	budget.Quo(&budget, big.NewInt(100))
+	if totalChange.Cmp(&budget) <= 0 {
+		return pending
+	}
+
+	limited := make(map[signature.PublicKey]int64)
+	for v, delta := range deltas {
+		var scaled big.Int
+		scaled.Mul(big.NewInt(delta), &budget)
+		scaled.Quo(&scaled, &totalChange)
+
+		if power := current[v] + scaled.Int64(); power > 0 {
+			limited[v] = power
+		}
+	}
+	return limited
+}
+
+// limitValidatorSetSize limits the validator set to at most maxValidators
+// validators and at most maxPerEntity validators of each entity, keeping
+// the validators with the highest voting power (ties are broken by the
+// validator identifier).
+//
+// The entities map contains the entity of each validator.
+func limitValidatorSetSize(
+	entities map[signature.PublicKey]signature.PublicKey,
+	validators map[signature.PublicKey]int64,
+	maxValidators int,
+	maxPerEntity int,
+) map[signature.PublicKey]int64 {
+	sorted := make([]signature.PublicKey, 0, len(validators))
+	for v := range validators {
+		sorted = append(sorted, v)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		pi, pj := validators[sorted[i]], validators[sorted[j]]
+		if pi != pj {
+			return pi > pj
+		}
+		return bytes.Compare(sorted[i][:], sorted[j][:]) < 0
+	})
+
+	limited := make(map[signature.PublicKey]int64)
+	perEntity := make(map[signature.PublicKey]int)
+	for _, v := range sorted {
+		if len(limited) >= maxValidators {
+			break
+		}
+		ent := entities[v]
+		if perEntity[ent] >= maxPerEntity {
+			continue
+		}
+		perEntity[ent]++
+		limited[v] = validators[v]
+	}
+	return limited
+}
+
 func publicKeyMapToSliceByStake(
 	entMap map[signature.PublicKey]bool,
 	stakeAcc *stakingState.StakeAccumulatorCache,
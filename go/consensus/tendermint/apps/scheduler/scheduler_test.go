@@ -1,14 +1,28 @@
 package scheduler
 
 import (
+	"crypto/sha256"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/abci/types"
 
+	"github.com/oasislabs/oasis-core/go/common"
+	"github.com/oasislabs/oasis-core/go/common/cbor"
 	"github.com/oasislabs/oasis-core/go/common/crypto/signature"
 	"github.com/oasislabs/oasis-core/go/common/logging"
+	"github.com/oasislabs/oasis-core/go/common/node"
+	"github.com/oasislabs/oasis-core/go/common/quantity"
 	"github.com/oasislabs/oasis-core/go/consensus/tendermint/api"
+	registryState "github.com/oasislabs/oasis-core/go/consensus/tendermint/apps/registry/state"
+	schedulerState "github.com/oasislabs/oasis-core/go/consensus/tendermint/apps/scheduler/state"
+	stakingState "github.com/oasislabs/oasis-core/go/consensus/tendermint/apps/staking/state"
+	tmcrypto "github.com/oasislabs/oasis-core/go/consensus/tendermint/crypto"
+	registry "github.com/oasislabs/oasis-core/go/registry/api"
+	scheduler "github.com/oasislabs/oasis-core/go/scheduler/api"
+	staking "github.com/oasislabs/oasis-core/go/staking/api"
 )
 
 func TestDiffValidators(t *testing.T) {
@@ -59,3 +73,303 @@ func TestDiffValidators(t *testing.T) {
 		require.Equal(t, tt.result, diffValidators(logger, tt.current, tt.pending), tt.msg)
 	}
 }
+
+func TestLimitVotingPowerChange(t *testing.T) {
+	require := require.New(t)
+
+	var a, b, c signature.PublicKey
+	a[0], b[0], c[0] = 1, 2, 3
+	current := map[signature.PublicKey]int64{a: 100, b: 100}
+	eligible := map[signature.PublicKey]signature.PublicKey{a: a, b: b, c: c}
+
+	// Changes within the limit should be applied as-is.
+	pending := map[signature.PublicKey]int64{a: 105, b: 100}
+	require.Equal(pending, limitVotingPowerChange(current, eligible, pending, 10), "changes within limit")
+
+	// Without a limit, everything should be applied as-is.
+	pending = map[signature.PublicKey]int64{a: 100, c: 100}
+	require.Equal(pending, limitVotingPowerChange(current, eligible, pending, 0), "no limit")
+
+	// Changes exceeding the limit should be scaled down proportionally.
+	require.Equal(
+		map[signature.PublicKey]int64{a: 100, b: 90, c: 10},
+		limitVotingPowerChange(current, eligible, pending, 10),
+		"changes exceeding limit",
+	)
+
+	// Ineligible validators should be removed immediately.
+	delete(eligible, b)
+	require.Equal(
+		map[signature.PublicKey]int64{a: 100, c: 20},
+		limitVotingPowerChange(current, eligible, pending, 10),
+		"ineligible validators",
+	)
+}
+
+func TestLimitValidatorSetSize(t *testing.T) {
+	require := require.New(t)
+
+	var a, b, c, entA, entB signature.PublicKey
+	a[0], b[0], c[0], entA[0], entB[0] = 1, 2, 3, 4, 5
+	entities := map[signature.PublicKey]signature.PublicKey{a: entA, b: entA, c: entB}
+	validators := map[signature.PublicKey]int64{a: 90, b: 100, c: 10}
+
+	// Sets within the limits should be kept as-is.
+	require.Equal(validators, limitValidatorSetSize(entities, validators, 3, 2), "within limits")
+
+	// The per-entity limit should keep the validators with the most power.
+	require.Equal(
+		map[signature.PublicKey]int64{b: 100, c: 10},
+		limitValidatorSetSize(entities, validators, 3, 1),
+		"per-entity limit",
+	)
+
+	// The set size limit should keep the validators with the most power.
+	require.Equal(
+		map[signature.PublicKey]int64{a: 90, b: 100},
+		limitValidatorSetSize(entities, validators, 2, 2),
+		"set size limit",
+	)
+
+	// Ties should be broken by the validator identifier.
+	validators = map[signature.PublicKey]int64{a: 100, b: 100, c: 100}
+	require.Equal(
+		map[signature.PublicKey]int64{a: 100},
+		limitValidatorSetSize(entities, validators, 1, 2),
+		"ties",
+	)
+}
+
+func testElectionNodes(entities, nodesPerEntity int) ([]signature.PublicKey, []*node.Node) {
+	var (
+		entityIDs []signature.PublicKey
+		nodes     []*node.Node
+	)
+	for i := 0; i < entities; i++ {
+		var entityID signature.PublicKey
+		entityID[0] = byte(i + 1)
+		entityIDs = append(entityIDs, entityID)
+		for j := 0; j < nodesPerEntity; j++ {
+			var nodeID signature.PublicKey
+			nodeID[0], nodeID[1] = byte(i+1), byte(j+1)
+			nodes = append(nodes, &node.Node{
+				ID:       nodeID,
+				EntityID: entityID,
+			})
+		}
+	}
+	return entityIDs, nodes
+}
+
+func TestElectUniform(t *testing.T) {
+	require := require.New(t)
+
+	var runtimeID common.Namespace
+	beacon := sha256.Sum256([]byte("beacon"))
+	_, nodes := testElectionNodes(3, 4)
+
+	// Without a limit, the election should follow the permutation.
+	elected, err := electUniform(beacon[:], runtimeID, RNGContextExecutor, nodes, 5, 0)
+	require.NoError(err, "electUniform")
+	require.Len(elected, 5, "all seats should be filled")
+	idxs, err := GetPerm(beacon[:], runtimeID, RNGContextExecutor, len(nodes))
+	require.NoError(err, "GetPerm")
+	for i, n := range elected {
+		require.Equal(nodes[idxs[i]], n, "election should follow the permutation")
+	}
+
+	// With a limit, no entity should get more seats than allowed.
+	elected, err = electUniform(beacon[:], runtimeID, RNGContextExecutor, nodes, 6, 2)
+	require.NoError(err, "electUniform")
+	require.Len(elected, 6, "all seats should be filled")
+	perEntity := make(map[signature.PublicKey]int)
+	for _, n := range elected {
+		perEntity[n.EntityID]++
+	}
+	for _, seats := range perEntity {
+		require.Equal(2, seats, "entities should get at most 2 seats")
+	}
+
+	// Not enough entities to fill all seats.
+	elected, err = electUniform(beacon[:], runtimeID, RNGContextExecutor, nodes, 6, 1)
+	require.NoError(err, "electUniform")
+	require.Len(elected, 3, "only one seat per entity should be filled")
+}
+
+func TestElectStakeWeighted(t *testing.T) {
+	require := require.New(t)
+
+	var runtimeID common.Namespace
+	entityIDs, nodes := testElectionNodes(3, 4)
+
+	// The first entity has no escrow, the third has three times the escrow
+	// of the second.
+	escrow := map[signature.PublicKey]uint64{
+		entityIDs[0]: 0,
+		entityIDs[1]: 1000,
+		entityIDs[2]: 3000,
+	}
+	escrowFn := func(id signature.PublicKey) (*quantity.Quantity, error) {
+		var q quantity.Quantity
+		_ = q.FromUint64(escrow[id])
+		return &q, nil
+	}
+
+	seats := make(map[signature.PublicKey]int)
+	for i := 0; i < 1000; i++ {
+		beacon := sha256.Sum256([]byte(fmt.Sprintf("beacon %d", i)))
+		elected, err := electStakeWeighted(beacon[:], runtimeID, RNGContextExecutor, escrowFn, nodes, 1, 0)
+		require.NoError(err, "electStakeWeighted")
+		require.Len(elected, 1, "all seats should be filled")
+		seats[elected[0].EntityID]++
+
+		// Election should be deterministic.
+		again, err := electStakeWeighted(beacon[:], runtimeID, RNGContextExecutor, escrowFn, nodes, 1, 0)
+		require.NoError(err, "electStakeWeighted")
+		require.Equal(elected, again, "election should be deterministic")
+	}
+	require.Zero(seats[entityIDs[0]], "entities without escrow should never be elected")
+	require.InDelta(750, seats[entityIDs[2]], 75, "seats should be proportional to escrow")
+
+	// Nodes should not be elected twice and the limit should be respected.
+	beacon := sha256.Sum256([]byte("beacon"))
+	elected, err := electStakeWeighted(beacon[:], runtimeID, RNGContextExecutor, escrowFn, nodes, 8, 0)
+	require.NoError(err, "electStakeWeighted")
+	require.Len(elected, 8, "all eligible nodes should be elected")
+	unique := make(map[signature.PublicKey]bool)
+	for _, n := range elected {
+		require.False(unique[n.ID], "nodes should not be elected twice")
+		unique[n.ID] = true
+	}
+
+	elected, err = electStakeWeighted(beacon[:], runtimeID, RNGContextExecutor, escrowFn, nodes, 8, 3)
+	require.NoError(err, "electStakeWeighted")
+	require.Len(elected, 6, "only three seats per entity should be filled")
+}
+
+func TestIsAdmitted(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1580461674, 0)
+	appState := api.NewMockApplicationState(api.MockApplicationStateConfig{})
+	ctx := appState.NewContext(api.ContextDeliverTx, now)
+	defer ctx.Close()
+
+	app := &schedulerApplication{}
+	entityIDs, nodes := testElectionNodes(2, 3)
+
+	// Only the second entity has enough escrow.
+	stakeState := stakingState.NewMutableState(ctx.State())
+	var acct staking.Account
+	_ = acct.Escrow.Active.Balance.FromUint64(1000)
+	err := stakeState.SetAccount(ctx, entityIDs[1], &acct)
+	require.NoError(err, "SetAccount")
+
+	countAdmitted := func(rt *registry.Runtime, role node.RolesMask) map[signature.PublicKey]uint64 {
+		admitted := make(map[signature.PublicKey]uint64)
+		for _, n := range nodes {
+			app.isAdmitted(ctx, nil, n, rt, role, admitted)
+		}
+		return admitted
+	}
+
+	var minEscrow quantity.Quantity
+	_ = minEscrow.FromUint64(1000)
+	rt := &registry.Runtime{
+		AdmissionPolicy: registry.RuntimeAdmissionPolicy{
+			MinEscrow: &registry.MinEscrowRuntimeAdmissionPolicy{
+				MinEscrow: minEscrow,
+			},
+		},
+	}
+	admitted := countAdmitted(rt, node.RoleComputeWorker)
+	require.Zero(admitted[entityIDs[0]], "entities without enough escrow should not be admitted")
+	require.EqualValues(3, admitted[entityIDs[1]], "entities with enough escrow should be admitted")
+
+	rt = &registry.Runtime{
+		AdmissionPolicy: registry.RuntimeAdmissionPolicy{
+			PerEntityNodeLimit: &registry.PerEntityNodeLimitRuntimeAdmissionPolicy{
+				Limits: map[node.RolesMask]uint64{
+					node.RoleComputeWorker: 2,
+				},
+			},
+		},
+	}
+	admitted = countAdmitted(rt, node.RoleComputeWorker)
+	require.EqualValues(2, admitted[entityIDs[0]], "per-entity node limit should be respected")
+	require.EqualValues(2, admitted[entityIDs[1]], "per-entity node limit should be respected")
+	admitted = countAdmitted(rt, node.RoleStorageWorker)
+	require.EqualValues(3, admitted[entityIDs[0]], "roles without a limit should not be limited")
+}
+
+func TestTrackValidatorLiveness(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1580461674, 0)
+	appState := api.NewMockApplicationState(api.MockApplicationStateConfig{
+		BlockHeight:  9,
+		CurrentEpoch: 3,
+	})
+	ctx := appState.NewContext(api.ContextBeginBlock, now)
+	defer ctx.Close()
+
+	app := &schedulerApplication{state: appState}
+	_, nodes := testElectionNodes(4, 1)
+
+	state := schedulerState.NewMutableState(ctx.State())
+	err := state.SetConsensusParameters(ctx, &scheduler.ConsensusParameters{
+		MinValidators:                     2,
+		MaxValidators:                     10,
+		MaxValidatorsPerEntity:            1,
+		ValidatorLivenessWindow:           10,
+		ValidatorLivenessMaxMissedPercent: 50,
+	})
+	require.NoError(err, "SetConsensusParameters")
+
+	regState := registryState.NewMutableState(ctx.State())
+	for i, n := range nodes {
+		n.Roles = node.RoleValidator
+		n.Consensus.ID[0], n.Consensus.ID[1] = byte(i+1), 0xff
+		sigNode := &node.MultiSignedNode{MultiSigned: signature.MultiSigned{Blob: cbor.Marshal(n)}}
+		err = regState.SetNode(ctx, nil, n, sigNode)
+		require.NoError(err, "SetNode")
+	}
+
+	// Three of the four validators missed all blocks of the window, one more
+	// than the most that can be jailed without dropping below the minimum.
+	err = state.SetLivenessWindow(ctx, &schedulerState.LivenessWindow{
+		StartHeight: 1,
+		Missed: map[signature.PublicKey]int64{
+			nodes[0].ID: 8,
+			nodes[1].ID: 10,
+			nodes[2].ID: 9,
+		},
+	})
+	require.NoError(err, "SetLivenessWindow")
+
+	var votes []types.VoteInfo
+	for _, n := range nodes {
+		votes = append(votes, types.VoteInfo{
+			Validator: types.Validator{
+				Address: tmcrypto.PublicKeyToTendermint(&n.Consensus.ID).Address(),
+			},
+			SignedLastBlock: true,
+		})
+	}
+	err = app.trackValidatorLiveness(ctx, types.RequestBeginBlock{
+		LastCommitInfo: types.LastCommitInfo{Votes: votes},
+	})
+	require.NoError(err, "trackValidatorLiveness")
+
+	jailed, err := state.JailedNodes(ctx)
+	require.NoError(err, "JailedNodes")
+	require.Len(jailed, 2, "jailing should not drop the eligible set below the minimum")
+	jailedIDs := []signature.PublicKey{jailed[0].NodeID, jailed[1].NodeID}
+	require.ElementsMatch([]signature.PublicKey{nodes[1].ID, nodes[2].ID}, jailedIDs, "the worst offenders should be jailed")
+	require.EqualValues(3, jailed[0].JailedAt, "jailing epoch")
+
+	window, err := state.LivenessWindow(ctx)
+	require.NoError(err, "LivenessWindow")
+	require.EqualValues(11, window.StartHeight, "a new liveness window should be started")
+	require.Empty(window.Missed, "a new liveness window should be empty")
+}
@@ -32,8 +32,27 @@ var (
 	//
 	// Value is CBOR-serialized api.ConsensusParameters.
 	parametersKeyFmt = keyformat.New(0x63)
+	// jailedNodeKeyFmt is the key format used for jailed validator nodes.
+	//
+	// Value is CBOR-serialized api.JailStatus.
+	jailedNodeKeyFmt = keyformat.New(0x64, keyformat.H(&signature.PublicKey{}))
+	// livenessWindowKeyFmt is the key format used for the validator
+	// liveness window that is currently being tracked.
+	//
+	// Value is CBOR-serialized LivenessWindow.
+	livenessWindowKeyFmt = keyformat.New(0x65)
 )
 
+// LivenessWindow is the validator liveness tracking state for a window of
+// blocks.
+type LivenessWindow struct {
+	// StartHeight is the height of the first block in the window.
+	StartHeight int64 `json:"start_height"`
+	// Missed is the number of blocks each validator node has failed to
+	// sign in the window.
+	Missed map[signature.PublicKey]int64 `json:"missed,omitempty"`
+}
+
 // ImmutableState is the immutable scheduler state wrapper.
 type ImmutableState struct {
 	is *abciAPI.ImmutableState
@@ -163,6 +182,66 @@ func (s *ImmutableState) ConsensusParameters(ctx context.Context) (*api.Consensu
 	return &params, nil
 }
 
+// JailStatus returns the jail status of a validator node or nil if the
+// node is not jailed.
+func (s *ImmutableState) JailStatus(ctx context.Context, id signature.PublicKey) (*api.JailStatus, error) {
+	raw, err := s.is.Get(ctx, jailedNodeKeyFmt.Encode(&id))
+	if err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	if raw == nil {
+		return nil, nil
+	}
+
+	var status api.JailStatus
+	if err = cbor.Unmarshal(raw, &status); err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	return &status, nil
+}
+
+// JailedNodes returns the jail status of all jailed validator nodes.
+func (s *ImmutableState) JailedNodes(ctx context.Context) ([]*api.JailStatus, error) {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	var statuses []*api.JailStatus
+	for it.Seek(jailedNodeKeyFmt.Encode()); it.Valid(); it.Next() {
+		if !jailedNodeKeyFmt.Decode(it.Key()) {
+			break
+		}
+
+		var status api.JailStatus
+		if err := cbor.Unmarshal(it.Value(), &status); err != nil {
+			return nil, abciAPI.UnavailableStateError(err)
+		}
+
+		statuses = append(statuses, &status)
+	}
+	if it.Err() != nil {
+		return nil, abciAPI.UnavailableStateError(it.Err())
+	}
+	return statuses, nil
+}
+
+// LivenessWindow returns the validator liveness window that is currently
+// being tracked or nil if no window has been started yet.
+func (s *ImmutableState) LivenessWindow(ctx context.Context) (*LivenessWindow, error) {
+	raw, err := s.is.Get(ctx, livenessWindowKeyFmt.Encode())
+	if err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	if raw == nil {
+		return nil, nil
+	}
+
+	var window LivenessWindow
+	if err = cbor.Unmarshal(raw, &window); err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	return &window, nil
+}
+
 func NewImmutableState(ctx context.Context, state abciAPI.ApplicationQueryState, version int64) (*ImmutableState, error) {
 	is, err := abciAPI.NewImmutableState(ctx, state, version)
 	if err != nil {
@@ -213,6 +292,25 @@ func (s *MutableState) SetConsensusParameters(ctx context.Context, params *api.C
 	return abciAPI.UnavailableStateError(err)
 }
 
+// SetJailStatus sets the jail status of a validator node.
+func (s *MutableState) SetJailStatus(ctx context.Context, status *api.JailStatus) error {
+	err := s.ms.Insert(ctx, jailedNodeKeyFmt.Encode(&status.NodeID), cbor.Marshal(status))
+	return abciAPI.UnavailableStateError(err)
+}
+
+// RemoveJailStatus removes the jail status of a validator node.
+func (s *MutableState) RemoveJailStatus(ctx context.Context, id signature.PublicKey) error {
+	err := s.ms.Remove(ctx, jailedNodeKeyFmt.Encode(&id))
+	return abciAPI.UnavailableStateError(err)
+}
+
+// SetLivenessWindow sets the validator liveness window that is currently
+// being tracked.
+func (s *MutableState) SetLivenessWindow(ctx context.Context, window *LivenessWindow) error {
+	err := s.ms.Insert(ctx, livenessWindowKeyFmt.Encode(), cbor.Marshal(window))
+	return abciAPI.UnavailableStateError(err)
+}
+
 // NewMutableState creates a new mutable scheduler state wrapper.
 func NewMutableState(tree mkvs.KeyValueTree) *MutableState {
 	return &MutableState{
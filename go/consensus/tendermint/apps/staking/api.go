@@ -40,4 +40,8 @@ var (
 	// KeyAddEscrow is an ABCI event attribute key for AddEscrow calls
 	// (value is an api.AddEscrowEvent).
 	KeyAddEscrow = stakingState.KeyAddEscrow
+
+	// KeyReward is an ABCI event attribute key for staking rewards (value
+	// is an api.RewardEvent).
+	KeyReward = stakingState.KeyReward
 )
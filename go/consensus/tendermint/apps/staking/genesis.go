@@ -226,6 +226,32 @@ func (app *stakingApplication) initDebondingDelegations(ctx *abciAPI.Context, st
 	return nil
 }
 
+func (app *stakingApplication) initRewardHistory(ctx *abciAPI.Context, state *stakingState.MutableState, st *staking.Genesis) error {
+	for escrowID, epochRewards := range st.EpochRewards {
+		for idx, er := range epochRewards {
+			if er == nil {
+				return fmt.Errorf("tendermint/staking: genesis epoch rewards of %s index %d is nil", escrowID, idx)
+			}
+			if err := state.SetEpochRewards(ctx, escrowID, er); err != nil {
+				return fmt.Errorf("tendermint/staking: failed to set epoch rewards: %w", err)
+			}
+		}
+	}
+	for escrowID, delegators := range st.DelegationShares {
+		for delegatorID, shares := range delegators {
+			for idx, eds := range shares {
+				if eds == nil {
+					return fmt.Errorf("tendermint/staking: genesis delegation shares to %s from %s index %d is nil", escrowID, delegatorID, idx)
+				}
+				if err := state.SetEpochDelegationShares(ctx, delegatorID, escrowID, eds); err != nil {
+					return fmt.Errorf("tendermint/staking: failed to set delegation shares: %w", err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
 // InitChain initializes the chain from genesis.
 func (app *stakingApplication) InitChain(ctx *abciAPI.Context, request types.RequestInitChain, doc *genesis.Document) error {
 	st := &doc.Staking
@@ -263,6 +289,10 @@ func (app *stakingApplication) InitChain(ctx *abciAPI.Context, request types.Req
 		return err
 	}
 
+	if err := app.initRewardHistory(ctx, state, st); err != nil {
+		return err
+	}
+
 	ctx.Logger().Debug("InitChain: allocations complete",
 		"common_pool", st.CommonPool,
 		"total_supply", totalSupply,
@@ -313,6 +343,10 @@ func (sq *stakingQuerier) Genesis(ctx context.Context) (*staking.Genesis, error)
 	if err != nil {
 		return nil, err
 	}
+	epochRewards, delegationShares, err := sq.state.RewardHistory(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	params, err := sq.state.ConsensusParameters(ctx)
 	if err != nil {
@@ -327,6 +361,8 @@ func (sq *stakingQuerier) Genesis(ctx context.Context) (*staking.Genesis, error)
 		Ledger:               ledger,
 		Delegations:          delegations,
 		DebondingDelegations: debondingDelegations,
+		EpochRewards:         epochRewards,
+		DelegationShares:     delegationShares,
 	}
 	return &gen, nil
 }
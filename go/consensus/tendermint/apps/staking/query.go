@@ -22,6 +22,7 @@ type Query interface {
 	AccountInfo(context.Context, signature.PublicKey) (*staking.Account, error)
 	Delegations(context.Context, signature.PublicKey) (map[signature.PublicKey]*staking.Delegation, error)
 	DebondingDelegations(context.Context, signature.PublicKey) (map[signature.PublicKey][]*staking.DebondingDelegation, error)
+	DelegationRewards(context.Context, signature.PublicKey, signature.PublicKey, epochtime.EpochTime, epochtime.EpochTime) (*staking.DelegationRewards, error)
 	Genesis(context.Context) (*staking.Genesis, error)
 	ConsensusParameters(context.Context) (*staking.ConsensusParameters, error)
 }
@@ -125,3 +126,67 @@ func (app *stakingApplication) QueryFactory() interface{} {
 func NewQueryFactory(state abciAPI.ApplicationQueryState) *QueryFactory {
 	return &QueryFactory{state}
 }
+
+func (sq *stakingQuerier) DelegationRewards(
+	ctx context.Context,
+	owner signature.PublicKey,
+	escrow signature.PublicKey,
+	from epochtime.EpochTime,
+	to epochtime.EpochTime,
+) (*staking.DelegationRewards, error) {
+	delegation, err := sq.state.Delegation(ctx, owner, escrow)
+	if err != nil {
+		return nil, err
+	}
+	epochRewards, err := sq.state.EpochRewards(ctx, escrow, from, to)
+	if err != nil {
+		return nil, err
+	}
+	epochShares, err := sq.state.EpochDelegationShares(ctx, owner, escrow, from)
+	if err != nil {
+		return nil, err
+	}
+
+	var rewards staking.DelegationRewards
+	for _, er := range epochRewards {
+		edr := &staking.EpochDelegationRewards{Epoch: er.Epoch}
+
+		// The epoch's rewards are paid out before the delegation changes in
+		// that epoch, so the delegation's shares at payout time are the
+		// shares it had at the start of the first epoch (not before this one)
+		// it changed in, or the current shares if it has not changed since.
+		for len(epochShares) > 0 && epochShares[0].Epoch < er.Epoch {
+			epochShares = epochShares[1:]
+		}
+		shares := &delegation.Shares
+		if len(epochShares) > 0 {
+			shares = &epochShares[0].Previous
+		}
+
+		// The delegation's share of the pool rewards is proportional to its
+		// share of the pool.
+		if !er.TotalShares.IsZero() {
+			q := er.Rewards.Clone()
+			if err = q.Mul(shares); err != nil {
+				return nil, err
+			}
+			if err = q.Quo(&er.TotalShares); err != nil {
+				return nil, err
+			}
+			edr.Rewards = *q
+		}
+		// Commission is only earned by the escrow account owner.
+		if owner.Equal(escrow) {
+			edr.Commission = er.Commission
+		}
+
+		if err = rewards.Total.Add(&edr.Rewards); err != nil {
+			return nil, err
+		}
+		if err = rewards.Total.Add(&edr.Commission); err != nil {
+			return nil, err
+		}
+		rewards.Epochs = append(rewards.Epochs, edr)
+	}
+	return &rewards, nil
+}
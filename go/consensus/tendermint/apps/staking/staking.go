@@ -247,6 +247,15 @@ func (app *stakingApplication) onEpochChange(ctx *api.Context, epoch epochtime.E
 		return fmt.Errorf("staking/tendermint: failed to add signing rewards: %w", err)
 	}
 
+	// Prune the reward history.
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch consensus parameters: %w", err)
+	}
+	if err = state.PruneRewardHistory(ctx, epoch, params.RewardHistoryEpochs); err != nil {
+		return fmt.Errorf("failed to prune reward history: %w", err)
+	}
+
 	return nil
 }
 
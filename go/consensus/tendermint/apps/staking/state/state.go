@@ -31,6 +31,9 @@ var (
 	// KeyTransfer is an ABCI event attribute key for Transfers (value is
 	// an app.TransferEvent).
 	KeyTransfer = []byte("transfer")
+	// KeyReward is an ABCI event attribute key for staking rewards (value
+	// is an api.RewardEvent).
+	KeyReward = []byte("reward")
 
 	// accountKeyFmt is the key format used for accounts (account id).
 	//
@@ -70,6 +73,27 @@ var (
 	//
 	// Value is CBOR-serialized EpochSigning.
 	epochSigningKeyFmt = keyformat.New(0x58)
+	// epochRewardsKeyFmt is the key format for per-epoch escrow account
+	// rewards (escrow id, epoch).
+	//
+	// Value is CBOR-serialized staking.EpochRewards.
+	epochRewardsKeyFmt = keyformat.New(0x59, &signature.PublicKey{}, uint64(0))
+	// epochDelegationSharesKeyFmt is the key format for per-epoch delegation
+	// share changes (escrow id, delegator id, epoch).
+	//
+	// Value is CBOR-serialized staking.EpochDelegationShares.
+	epochDelegationSharesKeyFmt = keyformat.New(0x5a, &signature.PublicKey{}, &signature.PublicKey{}, uint64(0))
+	// epochRewardsByEpochKeyFmt is the key format for the epoch-first index
+	// of per-epoch escrow account rewards (epoch, escrow id).
+	//
+	// Value is empty.
+	epochRewardsByEpochKeyFmt = keyformat.New(0x5b, uint64(0), &signature.PublicKey{})
+	// epochDelegationSharesByEpochKeyFmt is the key format for the
+	// epoch-first index of per-epoch delegation share changes (epoch,
+	// escrow id, delegator id).
+	//
+	// Value is empty.
+	epochDelegationSharesByEpochKeyFmt = keyformat.New(0x5c, uint64(0), &signature.PublicKey{}, &signature.PublicKey{})
 
 	logger = logging.GetLogger("tendermint/staking")
 )
@@ -257,6 +281,129 @@ func (s *ImmutableState) Delegation(ctx context.Context, delegatorID, escrowID s
 	return &del, nil
 }
 
+// EpochRewards returns the rewards added to the given escrow account in
+// epochs starting with the given epoch (inclusive) and ending before the
+// given epoch (exclusive), in epoch order.
+func (s *ImmutableState) EpochRewards(
+	ctx context.Context,
+	escrowID signature.PublicKey,
+	from, to epochtime.EpochTime,
+) ([]*staking.EpochRewards, error) {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	var rewards []*staking.EpochRewards
+	for it.Seek(epochRewardsKeyFmt.Encode(&escrowID, uint64(from))); it.Valid(); it.Next() {
+		var decEscrowID signature.PublicKey
+		var epoch uint64
+		if !epochRewardsKeyFmt.Decode(it.Key(), &decEscrowID, &epoch) {
+			break
+		}
+		if !decEscrowID.Equal(escrowID) || epochtime.EpochTime(epoch) >= to {
+			break
+		}
+
+		var er staking.EpochRewards
+		if err := cbor.Unmarshal(it.Value(), &er); err != nil {
+			return nil, abciAPI.UnavailableStateError(err)
+		}
+
+		rewards = append(rewards, &er)
+	}
+	if it.Err() != nil {
+		return nil, abciAPI.UnavailableStateError(it.Err())
+	}
+	return rewards, nil
+}
+
+// EpochDelegationShares returns the share changes of the given delegation
+// in epochs starting with the given epoch (inclusive), in epoch order.
+func (s *ImmutableState) EpochDelegationShares(
+	ctx context.Context,
+	delegatorID, escrowID signature.PublicKey,
+	from epochtime.EpochTime,
+) ([]*staking.EpochDelegationShares, error) {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	var shares []*staking.EpochDelegationShares
+	for it.Seek(epochDelegationSharesKeyFmt.Encode(&escrowID, &delegatorID, uint64(from))); it.Valid(); it.Next() {
+		var decEscrowID, decDelegatorID signature.PublicKey
+		var epoch uint64
+		if !epochDelegationSharesKeyFmt.Decode(it.Key(), &decEscrowID, &decDelegatorID, &epoch) {
+			break
+		}
+		if !decEscrowID.Equal(escrowID) || !decDelegatorID.Equal(delegatorID) {
+			break
+		}
+
+		var eds staking.EpochDelegationShares
+		if err := cbor.Unmarshal(it.Value(), &eds); err != nil {
+			return nil, abciAPI.UnavailableStateError(err)
+		}
+
+		shares = append(shares, &eds)
+	}
+	if it.Err() != nil {
+		return nil, abciAPI.UnavailableStateError(it.Err())
+	}
+	return shares, nil
+}
+
+// RewardHistory returns the reward history of all escrow accounts and the
+// share history of all delegations.
+func (s *ImmutableState) RewardHistory(ctx context.Context) (
+	map[signature.PublicKey][]*staking.EpochRewards,
+	map[signature.PublicKey]map[signature.PublicKey][]*staking.EpochDelegationShares,
+	error,
+) {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	rewards := make(map[signature.PublicKey][]*staking.EpochRewards)
+	for it.Seek(epochRewardsKeyFmt.Encode()); it.Valid(); it.Next() {
+		var escrowID signature.PublicKey
+		var epoch uint64
+		if !epochRewardsKeyFmt.Decode(it.Key(), &escrowID, &epoch) {
+			break
+		}
+
+		var er staking.EpochRewards
+		if err := cbor.Unmarshal(it.Value(), &er); err != nil {
+			return nil, nil, abciAPI.UnavailableStateError(err)
+		}
+
+		rewards[escrowID] = append(rewards[escrowID], &er)
+	}
+	if it.Err() != nil {
+		return nil, nil, abciAPI.UnavailableStateError(it.Err())
+	}
+
+	shares := make(map[signature.PublicKey]map[signature.PublicKey][]*staking.EpochDelegationShares)
+	for it.Seek(epochDelegationSharesKeyFmt.Encode()); it.Valid(); it.Next() {
+		var escrowID, delegatorID signature.PublicKey
+		var epoch uint64
+		if !epochDelegationSharesKeyFmt.Decode(it.Key(), &escrowID, &delegatorID, &epoch) {
+			break
+		}
+
+		var eds staking.EpochDelegationShares
+		if err := cbor.Unmarshal(it.Value(), &eds); err != nil {
+			return nil, nil, abciAPI.UnavailableStateError(err)
+		}
+
+		if shares[escrowID] == nil {
+			shares[escrowID] = make(map[signature.PublicKey][]*staking.EpochDelegationShares)
+		}
+		shares[escrowID][delegatorID] = append(shares[escrowID][delegatorID], &eds)
+	}
+	if it.Err() != nil {
+		return nil, nil, abciAPI.UnavailableStateError(it.Err())
+	}
+
+	return rewards, shares, nil
+}
+
 func (s *ImmutableState) DelegationsFor(ctx context.Context, delegatorID signature.PublicKey) (map[signature.PublicKey]*staking.Delegation, error) {
 	it := s.is.NewIterator(ctx)
 	defer it.Close()
@@ -590,6 +737,120 @@ func (s *MutableState) ClearEpochSigning(ctx context.Context) error {
 	return abciAPI.UnavailableStateError(err)
 }
 
+// SetEpochRewards sets the rewards added to an escrow account in an epoch.
+func (s *MutableState) SetEpochRewards(ctx context.Context, escrowID signature.PublicKey, er *staking.EpochRewards) error {
+	if err := s.ms.Insert(ctx, epochRewardsKeyFmt.Encode(&escrowID, uint64(er.Epoch)), cbor.Marshal(er)); err != nil {
+		return abciAPI.UnavailableStateError(err)
+	}
+	err := s.ms.Insert(ctx, epochRewardsByEpochKeyFmt.Encode(uint64(er.Epoch), &escrowID), []byte{})
+	return abciAPI.UnavailableStateError(err)
+}
+
+// SetEpochDelegationShares sets the share change of a delegation in an
+// epoch.
+func (s *MutableState) SetEpochDelegationShares(
+	ctx context.Context,
+	delegatorID, escrowID signature.PublicKey,
+	eds *staking.EpochDelegationShares,
+) error {
+	if err := s.ms.Insert(ctx, epochDelegationSharesKeyFmt.Encode(&escrowID, &delegatorID, uint64(eds.Epoch)), cbor.Marshal(eds)); err != nil {
+		return abciAPI.UnavailableStateError(err)
+	}
+	err := s.ms.Insert(ctx, epochDelegationSharesByEpochKeyFmt.Encode(uint64(eds.Epoch), &escrowID, &delegatorID), []byte{})
+	return abciAPI.UnavailableStateError(err)
+}
+
+// RecordDelegationShares records a change of a delegation's shares in the
+// reward history.
+//
+// The previous argument is the number of shares before the change.
+func (s *MutableState) RecordDelegationShares(
+	ctx context.Context,
+	epoch epochtime.EpochTime,
+	delegatorID, escrowID signature.PublicKey,
+	previous *quantity.Quantity,
+	delegation *staking.Delegation,
+) error {
+	params, err := s.ConsensusParameters(ctx)
+	if err != nil {
+		return err
+	}
+	if params.RewardHistoryEpochs == 0 {
+		return nil
+	}
+
+	key := epochDelegationSharesKeyFmt.Encode(&escrowID, &delegatorID, uint64(epoch))
+	value, err := s.is.Get(ctx, key)
+	if err != nil {
+		return abciAPI.UnavailableStateError(err)
+	}
+	eds := staking.EpochDelegationShares{
+		Epoch:    epoch,
+		Previous: *previous.Clone(),
+	}
+	if value != nil {
+		if err = cbor.Unmarshal(value, &eds); err != nil {
+			return abciAPI.UnavailableStateError(err)
+		}
+	} else {
+		indexKey := epochDelegationSharesByEpochKeyFmt.Encode(uint64(epoch), &escrowID, &delegatorID)
+		if err = s.ms.Insert(ctx, indexKey, []byte{}); err != nil {
+			return abciAPI.UnavailableStateError(err)
+		}
+	}
+	eds.Shares = delegation.Shares
+	err = s.ms.Insert(ctx, key, cbor.Marshal(&eds))
+	return abciAPI.UnavailableStateError(err)
+}
+
+// PruneRewardHistory removes the reward history of all epochs that are at
+// least keepEpochs epochs older than the given epoch.
+func (s *MutableState) PruneRewardHistory(ctx context.Context, epoch, keepEpochs epochtime.EpochTime) error {
+	if epoch < keepEpochs {
+		return nil
+	}
+	lastPruned := uint64(epoch - keepEpochs)
+
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	// Use the epoch-first indices so that only the expired epochs are
+	// visited.
+	var toDelete [][]byte
+	for it.Seek(epochRewardsByEpochKeyFmt.Encode()); it.Valid(); it.Next() {
+		var escrowID signature.PublicKey
+		var decEpoch uint64
+		if !epochRewardsByEpochKeyFmt.Decode(it.Key(), &decEpoch, &escrowID) || decEpoch > lastPruned {
+			break
+		}
+		toDelete = append(toDelete,
+			epochRewardsByEpochKeyFmt.Encode(decEpoch, &escrowID),
+			epochRewardsKeyFmt.Encode(&escrowID, decEpoch),
+		)
+	}
+	for it.Seek(epochDelegationSharesByEpochKeyFmt.Encode()); it.Valid(); it.Next() {
+		var escrowID, delegatorID signature.PublicKey
+		var decEpoch uint64
+		if !epochDelegationSharesByEpochKeyFmt.Decode(it.Key(), &decEpoch, &escrowID, &delegatorID) || decEpoch > lastPruned {
+			break
+		}
+		toDelete = append(toDelete,
+			epochDelegationSharesByEpochKeyFmt.Encode(decEpoch, &escrowID, &delegatorID),
+			epochDelegationSharesKeyFmt.Encode(&escrowID, &delegatorID, decEpoch),
+		)
+	}
+	if it.Err() != nil {
+		return abciAPI.UnavailableStateError(it.Err())
+	}
+
+	for _, key := range toDelete {
+		if err := s.ms.Remove(ctx, key); err != nil {
+			return abciAPI.UnavailableStateError(err)
+		}
+	}
+	return nil
+}
+
 func slashPool(dst *quantity.Quantity, p *staking.SharePool, amount, total *quantity.Quantity) error {
 	// slashAmount = amount * p.Balance / total
 	slashAmount := p.Balance.Clone()
@@ -766,6 +1027,10 @@ func (s *MutableState) AddRewards(
 			continue
 		}
 
+		// Total shares at the time of the payout, before any commission is
+		// deposited.
+		totalShares := ent.Escrow.Active.TotalShares.Clone()
+
 		var com *quantity.Quantity
 		rate := ent.Escrow.CommissionSchedule.CurrentRate(time)
 		if rate != nil {
@@ -801,6 +1066,7 @@ func (s *MutableState) AddRewards(
 			if err != nil {
 				return fmt.Errorf("tendermint/staking: failed to query delegation: %w", err)
 			}
+			previous := delegation.Shares.Clone()
 
 			if err = ent.Escrow.Active.Deposit(&delegation.Shares, commonPool, com); err != nil {
 				return fmt.Errorf("tendermint/staking: depositing commission: %w", err)
@@ -809,6 +1075,9 @@ func (s *MutableState) AddRewards(
 			if err = s.SetDelegation(ctx, id, id, delegation); err != nil {
 				return fmt.Errorf("tendermint/staking: failed to set delegation: %w", err)
 			}
+			if err = s.RecordDelegationShares(ctx, time, id, id, previous, delegation); err != nil {
+				return fmt.Errorf("tendermint/staking: failed to record delegation shares: %w", err)
+			}
 
 			ev := cbor.Marshal(&staking.AddEscrowEvent{
 				Owner:  staking.CommonPoolAccountID,
@@ -821,6 +1090,10 @@ func (s *MutableState) AddRewards(
 		if err = s.SetAccount(ctx, id, ent); err != nil {
 			return fmt.Errorf("tendermint/staking: failed to set account: %w", err)
 		}
+
+		if err = s.recordReward(ctx, time, id, totalShares, q, com); err != nil {
+			return err
+		}
 	}
 
 	if err = s.SetCommonPool(ctx, commonPool); err != nil {
@@ -830,6 +1103,66 @@ func (s *MutableState) AddRewards(
 	return nil
 }
 
+// recordReward adds a reward to the per-epoch rewards of an escrow account
+// and emits the corresponding reward event.
+func (s *MutableState) recordReward(
+	ctx *abciAPI.Context,
+	time epochtime.EpochTime,
+	id signature.PublicKey,
+	totalShares *quantity.Quantity,
+	rewards *quantity.Quantity,
+	commission *quantity.Quantity,
+) error {
+	if commission == nil {
+		commission = quantity.NewQuantity()
+	}
+
+	params, err := s.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("tendermint/staking: failed to fetch consensus parameters: %w", err)
+	}
+	if params.RewardHistoryEpochs > 0 {
+		key := epochRewardsKeyFmt.Encode(&id, uint64(time))
+		var value []byte
+		if value, err = s.is.Get(ctx, key); err != nil {
+			return abciAPI.UnavailableStateError(err)
+		}
+		// The total shares are snapshotted when the first reward of the
+		// epoch is added, which is before any of the epoch's delegation
+		// share changes that are recorded as the delegations' previous
+		// shares.
+		er := staking.EpochRewards{Epoch: time, TotalShares: *totalShares.Clone()}
+		if value != nil {
+			if err = cbor.Unmarshal(value, &er); err != nil {
+				return abciAPI.UnavailableStateError(err)
+			}
+		} else {
+			if err = s.ms.Insert(ctx, epochRewardsByEpochKeyFmt.Encode(uint64(time), &id), []byte{}); err != nil {
+				return abciAPI.UnavailableStateError(err)
+			}
+		}
+		if err = er.Rewards.Add(rewards); err != nil {
+			return fmt.Errorf("tendermint/staking: failed adding epoch rewards: %w", err)
+		}
+		if err = er.Commission.Add(commission); err != nil {
+			return fmt.Errorf("tendermint/staking: failed adding epoch commission: %w", err)
+		}
+		if err = s.ms.Insert(ctx, key, cbor.Marshal(&er)); err != nil {
+			return abciAPI.UnavailableStateError(err)
+		}
+	}
+
+	ev := cbor.Marshal(&staking.RewardEvent{
+		Epoch:      time,
+		Escrow:     id,
+		Rewards:    *rewards,
+		Commission: *commission,
+	})
+	ctx.EmitEvent(api.NewEventBuilder(AppName).Attribute(KeyReward, ev))
+
+	return nil
+}
+
 // AddRewardSingleAttenuated computes, scales, and transfers a staking reward to an active escrow account.
 func (s *MutableState) AddRewardSingleAttenuated(
 	ctx *abciAPI.Context,
@@ -894,6 +1227,10 @@ func (s *MutableState) AddRewardSingleAttenuated(
 		return nil
 	}
 
+	// Total shares at the time of the payout, before any commission is
+	// deposited.
+	totalShares := ent.Escrow.Active.TotalShares.Clone()
+
 	var com *quantity.Quantity
 	rate := ent.Escrow.CommissionSchedule.CurrentRate(time)
 	if rate != nil {
@@ -929,6 +1266,7 @@ func (s *MutableState) AddRewardSingleAttenuated(
 		if err != nil {
 			return fmt.Errorf("tendermint/staking: failed to query delegation: %w", err)
 		}
+		previous := delegation.Shares.Clone()
 
 		if err = ent.Escrow.Active.Deposit(&delegation.Shares, commonPool, com); err != nil {
 			return fmt.Errorf("tendermint/staking: failed depositing commission: %w", err)
@@ -937,6 +1275,9 @@ func (s *MutableState) AddRewardSingleAttenuated(
 		if err = s.SetDelegation(ctx, account, account, delegation); err != nil {
 			return fmt.Errorf("tendermint/staking: failed to set delegation: %w", err)
 		}
+		if err = s.RecordDelegationShares(ctx, time, account, account, previous, delegation); err != nil {
+			return fmt.Errorf("tendermint/staking: failed to record delegation shares: %w", err)
+		}
 
 		ev := cbor.Marshal(&staking.AddEscrowEvent{
 			Owner:  staking.CommonPoolAccountID,
@@ -950,6 +1291,10 @@ func (s *MutableState) AddRewardSingleAttenuated(
 		return fmt.Errorf("tendermint/staking: failed to set account: %w", err)
 	}
 
+	if err = s.recordReward(ctx, time, account, totalShares, q, com); err != nil {
+		return err
+	}
+
 	if err = s.SetCommonPool(ctx, commonPool); err != nil {
 		return fmt.Errorf("tendermint/staking: failed to set common pool: %w", err)
 	}
@@ -194,6 +194,7 @@ func TestRewardAndSlash(t *testing.T) {
 			MaxRateSteps:       4,
 			MaxBoundSteps:      12,
 		},
+		RewardHistoryEpochs: 10,
 	})
 	require.NoError(err, "SetConsensusParameters")
 	err = s.SetCommonPool(ctx, mustInitQuantityP(t, 10000))
@@ -277,6 +278,61 @@ func TestRewardAndSlash(t *testing.T) {
 	commonPool, err = s.CommonPool(ctx)
 	require.NoError(err, "load common pool")
 	require.Equal(mustInitQuantityP(t, 9827), commonPool, "reward attenuated - common pool")
+
+	// Rewards should be summed per epoch, with the commission split out.
+	epochRewards, err := s.EpochRewards(ctx, escrowID, 0, 40)
+	require.NoError(err, "EpochRewards")
+	require.Len(epochRewards, 2, "epoch rewards - only epochs with rewards")
+	require.EqualValues(10, epochRewards[0].Epoch, "epoch rewards - first epoch")
+	require.Equal(mustInitQuantity(t, 91), epochRewards[0].Rewards, "epoch rewards - first epoch rewards")
+	require.Equal(mustInitQuantity(t, 22), epochRewards[0].Commission, "epoch rewards - first epoch commission")
+	require.Equal(mustInitQuantity(t, 100), epochRewards[0].TotalShares, "epoch rewards - first epoch total shares at first payout")
+	require.EqualValues(30, epochRewards[1].Epoch, "epoch rewards - second epoch")
+	require.Equal(mustInitQuantity(t, 80), epochRewards[1].Rewards, "epoch rewards - second epoch rewards")
+	require.Equal(mustInitQuantity(t, 20), epochRewards[1].Commission, "epoch rewards - second epoch commission")
+
+	epochRewards, err = s.EpochRewards(ctx, escrowID, 11, 30)
+	require.NoError(err, "EpochRewards")
+	require.Empty(epochRewards, "epoch rewards - empty range")
+
+	// Commission deposits should be recorded in the share history.
+	escrowSelfDel, err = s.Delegation(ctx, escrowID, escrowID)
+	require.NoError(err, "Delegation")
+	epochShares, err := s.EpochDelegationShares(ctx, escrowID, escrowID, 0)
+	require.NoError(err, "EpochDelegationShares")
+	require.Len(epochShares, 2, "epoch delegation shares - only epochs with changes")
+	require.EqualValues(10, epochShares[0].Epoch, "epoch delegation shares - first epoch")
+	require.Equal(mustInitQuantity(t, 0), epochShares[0].Previous, "epoch delegation shares - first epoch previous")
+	require.Equal(escrowSelfDel.Shares, epochShares[0].Shares, "epoch delegation shares - first epoch shares")
+	require.EqualValues(30, epochShares[1].Epoch, "epoch delegation shares - second epoch")
+	require.Equal(mustInitQuantity(t, 11), epochShares[1].Previous, "epoch delegation shares - second epoch previous")
+	require.Equal(mustInitQuantity(t, 18), epochShares[1].Shares, "epoch delegation shares - second epoch shares")
+	epochShares, err = s.EpochDelegationShares(ctx, delegatorID, escrowID, 0)
+	require.NoError(err, "EpochDelegationShares")
+	require.Empty(epochShares, "epoch delegation shares - unchanged delegation")
+
+	// Pruning should only remove epochs outside the history window.
+	require.NoError(s.PruneRewardHistory(ctx, 35, 10), "PruneRewardHistory")
+	epochRewards, err = s.EpochRewards(ctx, escrowID, 0, 40)
+	require.NoError(err, "EpochRewards")
+	require.Len(epochRewards, 1, "pruned epoch rewards")
+	require.EqualValues(30, epochRewards[0].Epoch, "pruned epoch rewards - epoch")
+	epochShares, err = s.EpochDelegationShares(ctx, escrowID, escrowID, 0)
+	require.NoError(err, "EpochDelegationShares")
+	require.Len(epochShares, 1, "pruned epoch delegation shares")
+	require.EqualValues(30, epochShares[0].Epoch, "pruned epoch delegation shares - epoch")
+
+	require.NoError(s.PruneRewardHistory(ctx, 40, 10), "PruneRewardHistory")
+	rewardHistory, delegationShares, err := s.RewardHistory(ctx)
+	require.NoError(err, "RewardHistory")
+	require.Empty(rewardHistory, "pruned reward history - epoch rewards")
+	require.Empty(delegationShares, "pruned reward history - delegation shares")
+
+	// The epoch-first indices should be pruned as well.
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+	it.Seek(epochRewardsByEpochKeyFmt.Encode())
+	require.True(!it.Valid() || it.Key()[0] > 0x5c, "pruned reward history - indices")
 }
 
 func TestEpochSigning(t *testing.T) {
@@ -204,6 +204,12 @@ func (app *stakingApplication) addEscrow(ctx *api.Context, state *stakingState.M
 	if err != nil {
 		return fmt.Errorf("failed to fetch delegation: %w", err)
 	}
+	previousShares := delegation.Shares.Clone()
+
+	epoch, err := app.state.GetEpoch(ctx, ctx.BlockHeight()+1)
+	if err != nil {
+		return err
+	}
 
 	if err = to.Escrow.Active.Deposit(&delegation.Shares, &from.General.Balance, &escrow.Tokens); err != nil {
 		ctx.Logger().Error("AddEscrow: failed to escrow tokens",
@@ -228,6 +234,9 @@ func (app *stakingApplication) addEscrow(ctx *api.Context, state *stakingState.M
 	if err = state.SetDelegation(ctx, id, escrow.Account, delegation); err != nil {
 		return fmt.Errorf("failed to set delegation: %w", err)
 	}
+	if err = state.RecordDelegationShares(ctx, epoch, id, escrow.Account, previousShares, delegation); err != nil {
+		return fmt.Errorf("failed to record delegation shares: %w", err)
+	}
 
 	ctx.Logger().Debug("AddEscrow: escrowed tokens",
 		"from", id,
@@ -292,6 +301,7 @@ func (app *stakingApplication) reclaimEscrow(ctx *api.Context, state *stakingSta
 	if err != nil {
 		return fmt.Errorf("failed to fetch delegation: %w", err)
 	}
+	previousShares := delegation.Shares.Clone()
 
 	// Fetch debonding interval and current epoch.
 	debondingInterval, err := state.DebondingInterval(ctx)
@@ -348,6 +358,9 @@ func (app *stakingApplication) reclaimEscrow(ctx *api.Context, state *stakingSta
 	if err = state.SetDelegation(ctx, id, reclaim.Account, delegation); err != nil {
 		return fmt.Errorf("failed to set delegation: %w", err)
 	}
+	if err = state.RecordDelegationShares(ctx, epoch, id, reclaim.Account, previousShares, delegation); err != nil {
+		return fmt.Errorf("failed to record delegation shares: %w", err)
+	}
 	if err = state.SetAccount(ctx, id, to); err != nil {
 		return fmt.Errorf("failed to set account: %w", err)
 	}
@@ -5,9 +5,14 @@ import (
 	"context"
 
 	"github.com/oasislabs/oasis-core/go/beacon/api"
+	"github.com/oasislabs/oasis-core/go/common/crypto/signature"
 	"github.com/oasislabs/oasis-core/go/common/logging"
+	"github.com/oasislabs/oasis-core/go/common/node"
+	consensus "github.com/oasislabs/oasis-core/go/consensus/api"
 	app "github.com/oasislabs/oasis-core/go/consensus/tendermint/apps/beacon"
 	"github.com/oasislabs/oasis-core/go/consensus/tendermint/service"
+	epochtime "github.com/oasislabs/oasis-core/go/epochtime/api"
+	registry "github.com/oasislabs/oasis-core/go/registry/api"
 )
 
 var _ api.Backend = (*tendermintBackend)(nil)
@@ -17,6 +22,8 @@ type tendermintBackend struct {
 
 	service service.TendermintService
 	querier *app.QueryFactory
+
+	signer signature.Signer
 }
 
 func (t *tendermintBackend) GetBeacon(ctx context.Context, height int64) ([]byte, error) {
@@ -28,6 +35,15 @@ func (t *tendermintBackend) GetBeacon(ctx context.Context, height int64) ([]byte
 	return q.Beacon(ctx)
 }
 
+func (t *tendermintBackend) GetBeaconEvidence(ctx context.Context, height int64) (*api.Evidence, error) {
+	q, err := t.querier.QueryAt(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.Evidence(ctx)
+}
+
 func (t *tendermintBackend) StateToGenesis(ctx context.Context, height int64) (*api.Genesis, error) {
 	q, err := t.querier.QueryAt(ctx, height)
 	if err != nil {
@@ -37,8 +53,144 @@ func (t *tendermintBackend) StateToGenesis(ctx context.Context, height int64) (*
 	return q.Genesis(ctx)
 }
 
+func (t *tendermintBackend) worker(ctx context.Context) {
+	select {
+	case <-t.service.Started():
+	case <-ctx.Done():
+		return
+	}
+
+	ch, sub := t.service.EpochTime().WatchEpochs()
+	defer sub.Close()
+
+	for {
+		var epoch epochtime.EpochTime
+		select {
+		case epoch = <-ch:
+		case <-ctx.Done():
+			return
+		}
+
+		t.participate(ctx, epoch)
+	}
+}
+
+// participate reveals the secret committed in the previous epoch and commits
+// to a new secret for the given epoch, if the commit-reveal beacon is enabled
+// and the node is a validator.
+func (t *tendermintBackend) participate(ctx context.Context, epoch epochtime.EpochTime) {
+	q, err := t.querier.QueryAt(ctx, consensus.HeightLatest)
+	if err != nil {
+		t.logger.Error("failed to query beacon state",
+			"err", err,
+		)
+		return
+	}
+	params, err := q.Genesis(ctx)
+	if err != nil {
+		t.logger.Error("failed to query beacon consensus parameters",
+			"err", err,
+		)
+		return
+	}
+	if params.Parameters.CommitReveal == nil {
+		return
+	}
+
+	nodeID := t.signer.Public()
+	n, err := t.service.Registry().GetNode(ctx, &registry.IDQuery{ID: nodeID, Height: consensus.HeightLatest})
+	if err != nil || !n.HasRoles(node.RoleValidator) {
+		return
+	}
+
+	// Reveal the secret committed in the previous epoch.
+	if epoch > 0 {
+		prevEpoch := epoch - 1
+		commitment, err := q.Commitment(ctx, prevEpoch, nodeID)
+		switch {
+		case err != nil:
+			t.logger.Error("failed to query beacon commitment",
+				"err", err,
+				"epoch", prevEpoch,
+			)
+		case commitment != nil:
+			t.submitReveal(ctx, prevEpoch)
+		}
+	}
+
+	// Commit to a secret for the current epoch.
+	commitment, err := q.Commitment(ctx, epoch, nodeID)
+	switch {
+	case err != nil:
+		t.logger.Error("failed to query beacon commitment",
+			"err", err,
+			"epoch", epoch,
+		)
+	case commitment == nil:
+		t.submitCommit(ctx, epoch)
+	}
+}
+
+func (t *tendermintBackend) submitCommit(ctx context.Context, epoch epochtime.EpochTime) {
+	secret, err := api.DeriveSecret(t.signer, epoch)
+	if err != nil {
+		t.logger.Error("failed to derive beacon secret",
+			"err", err,
+			"epoch", epoch,
+		)
+		return
+	}
+
+	tx := api.NewCommitTx(0, nil, &api.Commit{
+		Epoch:      epoch,
+		Commitment: api.NewCommitment(t.signer.Public(), epoch, secret),
+	})
+	if err = consensus.SignAndSubmitTx(ctx, t.service, t.signer, tx); err != nil {
+		t.logger.Error("failed to submit beacon commitment",
+			"err", err,
+			"epoch", epoch,
+		)
+		return
+	}
+
+	t.logger.Debug("submitted beacon commitment",
+		"epoch", epoch,
+	)
+}
+
+func (t *tendermintBackend) submitReveal(ctx context.Context, epoch epochtime.EpochTime) {
+	secret, err := api.DeriveSecret(t.signer, epoch)
+	if err != nil {
+		t.logger.Error("failed to derive beacon secret",
+			"err", err,
+			"epoch", epoch,
+		)
+		return
+	}
+
+	tx := api.NewRevealTx(0, nil, &api.Reveal{
+		Epoch:  epoch,
+		Secret: secret,
+	})
+	if err = consensus.SignAndSubmitTx(ctx, t.service, t.signer, tx); err != nil {
+		t.logger.Error("failed to submit beacon reveal",
+			"err", err,
+			"epoch", epoch,
+		)
+		return
+	}
+
+	t.logger.Debug("submitted beacon reveal",
+		"epoch", epoch,
+	)
+}
+
 // New constructs a new tendermint backed beacon Backend instance.
-func New(ctx context.Context, service service.TendermintService) (api.Backend, error) {
+//
+// If the commit-reveal beacon is enabled and the node is a validator, the
+// backend participates in the commit-reveal protocol using the given node
+// signer.
+func New(ctx context.Context, service service.TendermintService, signer signature.Signer) (api.Backend, error) {
 	// Initialize and register the tendermint service component.
 	a := app.New()
 	if err := service.RegisterApplication(a); err != nil {
@@ -49,7 +201,10 @@ func New(ctx context.Context, service service.TendermintService) (api.Backend, e
 		logger:  logging.GetLogger("beacon/tendermint"),
 		service: service,
 		querier: a.QueryFactory().(*app.QueryFactory),
+		signer:  signer,
 	}
 
+	go t.worker(ctx)
+
 	return t, nil
 }
@@ -11,6 +11,7 @@ import (
 
 	"github.com/oasislabs/oasis-core/go/common/logging"
 	"github.com/oasislabs/oasis-core/go/common/pubsub"
+	app "github.com/oasislabs/oasis-core/go/consensus/tendermint/apps/epochtime"
 	"github.com/oasislabs/oasis-core/go/consensus/tendermint/service"
 	"github.com/oasislabs/oasis-core/go/epochtime/api"
 )
@@ -24,8 +25,11 @@ type tendermintBackend struct {
 
 	service  service.TendermintService
 	notifier *pubsub.Broker
+	// querier is only set when epochs target a wall-clock duration, in
+	// which case the epoch is tracked in consensus state.
+	querier *app.QueryFactory
 
-	interval     int64
+	params       api.ConsensusParameters
 	lastNotified api.EpochTime
 	epoch        api.EpochTime
 	base         api.EpochTime
@@ -41,7 +45,16 @@ func (t *tendermintBackend) GetEpoch(ctx context.Context, height int64) (api.Epo
 		defer t.RUnlock()
 		return t.epoch, nil
 	}
-	epoch := t.base + api.EpochTime(height/t.interval)
+	if t.querier != nil {
+		q, err := t.querier.QueryAt(ctx, height)
+		if err != nil {
+			return api.EpochInvalid, err
+		}
+
+		epoch, _, err := q.Epoch(ctx)
+		return epoch, err
+	}
+	epoch := t.base + api.EpochTime(height/t.params.Interval)
 
 	return epoch, nil
 }
@@ -50,7 +63,14 @@ func (t *tendermintBackend) GetEpochBlock(ctx context.Context, epoch api.EpochTi
 	if epoch < t.base {
 		return 0, fmt.Errorf("epochtime/tendermint: epoch predates base")
 	}
-	height := int64(epoch-t.base) * t.interval
+	if t.querier != nil {
+		q, err := t.querier.QueryAt(ctx, 0)
+		if err != nil {
+			return 0, err
+		}
+		return q.EpochBlock(ctx, epoch)
+	}
+	height := int64(epoch-t.base) * t.params.Interval
 
 	return height, nil
 }
@@ -78,11 +98,8 @@ func (t *tendermintBackend) StateToGenesis(ctx context.Context, height int64) (*
 	}
 
 	return &api.Genesis{
-		Parameters: api.ConsensusParameters{
-			DebugMockBackend: false,
-			Interval:         t.interval,
-		},
-		Base: now,
+		Parameters: t.params,
+		Base:       now,
 	}, nil
 }
 
@@ -107,7 +124,14 @@ func (t *tendermintBackend) updateCached(ctx context.Context, block *tmtypes.Blo
 	t.Lock()
 	defer t.Unlock()
 
-	epoch, _ := t.GetEpoch(ctx, block.Header.Height)
+	epoch, err := t.GetEpoch(ctx, block.Header.Height)
+	if err != nil {
+		t.logger.Error("failed to query epoch",
+			"err", err,
+			"height", block.Header.Height,
+		)
+		return false
+	}
 
 	t.epoch = epoch
 
@@ -123,8 +147,12 @@ func (t *tendermintBackend) updateCached(ctx context.Context, block *tmtypes.Blo
 }
 
 // New constructs a new tendermint backed epochtime Backend instance,
-// with the specified epoch interval.
-func New(ctx context.Context, service service.TendermintService, interval int64) (api.Backend, error) {
+// with the specified consensus parameters.
+//
+// If the parameters specify a target epoch duration, epoch transitions are
+// driven by the epochtime ABCI application based on consensus block time,
+// otherwise each epoch is exactly the configured interval of blocks long.
+func New(ctx context.Context, service service.TendermintService, params *api.ConsensusParameters) (api.Backend, error) {
 	genDoc, err := service.GetGenesisDocument(ctx)
 	if err != nil {
 		return nil, err
@@ -132,11 +160,19 @@ func New(ctx context.Context, service service.TendermintService, interval int64)
 
 	base := genDoc.EpochTime.Base
 	r := &tendermintBackend{
-		logger:   logging.GetLogger("epochtime/tendermint"),
-		service:  service,
-		interval: interval,
-		base:     base,
-		epoch:    base,
+		logger:  logging.GetLogger("epochtime/tendermint"),
+		service: service,
+		params:  *params,
+		base:    base,
+		epoch:   base,
+	}
+	if params.TargetDuration > 0 {
+		// Initialize and register the tendermint service component.
+		a := app.New(params)
+		if err = service.RegisterApplication(a); err != nil {
+			return nil, err
+		}
+		r.querier = a.QueryFactory().(*app.QueryFactory)
 	}
 	r.notifier = pubsub.NewBrokerEx(func(ch channels.Channel) {
 		r.RLock()
@@ -12,6 +12,7 @@ import (
 	tmtypes "github.com/tendermint/tendermint/types"
 
 	"github.com/oasislabs/oasis-core/go/common/cbor"
+	"github.com/oasislabs/oasis-core/go/common/crypto/hash"
 	"github.com/oasislabs/oasis-core/go/common/crypto/signature"
 	"github.com/oasislabs/oasis-core/go/common/entity"
 	"github.com/oasislabs/oasis-core/go/common/logging"
@@ -23,7 +24,17 @@ import (
 	"github.com/oasislabs/oasis-core/go/registry/api"
 )
 
-var _ api.Backend = (*tendermintBackend)(nil)
+// ServiceClient is the registry service client interface.
+type ServiceClient interface {
+	api.Backend
+
+	// EventsFromTendermint decodes the registry events from the given
+	// tendermint events emitted by the transaction with the given hash.
+	// Block events should be decoded with the empty hash.
+	EventsFromTendermint(ctx context.Context, txHash hash.Hash, height int64, tmEvents []abcitypes.Event) ([]api.Event, error)
+}
+
+var _ ServiceClient = (*tendermintBackend)(nil)
 
 type tendermintBackend struct {
 	logger *logging.Logger
@@ -31,10 +42,11 @@ type tendermintBackend struct {
 	service service.TendermintService
 	querier *app.QueryFactory
 
-	entityNotifier   *pubsub.Broker
-	nodeNotifier     *pubsub.Broker
-	nodeListNotifier *pubsub.Broker
-	runtimeNotifier  *pubsub.Broker
+	entityNotifier         *pubsub.Broker
+	entityMetadataNotifier *pubsub.Broker
+	nodeNotifier           *pubsub.Broker
+	nodeListNotifier       *pubsub.Broker
+	runtimeNotifier        *pubsub.Broker
 }
 
 func (tb *tendermintBackend) Querier() *app.QueryFactory {
@@ -67,6 +79,23 @@ func (tb *tendermintBackend) WatchEntities(ctx context.Context) (<-chan *api.Ent
 	return typedCh, sub, nil
 }
 
+func (tb *tendermintBackend) GetEntityMetadata(ctx context.Context, query *api.IDQuery) (*api.SignedEntityMetadata, error) {
+	q, err := tb.querier.QueryAt(ctx, query.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.EntityMetadata(ctx, query.ID)
+}
+
+func (tb *tendermintBackend) WatchEntityMetadata(ctx context.Context) (<-chan *api.EntityMetadataEvent, pubsub.ClosableSubscription, error) {
+	typedCh := make(chan *api.EntityMetadataEvent)
+	sub := tb.entityMetadataNotifier.Subscribe()
+	sub.Unwrap(typedCh)
+
+	return typedCh, sub, nil
+}
+
 func (tb *tendermintBackend) GetNode(ctx context.Context, query *api.IDQuery) (*node.Node, error) {
 	q, err := tb.querier.QueryAt(ctx, query.Height)
 	if err != nil {
@@ -134,12 +163,24 @@ func (tb *tendermintBackend) GetNodeList(ctx context.Context, height int64) (*ap
 func (tb *tendermintBackend) Cleanup() {
 }
 
-func (tb *tendermintBackend) GetRuntimes(ctx context.Context, height int64) ([]*api.Runtime, error) {
-	q, err := tb.querier.QueryAt(ctx, height)
+func (tb *tendermintBackend) GetRuntimeStatus(ctx context.Context, query *api.NamespaceQuery) (*api.RuntimeStatus, error) {
+	q, err := tb.querier.QueryAt(ctx, query.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.RuntimeStatus(ctx, query.ID)
+}
+
+func (tb *tendermintBackend) GetRuntimes(ctx context.Context, query *api.GetRuntimesQuery) ([]*api.Runtime, error) {
+	q, err := tb.querier.QueryAt(ctx, query.Height)
 	if err != nil {
 		return nil, err
 	}
 
+	if query.IncludeSuspended {
+		return q.AllRuntimes(ctx)
+	}
 	return q.Runtimes(ctx)
 }
 
@@ -164,12 +205,37 @@ func (tb *tendermintBackend) GetEvents(ctx context.Context, height int64) ([]api
 		return nil, err
 	}
 
+	// Get transactions at given height.
+	txns, err := tb.service.GetTransactions(ctx, height)
+	if err != nil {
+		tb.logger.Error("failed to get tendermint transactions",
+			"err", err,
+			"height", height,
+		)
+		return nil, err
+	}
+
 	// Decode events from block results.
-	tmEvents := append(results.BeginBlockEvents, results.EndBlockEvents...)
-	for _, txResults := range results.TxsResults {
-		tmEvents = append(tmEvents, txResults.Events...)
+	tmEvents := append([]abcitypes.Event{}, results.BeginBlockEvents...)
+	tmEvents = append(tmEvents, results.EndBlockEvents...)
+	events, err := tb.onABCIEvents(ctx, tmEvents, height, false)
+	if err != nil {
+		return nil, err
+	}
+	for i := range events {
+		events[i].TxHash.Empty()
 	}
-	return tb.onABCIEvents(ctx, tmEvents, height, false)
+	for txIdx, txResults := range results.TxsResults {
+		// The order of transactions in txns and results.TxsResults is
+		// supposed to match, so the same index in both slices refers to the
+		// same transaction.
+		txEvents, txErr := tb.EventsFromTendermint(ctx, hash.NewFromBytes(txns[txIdx]), height, txResults.Events)
+		if txErr != nil {
+			return nil, txErr
+		}
+		events = append(events, txEvents...)
+	}
+	return events, nil
 }
 
 func (tb *tendermintBackend) worker(ctx context.Context) {
@@ -278,6 +344,44 @@ func (tb *tendermintBackend) onABCIEvents(ctx context.Context, tmEvents []abcity
 					}
 					events = append(events, evt)
 				}
+			} else if bytes.Equal(key, app.KeyRuntimeSuspended) {
+				// Runtime suspended event.
+				var rtEv api.RuntimeEvent
+				if err := cbor.Unmarshal(val, &rtEv); err != nil {
+					tb.logger.Error("worker: failed to get runtime from tag",
+						"err", err,
+					)
+					if doBroadcast {
+						continue
+					} else {
+						return nil, fmt.Errorf("registry: corrupt RuntimeSuspended event: %w", err)
+					}
+				}
+
+				if !doBroadcast {
+					events = append(events, api.Event{RuntimeEvent: &rtEv})
+				}
+			} else if bytes.Equal(key, app.KeyRuntimeOwnershipTransferred) {
+				// Runtime ownership transferred event.
+				var rtEv api.RuntimeEvent
+				if err := cbor.Unmarshal(val, &rtEv); err != nil {
+					tb.logger.Error("worker: failed to get runtime ownership transfer from tag",
+						"err", err,
+					)
+					if doBroadcast {
+						continue
+					} else {
+						return nil, fmt.Errorf("registry: corrupt RuntimeOwnershipTransferred event: %w", err)
+					}
+				}
+
+				if doBroadcast {
+					if !rtEv.Suspended {
+						tb.runtimeNotifier.Broadcast(rtEv.Runtime)
+					}
+				} else {
+					events = append(events, api.Event{RuntimeEvent: &rtEv})
+				}
 			} else if bytes.Equal(key, app.KeyEntityRegistered) {
 				// Entity registered event.
 				var ent entity.Entity
@@ -326,6 +430,25 @@ func (tb *tendermintBackend) onABCIEvents(ctx context.Context, tmEvents []abcity
 				} else {
 					events = append(events, api.Event{EntityEvent: eev})
 				}
+			} else if bytes.Equal(key, app.KeyEntityMetadata) {
+				// Entity metadata event.
+				var mev api.EntityMetadataEvent
+				if err := cbor.Unmarshal(val, &mev); err != nil {
+					tb.logger.Error("worker: failed to get entity metadata from tag",
+						"err", err,
+					)
+					if doBroadcast {
+						continue
+					} else {
+						return nil, fmt.Errorf("registry: corrupt EntityMetadata event: %w", err)
+					}
+				}
+
+				if doBroadcast {
+					tb.entityMetadataNotifier.Broadcast(&mev)
+				} else {
+					events = append(events, api.Event{EntityMetadataEvent: &mev})
+				}
 			} else if bytes.Equal(key, app.KeyRegistryNodeListEpoch) && doBroadcast {
 				// Node list epoch event.
 				nl, err := tb.getNodeList(ctx, height)
@@ -398,8 +521,22 @@ func (tb *tendermintBackend) getNodeList(ctx context.Context, height int64) (*ap
 	}, nil
 }
 
+// EventsFromTendermint implements ServiceClient.
+func (tb *tendermintBackend) EventsFromTendermint(ctx context.Context, txHash hash.Hash, height int64, tmEvents []abcitypes.Event) ([]api.Event, error) {
+	events, err := tb.onABCIEvents(ctx, tmEvents, height, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Append hash to each event.
+	for i := range events {
+		events[i].TxHash = txHash
+	}
+	return events, nil
+}
+
 // New constructs a new tendermint backed registry Backend instance.
-func New(ctx context.Context, service service.TendermintService) (api.Backend, error) {
+func New(ctx context.Context, service service.TendermintService) (ServiceClient, error) {
 	// Initialize and register the tendermint service component.
 	a := app.New()
 	if err := service.RegisterApplication(a); err != nil {
@@ -407,16 +544,17 @@ func New(ctx context.Context, service service.TendermintService) (api.Backend, e
 	}
 
 	tb := &tendermintBackend{
-		logger:           logging.GetLogger("registry/tendermint"),
-		service:          service,
-		querier:          a.QueryFactory().(*app.QueryFactory),
-		entityNotifier:   pubsub.NewBroker(false),
-		nodeNotifier:     pubsub.NewBroker(false),
-		nodeListNotifier: pubsub.NewBroker(true),
+		logger:                 logging.GetLogger("registry/tendermint"),
+		service:                service,
+		querier:                a.QueryFactory().(*app.QueryFactory),
+		entityNotifier:         pubsub.NewBroker(false),
+		entityMetadataNotifier: pubsub.NewBroker(false),
+		nodeNotifier:           pubsub.NewBroker(false),
+		nodeListNotifier:       pubsub.NewBroker(true),
 	}
 	tb.runtimeNotifier = pubsub.NewBrokerEx(func(ch channels.Channel) {
 		wr := ch.In()
-		runtimes, err := tb.GetRuntimes(ctx, consensus.HeightLatest)
+		runtimes, err := tb.GetRuntimes(ctx, &api.GetRuntimesQuery{Height: consensus.HeightLatest})
 		if err != nil {
 			tb.logger.Error("runtime notifier: unable to get a list of runtimes",
 				"err", err,
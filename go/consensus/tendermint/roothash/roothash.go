@@ -16,6 +16,7 @@ import (
 	"github.com/oasislabs/oasis-core/go/common"
 	"github.com/oasislabs/oasis-core/go/common/cbor"
 	"github.com/oasislabs/oasis-core/go/common/crash"
+	"github.com/oasislabs/oasis-core/go/common/crypto/hash"
 	"github.com/oasislabs/oasis-core/go/common/logging"
 	"github.com/oasislabs/oasis-core/go/common/pubsub"
 	consensus "github.com/oasislabs/oasis-core/go/consensus/api"
@@ -208,11 +209,43 @@ func (tb *tendermintBackend) GetEvents(ctx context.Context, height int64) ([]api
 		return nil, err
 	}
 
+	// Get transactions at given height.
+	txns, err := tb.service.GetTransactions(ctx, height)
+	if err != nil {
+		tb.logger.Error("failed to get tendermint transactions",
+			"err", err,
+			"height", height,
+		)
+		return nil, err
+	}
+
 	// Decode events from block results.
-	tmEvents := append(results.BeginBlockEvents, results.EndBlockEvents...)
-	for _, txResults := range results.TxsResults {
-		tmEvents = append(tmEvents, txResults.Events...)
+	var emptyHash hash.Hash
+	emptyHash.Empty()
+	tmEvents := append([]types.Event{}, results.BeginBlockEvents...)
+	tmEvents = append(tmEvents, results.EndBlockEvents...)
+	events, err := EventsFromTendermint(emptyHash, tmEvents)
+	if err != nil {
+		return nil, err
+	}
+	for txIdx, txResults := range results.TxsResults {
+		// The order of transactions in txns and results.TxsResults is
+		// supposed to match, so the same index in both slices refers to the
+		// same transaction.
+		txEvents, txErr := EventsFromTendermint(hash.NewFromBytes(txns[txIdx]), txResults.Events)
+		if txErr != nil {
+			return nil, txErr
+		}
+		events = append(events, txEvents...)
 	}
+
+	return events, nil
+}
+
+// EventsFromTendermint decodes the roothash events from the given tendermint
+// events emitted by the transaction with the given hash. Block events should
+// be decoded with the empty hash.
+func EventsFromTendermint(txHash hash.Hash, tmEvents []types.Event) ([]api.Event, error) {
 	var events []api.Event
 	for _, tmEv := range tmEvents {
 		// Ignore events that don't relate to the roothash app.
@@ -224,6 +257,7 @@ func (tb *tendermintBackend) GetEvents(ctx context.Context, height int64) ([]api
 			if bytes.Equal(pair.GetKey(), app.KeyMergeDiscrepancyDetected) {
 				// Merge discrepancy event.
 				evt := api.Event{
+					TxHash:                   txHash,
 					MergeDiscrepancyDetected: &api.MergeDiscrepancyDetectedEvent{},
 				}
 				events = append(events, evt)
@@ -234,6 +268,7 @@ func (tb *tendermintBackend) GetEvents(ctx context.Context, height int64) ([]api
 					return nil, fmt.Errorf("roothash: corrupt ExecutionDiscrepancyDetected event: %w", err)
 				}
 				evt := api.Event{
+					TxHash:                       txHash,
 					ExecutionDiscrepancyDetected: &eddValue.Event,
 				}
 				events = append(events, evt)
@@ -0,0 +1,166 @@
+package scheduler
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/dgraph-io/badger/v2/options"
+
+	cmnBadger "github.com/oasislabs/oasis-core/go/common/badger"
+	"github.com/oasislabs/oasis-core/go/common/cbor"
+	"github.com/oasislabs/oasis-core/go/common/keyformat"
+	"github.com/oasislabs/oasis-core/go/common/logging"
+	epochtime "github.com/oasislabs/oasis-core/go/epochtime/api"
+	"github.com/oasislabs/oasis-core/go/scheduler/api"
+)
+
+const (
+	archiveDBFilename = "scheduler-archive.badger.db"
+	archiveDBVersion  = 1
+)
+
+var (
+	// archiveMetadataKeyFmt is the metadata key format.
+	//
+	// Value is CBOR-serialized archiveMetadata.
+	archiveMetadataKeyFmt = keyformat.New(0x01)
+	// electionKeyFmt is the election index key format.
+	//
+	// Value is CBOR-serialized api.Election.
+	electionKeyFmt = keyformat.New(0x02, uint64(0))
+)
+
+type archiveMetadata struct {
+	// Version is the database schema version.
+	Version uint64 `json:"version"`
+}
+
+// electionArchive is a compact per-epoch archive of election results that
+// is kept independently of the (prunable) consensus state.
+type electionArchive struct {
+	logger *logging.Logger
+
+	db *badger.DB
+	gc *cmnBadger.GCWorker
+}
+
+func (a *electionArchive) ensureMetadata() error {
+	return a.db.Update(func(tx *badger.Txn) error {
+		item, err := tx.Get(archiveMetadataKeyFmt.Encode())
+		switch err {
+		case nil:
+		case badger.ErrKeyNotFound:
+			// Create new metadata section.
+			meta := archiveMetadata{
+				Version: archiveDBVersion,
+			}
+			return tx.Set(archiveMetadataKeyFmt.Encode(), cbor.Marshal(meta))
+		default:
+			return err
+		}
+
+		// Verify metadata section.
+		var meta archiveMetadata
+		if err = item.Value(func(val []byte) error {
+			return cbor.Unmarshal(val, &meta)
+		}); err != nil {
+			return err
+		}
+		if meta.Version != archiveDBVersion {
+			return fmt.Errorf("scheduler/tendermint: unsupported archive version (expected: %d got: %d)",
+				archiveDBVersion,
+				meta.Version,
+			)
+		}
+		return nil
+	})
+}
+
+func (a *electionArchive) put(election *api.Election) error {
+	return a.db.Update(func(tx *badger.Txn) error {
+		return tx.Set(electionKeyFmt.Encode(uint64(election.Epoch)), cbor.Marshal(election))
+	})
+}
+
+func (a *electionArchive) get(epoch epochtime.EpochTime) (*api.Election, error) {
+	var election api.Election
+	txErr := a.db.View(func(tx *badger.Txn) error {
+		item, err := tx.Get(electionKeyFmt.Encode(uint64(epoch)))
+		switch err {
+		case nil:
+		case badger.ErrKeyNotFound:
+			return api.ErrNoSuchElection
+		default:
+			return err
+		}
+
+		return item.Value(func(val []byte) error {
+			return cbor.Unmarshal(val, &election)
+		})
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	return &election, nil
+}
+
+// lastEpoch returns the most recent archived epoch, if any.
+func (a *electionArchive) lastEpoch() (epochtime.EpochTime, bool, error) {
+	var (
+		epoch uint64
+		found bool
+	)
+	txErr := a.db.View(func(tx *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Reverse = true
+		it := tx.NewIterator(opts)
+		defer it.Close()
+
+		it.Seek(electionKeyFmt.Encode(uint64(math.MaxUint64)))
+		if it.Valid() && electionKeyFmt.Decode(it.Item().Key(), &epoch) {
+			found = true
+		}
+		return nil
+	})
+	if txErr != nil {
+		return 0, false, txErr
+	}
+	return epochtime.EpochTime(epoch), found, nil
+}
+
+func (a *electionArchive) close() {
+	a.gc.Close()
+	a.db.Close()
+}
+
+func newElectionArchive(fn string) (*electionArchive, error) {
+	logger := logging.GetLogger("scheduler/tendermint/archive").With("path", fn)
+
+	opts := badger.DefaultOptions(fn)
+	opts = opts.WithLogger(cmnBadger.NewLogAdapter(logger))
+	opts = opts.WithSyncWrites(true)
+	// Allow value log truncation if required (this is needed to recover the
+	// value log file which can get corrupted in crashes).
+	opts = opts.WithTruncate(true)
+	opts = opts.WithCompression(options.None)
+	// Reduce cache size to 10 MiB as the default is 1 GiB.
+	opts = opts.WithMaxCacheSize(10 * 1024 * 1024)
+
+	db, err := badger.Open(opts)
+	if err != nil {
+		return nil, fmt.Errorf("scheduler/tendermint: failed to open archive: %w", err)
+	}
+
+	a := &electionArchive{
+		logger: logger,
+		db:     db,
+		gc:     cmnBadger.NewGCWorker(logger, db),
+	}
+	if err = a.ensureMetadata(); err != nil {
+		a.close()
+		return nil, err
+	}
+
+	return a, nil
+}
@@ -0,0 +1,74 @@
+package scheduler
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasislabs/oasis-core/go/common"
+	epochtime "github.com/oasislabs/oasis-core/go/epochtime/api"
+	"github.com/oasislabs/oasis-core/go/scheduler/api"
+)
+
+func TestElectionArchive(t *testing.T) {
+	require := require.New(t)
+
+	// Create a new random temporary directory under /tmp.
+	dataDir, err := ioutil.TempDir("", "oasis-scheduler-archive-test_")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dataDir)
+
+	fn := filepath.Join(dataDir, archiveDBFilename)
+	archive, err := newElectionArchive(fn)
+	require.NoError(err, "newElectionArchive")
+
+	_, err = archive.get(1)
+	require.Equal(api.ErrNoSuchElection, err, "get should fail for non-archived epoch")
+	_, ok, err := archive.lastEpoch()
+	require.NoError(err, "lastEpoch")
+	require.False(ok, "lastEpoch should not return an epoch for an empty archive")
+
+	runtimeID := common.NewTestNamespaceFromSeed([]byte("scheduler archive test ns"), 0)
+	for _, epoch := range []epochtime.EpochTime{3, 1, 2, 5} {
+		err = archive.put(&api.Election{
+			Epoch:  epoch,
+			Height: int64(epoch) * 10,
+			Committees: []*api.Committee{
+				{
+					Kind:      api.KindComputeExecutor,
+					RuntimeID: runtimeID,
+					ValidFor:  epoch,
+				},
+			},
+		})
+		require.NoError(err, "put")
+	}
+
+	election, err := archive.get(2)
+	require.NoError(err, "get")
+	require.EqualValues(2, election.Epoch)
+	require.EqualValues(20, election.Height)
+	require.Len(election.Committees, 1)
+	require.EqualValues(2, election.Committees[0].ValidFor)
+
+	_, err = archive.get(4)
+	require.Equal(api.ErrNoSuchElection, err, "get should fail for a missing epoch")
+
+	lastEpoch, ok, err := archive.lastEpoch()
+	require.NoError(err, "lastEpoch")
+	require.True(ok, "lastEpoch should return an epoch")
+	require.EqualValues(5, lastEpoch, "lastEpoch should return the most recent epoch")
+
+	// Archive should persist across reopens.
+	archive.close()
+	archive, err = newElectionArchive(fn)
+	require.NoError(err, "newElectionArchive (reopen)")
+	defer archive.close()
+
+	election, err = archive.get(5)
+	require.NoError(err, "get after reopen")
+	require.EqualValues(5, election.Epoch)
+}
@@ -5,6 +5,8 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"path/filepath"
+	"sync"
 
 	"github.com/eapache/channels"
 	tmtypes "github.com/tendermint/tendermint/types"
@@ -15,6 +17,7 @@ import (
 	consensus "github.com/oasislabs/oasis-core/go/consensus/api"
 	app "github.com/oasislabs/oasis-core/go/consensus/tendermint/apps/scheduler"
 	"github.com/oasislabs/oasis-core/go/consensus/tendermint/service"
+	epochtime "github.com/oasislabs/oasis-core/go/epochtime/api"
 	"github.com/oasislabs/oasis-core/go/scheduler/api"
 )
 
@@ -25,8 +28,12 @@ type tendermintBackend struct {
 
 	service service.TendermintService
 	querier *app.QueryFactory
+	archive *electionArchive
 
 	notifier *pubsub.Broker
+
+	closeOnce sync.Once
+	workersWg sync.WaitGroup
 }
 
 func (tb *tendermintBackend) StateToGenesis(ctx context.Context, height int64) (*api.Genesis, error) {
@@ -38,6 +45,11 @@ func (tb *tendermintBackend) StateToGenesis(ctx context.Context, height int64) (
 }
 
 func (tb *tendermintBackend) Cleanup() {
+	tb.closeOnce.Do(func() {
+		// Wait for all goroutines that write to the archive to terminate.
+		tb.workersWg.Wait()
+		tb.archive.close()
+	})
 }
 
 func (tb *tendermintBackend) GetValidators(ctx context.Context, height int64) ([]*api.Validator, error) {
@@ -49,6 +61,15 @@ func (tb *tendermintBackend) GetValidators(ctx context.Context, height int64) ([
 	return q.Validators(ctx)
 }
 
+func (tb *tendermintBackend) GetJailedNodes(ctx context.Context, height int64) ([]*api.JailStatus, error) {
+	q, err := tb.querier.QueryAt(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.JailedNodes(ctx)
+}
+
 func (tb *tendermintBackend) GetCommittees(ctx context.Context, request *api.GetCommitteesRequest) ([]*api.Committee, error) {
 	q, err := tb.querier.QueryAt(ctx, request.Height)
 	if err != nil {
@@ -78,6 +99,127 @@ func (tb *tendermintBackend) WatchCommittees(ctx context.Context) (<-chan *api.C
 	return typedCh, sub, nil
 }
 
+func (tb *tendermintBackend) GetValidatorsAtEpoch(ctx context.Context, epoch epochtime.EpochTime) ([]*api.Validator, error) {
+	election, err := tb.getElection(ctx, epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	return election.Validators, nil
+}
+
+func (tb *tendermintBackend) GetCommitteesAtEpoch(ctx context.Context, request *api.GetCommitteesAtEpochRequest) ([]*api.Committee, error) {
+	election, err := tb.getElection(ctx, request.Epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	var runtimeCommittees []*api.Committee
+	for _, c := range election.Committees {
+		if c.RuntimeID.Equal(&request.RuntimeID) {
+			runtimeCommittees = append(runtimeCommittees, c)
+		}
+	}
+
+	return runtimeCommittees, nil
+}
+
+func (tb *tendermintBackend) WatchCommitteesFromEpoch(ctx context.Context, epoch epochtime.EpochTime) (<-chan *api.Committee, pubsub.ClosableSubscription, error) {
+	currentEpoch, err := tb.service.EpochTime().GetEpoch(ctx, consensus.HeightLatest)
+	if err != nil {
+		return nil, nil, fmt.Errorf("scheduler: failed to get current epoch: %w", err)
+	}
+
+	if epoch < currentEpoch && currentEpoch-epoch > api.MaxCommitteeReplayEpochs {
+		return nil, nil, fmt.Errorf("%w: too many epochs to replay (max %d)", api.ErrInvalidArgument, api.MaxCommitteeReplayEpochs)
+	}
+
+	// Committees for the current epoch are sent by the broker-wide hook
+	// which runs after this one, so only replay the preceding epochs.
+	var elections []*api.Election
+	for e := epoch; e < currentEpoch; e++ {
+		var election *api.Election
+		if election, err = tb.getElection(ctx, e); err != nil {
+			return nil, nil, err
+		}
+		elections = append(elections, election)
+	}
+
+	typedCh := make(chan *api.Committee)
+	sub := tb.notifier.SubscribeEx(-1, func(ch channels.Channel) {
+		for _, election := range elections {
+			for _, c := range election.Committees {
+				ch.In() <- c
+			}
+		}
+	})
+	sub.Unwrap(typedCh)
+
+	return typedCh, sub, nil
+}
+
+// getElection returns the archived election results for the given epoch,
+// falling back to querying (and archiving) the consensus state at the
+// epoch's first block in case the epoch is not in the archive.
+func (tb *tendermintBackend) getElection(ctx context.Context, epoch epochtime.EpochTime) (*api.Election, error) {
+	election, err := tb.archive.get(epoch)
+	switch err {
+	case nil:
+		return election, nil
+	case api.ErrNoSuchElection:
+	default:
+		return nil, err
+	}
+
+	height, err := tb.service.EpochTime().GetEpochBlock(ctx, epoch)
+	if err != nil {
+		return nil, api.ErrNoSuchElection
+	}
+	election, err = tb.queryElection(ctx, epoch, height)
+	if err != nil {
+		return nil, api.ErrNoSuchElection
+	}
+	if err = tb.archive.put(election); err != nil {
+		tb.logger.Error("failed to archive election",
+			"err", err,
+			"epoch", epoch,
+		)
+	}
+
+	return election, nil
+}
+
+func (tb *tendermintBackend) queryElection(ctx context.Context, epoch epochtime.EpochTime, height int64) (*api.Election, error) {
+	q, err := tb.querier.QueryAt(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	validators, err := q.Validators(ctx)
+	if err != nil {
+		return nil, err
+	}
+	committees, err := q.AllCommittees(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only include committees that were elected for the given epoch.
+	var epochCommittees []*api.Committee
+	for _, c := range committees {
+		if c.ValidFor == epoch {
+			epochCommittees = append(epochCommittees, c)
+		}
+	}
+
+	return &api.Election{
+		Epoch:      epoch,
+		Height:     height,
+		Validators: validators,
+		Committees: epochCommittees,
+	}, nil
+}
+
 func (tb *tendermintBackend) getCurrentCommittees() ([]*api.Committee, error) {
 	q, err := tb.querier.QueryAt(context.TODO(), consensus.HeightLatest)
 	if err != nil {
@@ -88,6 +230,8 @@ func (tb *tendermintBackend) getCurrentCommittees() ([]*api.Committee, error) {
 }
 
 func (tb *tendermintBackend) worker(ctx context.Context) {
+	defer tb.workersWg.Done()
+
 	// Subscribe to blocks which elect committees.
 	sub, err := tb.service.Subscribe("scheduler-worker", app.QueryApp)
 	if err != nil {
@@ -164,23 +308,113 @@ func (tb *tendermintBackend) onEventDataNewBlock(ctx context.Context, ev tmtypes
 				for _, c := range committees {
 					tb.notifier.Broadcast(c)
 				}
+
+				tb.archiveElection(ctx, ev.Block.Header.Height)
 			}
 		}
 	}
 }
 
+// backfillArchive archives the elections of all epochs since the most
+// recently archived epoch (e.g., the epochs that passed while the node was
+// offline). Epochs whose consensus state has already been pruned can not be
+// recovered and remain missing from the archive.
+func (tb *tendermintBackend) backfillArchive(ctx context.Context) {
+	defer tb.workersWg.Done()
+
+	select {
+	case <-tb.service.Started():
+	case <-ctx.Done():
+		return
+	}
+
+	from, err := tb.service.EpochTime().GetBaseEpoch(ctx)
+	if err != nil {
+		tb.logger.Error("couldn't query base epoch for archive backfill",
+			"err", err,
+		)
+		return
+	}
+	lastEpoch, ok, err := tb.archive.lastEpoch()
+	if err != nil {
+		tb.logger.Error("couldn't query last archived epoch",
+			"err", err,
+		)
+		return
+	}
+	if ok && lastEpoch >= from {
+		from = lastEpoch + 1
+	}
+	currentEpoch, err := tb.service.EpochTime().GetEpoch(ctx, consensus.HeightLatest)
+	if err != nil {
+		tb.logger.Error("couldn't query current epoch for archive backfill",
+			"err", err,
+		)
+		return
+	}
+
+	var missing uint64
+	for epoch := from; epoch <= currentEpoch; epoch++ {
+		if ctx.Err() != nil {
+			return
+		}
+		if _, err = tb.getElection(ctx, epoch); err != nil {
+			missing++
+		}
+	}
+	if missing > 0 {
+		tb.logger.Warn("some elections could not be archived, consensus state not available",
+			"from_epoch", from,
+			"to_epoch", currentEpoch,
+			"num_missing", missing,
+		)
+	}
+}
+
+func (tb *tendermintBackend) archiveElection(ctx context.Context, height int64) {
+	epoch, err := tb.service.EpochTime().GetEpoch(ctx, height)
+	if err != nil {
+		tb.logger.Error("worker: couldn't query epoch for election archive",
+			"err", err,
+			"height", height,
+		)
+		return
+	}
+
+	election, err := tb.queryElection(ctx, epoch, height)
+	if err != nil {
+		tb.logger.Error("worker: couldn't query election for archive",
+			"err", err,
+			"epoch", epoch,
+		)
+		return
+	}
+	if err = tb.archive.put(election); err != nil {
+		tb.logger.Error("worker: failed to archive election",
+			"err", err,
+			"epoch", epoch,
+		)
+	}
+}
+
 // New constracts a new tendermint-based scheduler Backend instance.
-func New(ctx context.Context, service service.TendermintService) (api.Backend, error) {
+func New(ctx context.Context, dataDir string, service service.TendermintService) (api.Backend, error) {
 	// Initialze and register the tendermint service component.
 	a := app.New()
 	if err := service.RegisterApplication(a); err != nil {
 		return nil, err
 	}
 
+	archive, err := newElectionArchive(filepath.Join(dataDir, archiveDBFilename))
+	if err != nil {
+		return nil, err
+	}
+
 	tb := &tendermintBackend{
 		logger:  logging.GetLogger("scheduler/tendermint"),
 		service: service,
 		querier: a.QueryFactory().(*app.QueryFactory),
+		archive: archive,
 	}
 	tb.notifier = pubsub.NewBrokerEx(func(ch channels.Channel) {
 		currentCommittees, err := tb.getCurrentCommittees()
@@ -195,7 +429,9 @@ func New(ctx context.Context, service service.TendermintService) (api.Backend, e
 		}
 	})
 
+	tb.workersWg.Add(2)
 	go tb.worker(ctx)
+	go tb.backfillArchive(ctx)
 
 	return tb, nil
 }
@@ -32,6 +32,10 @@ type TendermintService interface {
 	// ABCI multiplexer.
 	SetTransactionAuthHandler(abci.TransactionAuthHandler) error
 
+	// SetMinGasPrice changes the minimum gas price of transactions
+	// accepted by the local node.
+	SetMinGasPrice(price uint64) error
+
 	// GetHeight returns the Tendermint block height.
 	GetHeight(ctx context.Context) (int64, error)
 
@@ -22,7 +22,17 @@ import (
 	"github.com/oasislabs/oasis-core/go/staking/api"
 )
 
-var _ api.Backend = (*tendermintBackend)(nil)
+// ServiceClient is the staking service client interface.
+type ServiceClient interface {
+	api.Backend
+
+	// EventsFromTendermint decodes the staking events from the given
+	// tendermint events emitted by the transaction with the given hash.
+	// Block events should be decoded with the empty hash.
+	EventsFromTendermint(ctx context.Context, txHash hash.Hash, height int64, tmEvents []abcitypes.Event) ([]api.Event, error)
+}
+
+var _ ServiceClient = (*tendermintBackend)(nil)
 
 type tendermintBackend struct {
 	logger *logging.Logger
@@ -118,6 +128,15 @@ func (tb *tendermintBackend) DebondingDelegations(ctx context.Context, query *ap
 	return q.DebondingDelegations(ctx, query.Owner)
 }
 
+func (tb *tendermintBackend) DelegationRewards(ctx context.Context, query *api.DelegationRewardsQuery) (*api.DelegationRewards, error) {
+	q, err := tb.querier.QueryAt(ctx, query.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.DelegationRewards(ctx, query.Owner, query.Escrow, query.From, query.To)
+}
+
 func (tb *tendermintBackend) WatchTransfers(ctx context.Context) (<-chan *api.TransferEvent, pubsub.ClosableSubscription, error) {
 	typedCh := make(chan *api.TransferEvent)
 	sub := tb.transferNotifier.Subscribe()
@@ -368,6 +387,27 @@ func (tb *tendermintBackend) onABCIEvents(context context.Context, tmEvents []ab
 
 				ee := &api.EscrowEvent{Add: &e}
 
+				if doBroadcast {
+					tb.escrowNotifier.Broadcast(ee)
+				} else {
+					events = append(events, api.Event{TxHash: eh, EscrowEvent: ee})
+				}
+			} else if bytes.Equal(key, app.KeyReward) {
+				// Reward event.
+				var e api.RewardEvent
+				if err := cbor.Unmarshal(val, &e); err != nil {
+					tb.logger.Error("worker: failed to get reward event from tag",
+						"err", err,
+					)
+					if doBroadcast {
+						continue
+					} else {
+						return nil, fmt.Errorf("staking: corrupt Reward event: %w", err)
+					}
+				}
+
+				ee := &api.EscrowEvent{Reward: &e}
+
 				if doBroadcast {
 					tb.escrowNotifier.Broadcast(ee)
 				} else {
@@ -398,8 +438,17 @@ func (tb *tendermintBackend) onABCIEvents(context context.Context, tmEvents []ab
 	return events, nil
 }
 
+// EventsFromTendermint implements ServiceClient.
+func (tb *tendermintBackend) EventsFromTendermint(ctx context.Context, txHash hash.Hash, height int64, tmEvents []abcitypes.Event) ([]api.Event, error) {
+	evs := make([]abciEventWithHash, 0, len(tmEvents))
+	for _, tmEv := range tmEvents {
+		evs = append(evs, abciEventWithHash{Event: tmEv, TxHash: txHash})
+	}
+	return tb.onABCIEvents(ctx, evs, height, false)
+}
+
 // New constructs a new tendermint backed staking Backend instance.
-func New(ctx context.Context, service service.TendermintService) (api.Backend, error) {
+func New(ctx context.Context, service service.TendermintService) (ServiceClient, error) {
 	// Initialize and register the tendermint service component.
 	a := app.New()
 	if err := service.RegisterApplication(a); err != nil {
@@ -13,6 +13,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/opentracing/opentracing-go"
 	"github.com/prometheus/client_golang/prometheus"
 	flag "github.com/spf13/pflag"
 	"github.com/spf13/viper"
@@ -211,10 +212,10 @@ type tendermintService struct {
 	beacon          beaconAPI.Backend
 	epochtime       epochtimeAPI.Backend
 	keymanager      keymanagerAPI.Backend
-	registry        registryAPI.Backend
+	registry        tmregistry.ServiceClient
 	registryMetrics *registry.MetricsUpdater
 	roothash        roothashAPI.Backend
-	staking         stakingAPI.Backend
+	staking         tmstaking.ServiceClient
 	scheduler       schedulerAPI.Backend
 	submissionMgr   consensusAPI.SubmissionManager
 
@@ -223,6 +224,7 @@ type tendermintService struct {
 	consensusSigner          signature.Signer
 	nodeSigner               signature.Signer
 	dataDir                  string
+	minGasPrice              uint64
 	isInitialized, isStarted bool
 	startedCh                chan struct{}
 	syncedCh                 chan struct{}
@@ -483,6 +485,15 @@ func (t *tendermintService) SubmitTx(ctx context.Context, tx *transaction.Signed
 	// Subscribe to the transaction becoming invalid.
 	txHash := hash.NewFromBytes(data)
 
+	// Trace submission until inclusion if the caller is being traced.
+	if parent := opentracing.SpanFromContext(ctx); parent != nil {
+		span := opentracing.StartSpan("SubmitTx(tx)",
+			opentracing.Tag{Key: "txHash", Value: txHash},
+			opentracing.ChildOf(parent.Context()),
+		)
+		defer span.Finish()
+	}
+
 	recheckCh, recheckSub, err := t.mux.WatchInvalidatedTx(txHash)
 	if err != nil {
 		return err
@@ -624,6 +635,27 @@ func (t *tendermintService) SetTransactionAuthHandler(handler abci.TransactionAu
 	return t.mux.SetTransactionAuthHandler(handler)
 }
 
+func (t *tendermintService) SetMinGasPrice(price uint64) error {
+	t.Lock()
+	defer t.Unlock()
+
+	t.minGasPrice = price
+	if !t.isInitialized {
+		// The minimum gas price will be applied on initialization.
+		return nil
+	}
+
+	if err := t.mux.SetMinGasPrice(price); err != nil {
+		return err
+	}
+
+	t.Logger.Info("changed minimum gas price",
+		"min_gas_price", price,
+	)
+
+	return nil
+}
+
 func (t *tendermintService) TransactionAuthHandler() consensusAPI.TransactionAuthHandler {
 	return t.mux.TransactionAuthHandler()
 }
@@ -709,6 +741,134 @@ func (t *tendermintService) GetTransactions(ctx context.Context, height int64) (
 	return txs, nil
 }
 
+func (t *tendermintService) GetTransactionsWithResults(ctx context.Context, height int64) (*consensusAPI.TransactionsWithResults, error) {
+	blk, err := t.GetTendermintBlock(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	if blk == nil {
+		return nil, consensusAPI.ErrNoCommittedBlocks
+	}
+	// Use the resolved height for all further queries.
+	height = blk.Header.Height
+
+	results, err := t.GetBlockResults(height)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.TxsResults) != len(blk.Data.Txs) {
+		return nil, fmt.Errorf("tendermint: mismatched number of transactions and results (txns: %d results: %d)",
+			len(blk.Data.Txs),
+			len(results.TxsResults),
+		)
+	}
+
+	txsWithResults := &consensusAPI.TransactionsWithResults{
+		Transactions: make([][]byte, 0, len(blk.Data.Txs)),
+		Results:      make([]*consensusAPI.Result, 0, len(results.TxsResults)),
+	}
+	for idx, tx := range blk.Data.Txs {
+		// Decode the events emitted by each transaction from its own results
+		// as identical transactions may be included multiple times.
+		rs := results.TxsResults[idx]
+		events, err := t.eventsFromTendermint(ctx, hash.NewFromBytes(tx), height, rs.Events)
+		if err != nil {
+			return nil, err
+		}
+
+		result := &consensusAPI.Result{
+			Events:  events,
+			GasUsed: transaction.Gas(rs.GetGasUsed()),
+		}
+		if !rs.IsOK() {
+			result.Error = consensusAPI.TransactionError{
+				Module:  rs.GetCodespace(),
+				Code:    rs.GetCode(),
+				Message: rs.GetLog(),
+			}
+		}
+
+		txsWithResults.Transactions = append(txsWithResults.Transactions, tx[:])
+		txsWithResults.Results = append(txsWithResults.Results, result)
+	}
+	return txsWithResults, nil
+}
+
+func (t *tendermintService) eventsFromTendermint(
+	ctx context.Context,
+	txHash hash.Hash,
+	height int64,
+	tmEvents []tmabcitypes.Event,
+) ([]*consensusAPI.Event, error) {
+	var events []*consensusAPI.Event
+
+	stakingEvents, err := t.staking.EventsFromTendermint(ctx, txHash, height, tmEvents)
+	if err != nil {
+		return nil, err
+	}
+	for i := range stakingEvents {
+		events = append(events, &consensusAPI.Event{Staking: &stakingEvents[i]})
+	}
+	registryEvents, err := t.registry.EventsFromTendermint(ctx, txHash, height, tmEvents)
+	if err != nil {
+		return nil, err
+	}
+	for i := range registryEvents {
+		events = append(events, &consensusAPI.Event{Registry: &registryEvents[i]})
+	}
+	roothashEvents, err := tmroothash.EventsFromTendermint(txHash, tmEvents)
+	if err != nil {
+		return nil, err
+	}
+	for i := range roothashEvents {
+		events = append(events, &consensusAPI.Event{RootHash: &roothashEvents[i]})
+	}
+
+	return events, nil
+}
+
+func (t *tendermintService) GetUnconfirmedTransactions(
+	ctx context.Context,
+	req *consensusAPI.GetUnconfirmedTransactionsRequest,
+) ([]*consensusAPI.UnconfirmedTransaction, error) {
+	if err := t.ensureStarted(ctx); err != nil {
+		return nil, err
+	}
+
+	// The mempool returns transactions in the order in which they will be
+	// considered for inclusion in a block.
+	var position uint64
+	var txs []*consensusAPI.UnconfirmedTransaction
+	for _, rawTx := range t.node.Mempool().ReapMaxTxs(-1) {
+		txHash := hash.NewFromBytes(rawTx)
+		if t.mux.IsTxReplaced(txHash) {
+			// Replaced transactions will be evicted from the mempool.
+			continue
+		}
+
+		// Transactions in the mempool have already been verified by CheckTx.
+		var sigTx transaction.SignedTransaction
+		if err := cbor.Unmarshal(rawTx, &sigTx); err != nil {
+			continue
+		}
+		var tx transaction.Transaction
+		if err := cbor.Unmarshal(sigTx.Blob, &tx); err != nil {
+			continue
+		}
+
+		if req.Signer == nil || req.Signer.Equal(sigTx.Signature.PublicKey) {
+			txs = append(txs, &consensusAPI.UnconfirmedTransaction{
+				Hash:        txHash,
+				Position:    position,
+				Signer:      sigTx.Signature.PublicKey,
+				Transaction: &tx,
+			})
+		}
+		position++
+	}
+	return txs, nil
+}
+
 func (t *tendermintService) GetStatus(ctx context.Context) (*consensusAPI.Status, error) {
 	// Genesis block is hardcoded as block 1, since tendermint doesn't have
 	// a genesis block as such, but some external tooling expects there to be
@@ -813,7 +973,7 @@ func (t *tendermintService) initialize() error {
 
 	// Initialize the rest of backends.
 	var err error
-	if t.beacon, err = tmbeacon.New(t.ctx, t); err != nil {
+	if t.beacon, err = tmbeacon.New(t.ctx, t, t.nodeSigner); err != nil {
 		t.Logger.Error("initialize: failed to initialize beacon backend",
 			"err", err,
 		)
@@ -845,7 +1005,7 @@ func (t *tendermintService) initialize() error {
 	}
 	t.svcMgr.RegisterCleanupOnly(t.staking, "staking backend")
 
-	if t.scheduler, err = tmscheduler.New(t.ctx, t); err != nil {
+	if t.scheduler, err = tmscheduler.New(t.ctx, filepath.Join(t.dataDir, StateDir), t); err != nil {
 		t.Logger.Error("scheduler: failed to initialize scheduler backend",
 			"err", err,
 		)
@@ -885,7 +1045,7 @@ func (t *tendermintService) GetTendermintBlock(ctx context.Context, height int64
 	}
 	result, err := t.client.Block(&tmHeight)
 	if err != nil {
-		return nil, fmt.Errorf("tendermint: block query failed: %w", err)
+		return nil, fmt.Errorf("%w: tendermint: block query failed: %s", consensusAPI.ErrVersionNotFound, err.Error())
 	}
 	return result.Block, nil
 }
@@ -918,7 +1078,7 @@ func (t *tendermintService) GetBlockResults(height int64) (*tmrpctypes.ResultBlo
 
 	result, err := t.client.BlockResults(&tmHeight)
 	if err != nil {
-		return nil, fmt.Errorf("tendermint: block results query failed: %w", err)
+		return nil, fmt.Errorf("%w: tendermint: block results query failed: %s", consensusAPI.ErrVersionNotFound, err.Error())
 	}
 
 	return result, nil
@@ -948,7 +1108,7 @@ func (t *tendermintService) initEpochtime() error {
 			return err
 		}
 	} else {
-		epochTime, err = epochtime.New(t.ctx, t, t.genesis.EpochTime.Parameters.Interval)
+		epochTime, err = epochtime.New(t.ctx, t, &t.genesis.EpochTime.Parameters)
 		if err != nil {
 			t.Logger.Error("initEpochtime: failed to initialize epochtime backend",
 				"err", err,
@@ -980,7 +1140,7 @@ func (t *tendermintService) lazyInit() error {
 		StorageBackend:  db.GetBackendName(),
 		Pruning:         pruneCfg,
 		HaltEpochHeight: t.genesis.HaltEpoch,
-		MinGasPrice:     viper.GetUint64(CfgConsensusMinGasPrice),
+		MinGasPrice:     t.minGasPrice,
 		OwnTxSigner:     t.nodeSigner.Public(),
 		DisableCheckTx:  viper.GetBool(CfgConsensusDebugDisableCheckTx) && cmflags.DebugDontBlameOasis(),
 	}
@@ -1412,6 +1572,7 @@ func New(ctx context.Context, dataDir string, identity *identity.Identity, upgra
 		genesisProvider:       genesisProvider,
 		ctx:                   ctx,
 		dataDir:               dataDir,
+		minGasPrice:           viper.GetUint64(CfgConsensusMinGasPrice),
 		startedCh:             make(chan struct{}),
 		syncedCh:              make(chan struct{}),
 	}
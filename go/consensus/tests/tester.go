@@ -44,9 +44,14 @@ func ConsensusImplementationTests(t *testing.T, backend consensus.ClientBackend)
 	require.EqualValues(blk.Height, status.LatestHeight, "latest block heights should match")
 	require.EqualValues(blk.Hash, status.LatestHash, "latest block hashes should match")
 
-	_, err = backend.GetTransactions(ctx, consensus.HeightLatest)
+	txs, err := backend.GetTransactions(ctx, blk.Height)
 	require.NoError(err, "GetTransactions")
 
+	txsWithResults, err := backend.GetTransactionsWithResults(ctx, blk.Height)
+	require.NoError(err, "GetTransactionsWithResults")
+	require.Len(txsWithResults.Transactions, len(txs), "GetTransactionsWithResults should return all transactions")
+	require.Len(txsWithResults.Results, len(txs), "GetTransactionsWithResults should return a result for each transaction")
+
 	blockCh, blockSub, err := backend.WatchBlocks(ctx)
 	require.NoError(err, "WatchBlocks")
 	defer blockSub.Close()
@@ -3,10 +3,17 @@ package api
 
 import (
 	"context"
+	"time"
 
+	"github.com/oasislabs/oasis-core/go/common"
+	"github.com/oasislabs/oasis-core/go/common/crypto/hash"
+	"github.com/oasislabs/oasis-core/go/common/crypto/signature"
 	"github.com/oasislabs/oasis-core/go/common/errors"
+	"github.com/oasislabs/oasis-core/go/common/node"
+	"github.com/oasislabs/oasis-core/go/common/version"
 	consensus "github.com/oasislabs/oasis-core/go/consensus/api"
 	epochtime "github.com/oasislabs/oasis-core/go/epochtime/api"
+	scheduler "github.com/oasislabs/oasis-core/go/scheduler/api"
 	upgrade "github.com/oasislabs/oasis-core/go/upgrade/api"
 )
 
@@ -19,13 +26,25 @@ type NodeController interface {
 	RequestShutdown(ctx context.Context, wait bool) error
 
 	// WaitSync waits for the node to finish syncing.
-	// TODO: These should be replaced with WaitReady (see oasis-core#2130).
+	//
+	// Deprecated: This only reflects the consensus layer, use WaitReady to
+	// wait for all of the node's services to become ready.
 	WaitSync(ctx context.Context) error
 
 	// IsSynced checks whether the node has finished syncing.
-	// TODO: These should be replaced with IsReady (see oasis-core#2130).
+	//
+	// Deprecated: This only reflects the consensus layer, use IsReady to
+	// check whether all of the node's services are ready.
 	IsSynced(ctx context.Context) (bool, error)
 
+	// WaitReady waits for the node to become ready, meaning that all of the
+	// node's services are ready to accept work.
+	WaitReady(ctx context.Context) error
+
+	// IsReady checks whether the node is ready, meaning that all of the
+	// node's services are ready to accept work.
+	IsReady(ctx context.Context) (bool, error)
+
 	// UpgradeBinary submits an upgrade descriptor to a running node.
 	// The node will wait for the appropriate epoch, then update its binaries
 	// and shut down.
@@ -36,6 +55,11 @@ type NodeController interface {
 
 	// GetStatus returns the current status overview of the node.
 	GetStatus(ctx context.Context) (*Status, error)
+
+	// ReloadConfig re-reads the node's configuration file and re-applies
+	// the subset of the configuration that can be changed without
+	// restarting the node.
+	ReloadConfig(ctx context.Context) error
 }
 
 // Status is the current status overview.
@@ -43,14 +67,216 @@ type Status struct {
 	// SoftwareVersion is the oasis-node software version.
 	SoftwareVersion string `json:"software_version"`
 
+	// Identity is the identity of the node.
+	Identity IdentityStatus `json:"identity"`
+
 	// Consensus is the status overview of the consensus layer.
 	Consensus consensus.Status `json:"consensus"`
+
+	// Readiness is the readiness of the node's services.
+	Readiness ReadinessStatus `json:"readiness"`
+
+	// Registration is the node's registration status.
+	Registration RegistrationStatus `json:"registration"`
+
+	// Runtimes is the status overview for each configured runtime.
+	Runtimes map[common.Namespace]RuntimeStatus `json:"runtimes,omitempty"`
+
+	// Keymanager is the key manager worker status in case the node is
+	// running a key manager.
+	Keymanager *KeymanagerStatus `json:"keymanager,omitempty"`
+}
+
+// ReadinessStatus is the readiness status of a node.
+type ReadinessStatus struct {
+	// Ready is true iff all of the node's services are ready.
+	Ready bool `json:"ready"`
+
+	// Services is the readiness of each of the node's services.
+	Services []ServiceReadiness `json:"services"`
+}
+
+// ServiceReadiness is the readiness of a single node service.
+type ServiceReadiness struct {
+	// Name is the name of the service.
+	Name string `json:"name"`
+
+	// Ready is true iff the service is ready.
+	Ready bool `json:"ready"`
+}
+
+// NewReadinessStatus creates a new readiness status from the readiness of
+// the individual services.
+func NewReadinessStatus(services []ServiceReadiness) *ReadinessStatus {
+	rs := &ReadinessStatus{
+		Ready:    true,
+		Services: services,
+	}
+	for _, svc := range services {
+		if !svc.Ready {
+			rs.Ready = false
+			break
+		}
+	}
+	return rs
+}
+
+// IdentityStatus is the identity of a node.
+type IdentityStatus struct {
+	// Node is the node identity public key.
+	Node signature.PublicKey `json:"node"`
+
+	// P2P is the public key used for runtime P2P communication.
+	P2P signature.PublicKey `json:"p2p"`
+
+	// Consensus is the consensus public key.
+	Consensus signature.PublicKey `json:"consensus"`
+
+	// TLS is the public key used for TLS connections.
+	TLS signature.PublicKey `json:"tls"`
+
+	// NextTLS is the public key that will be used for TLS connections after
+	// the next certificate rotation (if any).
+	NextTLS *signature.PublicKey `json:"next_tls,omitempty"`
+}
+
+// RegistrationStatus is the node registration status.
+type RegistrationStatus struct {
+	// LastRegistration is the time of the last successful registration with
+	// the consensus registry service. In case the node did not successfully
+	// register yet, it will be the zero timestamp.
+	LastRegistration time.Time `json:"last_registration"`
+
+	// Descriptor is the node descriptor that the node successfully
+	// registered with. In case the node did not successfully register yet,
+	// it will be nil.
+	Descriptor *node.Node `json:"descriptor,omitempty"`
+}
+
+// RuntimeStatus is the per-runtime status overview.
+type RuntimeStatus struct {
+	// Committee is the node's committee membership in the current epoch.
+	Committee *CommitteeStatus `json:"committee,omitempty"`
+
+	// LatestRound is the round of the latest runtime block seen by the node.
+	LatestRound uint64 `json:"latest_round"`
+
+	// LatestHash is the hash of the latest runtime block seen by the node.
+	LatestHash hash.Hash `json:"latest_hash"`
+
+	// Storage is the storage worker status in case the node is a storage
+	// node for the runtime.
+	Storage *StorageStatus `json:"storage,omitempty"`
+
+	// Host is the hosted runtime status in case the node is hosting the
+	// runtime.
+	Host *RuntimeHostStatus `json:"host,omitempty"`
+}
+
+// CommitteeStatus is the node's committee membership in a given epoch.
+//
+// Roles are set to scheduler.Invalid in case the node is not a member of
+// the given committee.
+type CommitteeStatus struct {
+	// Epoch is the epoch the committees were elected for.
+	Epoch epochtime.EpochTime `json:"epoch"`
+
+	// ExecutorRole is the node's role in the executor committee.
+	ExecutorRole scheduler.Role `json:"executor_role"`
+
+	// TransactionSchedulerRole is the node's role in the transaction
+	// scheduler committee.
+	TransactionSchedulerRole scheduler.Role `json:"txn_scheduler_role"`
+
+	// MergeRole is the node's role in the merge committee.
+	MergeRole scheduler.Role `json:"merge_role"`
+
+	// StorageRole is the node's role in the storage committee.
+	StorageRole scheduler.Role `json:"storage_role"`
 }
 
-// Shutdownable is an interface the node presents for shutting itself down.
-type Shutdownable interface {
+// StorageStatus is the storage worker status for a runtime.
+type StorageStatus struct {
+	// LastSyncedRound is the last runtime round that was fully synced.
+	LastSyncedRound uint64 `json:"last_synced_round"`
+}
+
+// RuntimeHostState is the state of a hosted runtime.
+type RuntimeHostState string
+
+const (
+	// RuntimeHostStateProvisioned is the state of a runtime that has been
+	// provisioned but has not yet started.
+	RuntimeHostStateProvisioned RuntimeHostState = "provisioned"
+	// RuntimeHostStateStarted is the state of a runtime that is running.
+	RuntimeHostStateStarted RuntimeHostState = "started"
+	// RuntimeHostStateFailed is the state of a runtime that failed to start.
+	RuntimeHostStateFailed RuntimeHostState = "failed"
+	// RuntimeHostStateStopped is the state of a runtime that has stopped.
+	RuntimeHostStateStopped RuntimeHostState = "stopped"
+)
+
+// RuntimeHostStatus is the status of a hosted runtime.
+type RuntimeHostStatus struct {
+	// State is the current state of the hosted runtime.
+	State RuntimeHostState `json:"state"`
+
+	// Version is the version of the running runtime.
+	Version *version.Version `json:"version,omitempty"`
+
+	// TEE is the TEE capability of the running runtime in case the runtime
+	// is running inside a TEE.
+	TEE *node.CapabilityTEE `json:"tee,omitempty"`
+
+	// Error is the error that caused the runtime to fail to start.
+	Error string `json:"error,omitempty"`
+}
+
+// KeymanagerStatus is the key manager worker status.
+type KeymanagerStatus struct {
+	// RuntimeID is the key manager runtime identifier.
+	RuntimeID common.Namespace `json:"runtime_id"`
+
+	// Initialized is true iff the key manager enclave has been initialized.
+	Initialized bool `json:"initialized"`
+
+	// IsSecure is true iff the key manager enclave is a secure build.
+	IsSecure bool `json:"is_secure"`
+
+	// Checksum is the key manager master secret verification checksum.
+	Checksum []byte `json:"checksum,omitempty"`
+
+	// PolicyChecksum is the checksum of the key manager policy in use.
+	PolicyChecksum []byte `json:"policy_checksum,omitempty"`
+
+	// MayGenerate is true iff the key manager may generate a new master
+	// secret.
+	MayGenerate bool `json:"may_generate"`
+}
+
+// ControlledNode is an interface the node presents to the control server.
+type ControlledNode interface {
 	// RequestShutdown is the method called by the control server to trigger node shutdown.
 	RequestShutdown() (<-chan struct{}, error)
+
+	// GetReadiness returns the readiness status of the node's services.
+	GetReadiness(ctx context.Context) (*ReadinessStatus, error)
+
+	// GetIdentity returns the node's identity status.
+	GetIdentity() IdentityStatus
+
+	// GetRegistrationStatus returns the node's registration status.
+	GetRegistrationStatus(ctx context.Context) (*RegistrationStatus, error)
+
+	// GetRuntimeStatus returns the status overview of all configured runtimes.
+	GetRuntimeStatus(ctx context.Context) (map[common.Namespace]RuntimeStatus, error)
+
+	// GetKeymanagerStatus returns the key manager worker status or nil in
+	// case the node is not running a key manager.
+	GetKeymanagerStatus(ctx context.Context) (*KeymanagerStatus, error)
+
+	// ReloadConfig reloads the node's reloadable configuration.
+	ReloadConfig(ctx context.Context) error
 }
 
 // DebugModuleName is the module name for the debug controller service.
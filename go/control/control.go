@@ -3,6 +3,7 @@ package control
 
 import (
 	"context"
+	"time"
 
 	"github.com/oasislabs/oasis-core/go/common/version"
 	consensus "github.com/oasislabs/oasis-core/go/consensus/api"
@@ -10,8 +11,12 @@ import (
 	upgrade "github.com/oasislabs/oasis-core/go/upgrade/api"
 )
 
+// readinessCheckInterval is the interval at which WaitReady re-checks the
+// readiness of the node's services.
+const readinessCheckInterval = 1 * time.Second
+
 type nodeController struct {
-	node      control.Shutdownable
+	node      control.ControlledNode
 	consensus consensus.Backend
 	upgrader  upgrade.Backend
 }
@@ -52,6 +57,35 @@ func (c *nodeController) IsSynced(ctx context.Context) (bool, error) {
 	}
 }
 
+func (c *nodeController) WaitReady(ctx context.Context) error {
+	ticker := time.NewTicker(readinessCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		ready, err := c.IsReady(ctx)
+		if err != nil {
+			return err
+		}
+		if ready {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *nodeController) IsReady(ctx context.Context) (bool, error) {
+	rs, err := c.node.GetReadiness(ctx)
+	if err != nil {
+		return false, err
+	}
+	return rs.Ready, nil
+}
+
 func (c *nodeController) UpgradeBinary(ctx context.Context, descriptor *upgrade.Descriptor) error {
 	return c.upgrader.SubmitDescriptor(ctx, descriptor)
 }
@@ -60,20 +94,49 @@ func (c *nodeController) CancelUpgrade(ctx context.Context) error {
 	return c.upgrader.CancelUpgrade(ctx)
 }
 
+func (c *nodeController) ReloadConfig(ctx context.Context) error {
+	return c.node.ReloadConfig(ctx)
+}
+
 func (c *nodeController) GetStatus(ctx context.Context) (*control.Status, error) {
 	cs, err := c.consensus.GetStatus(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	readiness, err := c.node.GetReadiness(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rs, err := c.node.GetRegistrationStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	runtimes, err := c.node.GetRuntimeStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	kms, err := c.node.GetKeymanagerStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	return &control.Status{
 		SoftwareVersion: version.SoftwareVersion,
+		Identity:        c.node.GetIdentity(),
 		Consensus:       *cs,
+		Readiness:       *readiness,
+		Registration:    *rs,
+		Runtimes:        runtimes,
+		Keymanager:      kms,
 	}, nil
 }
 
 // New creates a new oasis-node controller.
-func New(node control.Shutdownable, consensus consensus.Backend, upgrader upgrade.Backend) control.NodeController {
+func New(node control.ControlledNode, consensus consensus.Backend, upgrader upgrade.Backend) control.NodeController {
 	return &nodeController{
 		node:      node,
 		consensus: consensus,
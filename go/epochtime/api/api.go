@@ -4,6 +4,7 @@ package api
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/oasislabs/oasis-core/go/common/pubsub"
 	"github.com/oasislabs/oasis-core/go/oasis-node/cmd/common/flags"
@@ -67,8 +68,16 @@ type Genesis struct {
 // ConsensusParameters are the epochtime consensus parameters.
 type ConsensusParameters struct {
 	// Interval is the epoch interval (in blocks).
+	//
+	// If TargetDuration is set, this is instead the minimum number of
+	// blocks in an epoch.
 	Interval int64 `json:"interval"`
 
+	// TargetDuration is the target wall-clock duration of an epoch, based
+	// on consensus block time. If zero, epochs have a fixed length of
+	// Interval blocks.
+	TargetDuration time.Duration `json:"target_duration,omitempty"`
+
 	// DebugMockBackend is flag for enabling mock epochtime backend.
 	DebugMockBackend bool `json:"debug_mock_backend"`
 }
@@ -84,6 +93,13 @@ func (g *Genesis) SanityCheck() error {
 		return fmt.Errorf("epochtime: sanity check failed: epoch interval must be > 0")
 	}
 
+	if g.Parameters.TargetDuration < 0 {
+		return fmt.Errorf("epochtime: sanity check failed: target duration must be >= 0")
+	}
+	if g.Parameters.TargetDuration > 0 && g.Parameters.DebugMockBackend {
+		return fmt.Errorf("epochtime: sanity check failed: target duration not supported by mock backend")
+	}
+
 	if g.Base == EpochInvalid {
 		return fmt.Errorf("epochtime: sanity check failed: starting epoch is invalid")
 	}
@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
@@ -24,10 +26,17 @@ import (
 )
 
 const (
+	// CfgNodeSelectionPolicy configures the node selection policy used by the key manager
+	// client.
+	CfgNodeSelectionPolicy = "keymanager.client.node_selection_policy"
+
 	retryInterval = 1 * time.Second
 	maxRetries    = 15
 )
 
+// Flags has the configuration flags.
+var Flags = flag.NewFlagSet("", flag.ContinueOnError)
+
 // ErrKeyManagerNotAvailable is the error when a key manager is not available.
 var ErrKeyManagerNotAvailable = errors.New("keymanager/client: key manager not available")
 
@@ -67,14 +76,15 @@ func (c *Client) CallRemote(ctx context.Context, data []byte) ([]byte, error) {
 
 	var resp []byte
 	call := func() error {
-		conn := c.committeeClient.GetConnection()
+		conn := c.committeeClient.GetConnectionWithMeta()
 		if conn == nil {
 			c.logger.Warn("no key manager connection for runtime")
 			return ErrKeyManagerNotAvailable
 		}
-		client := enclaverpc.NewTransportClient(conn)
+		client := enclaverpc.NewTransportClient(conn.ClientConn)
 
 		var err error
+		start := time.Now()
 		resp, err = client.CallEnclave(ctx, &enclaverpc.CallEnclaveRequest{
 			RuntimeID: c.runtime.ID(),
 			Endpoint:  api.EnclaveRPCEndpoint,
@@ -85,8 +95,12 @@ func (c *Client) CallRemote(ctx context.Context, data []byte) ([]byte, error) {
 			// is being updated, so we must retry.
 			return err
 		}
-		// Request failed, communicate that to the node selection policy.
-		c.committeeClient.UpdateNodeSelectionPolicy(committee.NodeSelectionFeedback{Bad: err})
+		// Communicate the outcome of the request to the node selection policy.
+		c.committeeClient.UpdateNodeSelectionPolicy(committee.NodeSelectionFeedback{
+			ID:      conn.Node.ID,
+			Bad:     err,
+			Latency: time.Since(start),
+		})
 		return backoff.Permanent(err)
 	}
 
@@ -198,7 +212,12 @@ func New(
 		return nil, fmt.Errorf("keymanager/client: failed to create node descriptor watcher: %w", err)
 	}
 
-	var opts []committee.ClientOption
+	policy, err := committee.NewNodeSelectionPolicy(viper.GetString(CfgNodeSelectionPolicy), "keymanager", runtime.ID())
+	if err != nil {
+		return nil, fmt.Errorf("keymanager/client: failed to create node selection policy: %w", err)
+	}
+
+	opts := []committee.ClientOption{committee.WithNodeSelectionPolicy(policy)}
 	if identity != nil {
 		opts = append(opts, committee.WithClientAuthentication(identity))
 	}
@@ -221,3 +240,9 @@ func New(
 
 	return c, nil
 }
+
+func init() {
+	Flags.String(CfgNodeSelectionPolicy, committee.NodeSelectionPolicyRoundRobin, "Key manager client node selection policy (round-robin, latency)")
+
+	_ = viper.BindPFlags(Flags)
+}
@@ -0,0 +1,156 @@
+// Package gateway implements the JSON/HTTP gateway service.
+package gateway
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	cmnGrpc "github.com/oasislabs/oasis-core/go/common/grpc"
+	grpcGateway "github.com/oasislabs/oasis-core/go/common/grpc/gateway"
+	"github.com/oasislabs/oasis-core/go/common/service"
+	consensus "github.com/oasislabs/oasis-core/go/consensus/api"
+	registry "github.com/oasislabs/oasis-core/go/registry/api"
+	roothash "github.com/oasislabs/oasis-core/go/roothash/api"
+	runtimeClient "github.com/oasislabs/oasis-core/go/runtime/client/api"
+	scheduler "github.com/oasislabs/oasis-core/go/scheduler/api"
+	staking "github.com/oasislabs/oasis-core/go/staking/api"
+)
+
+const (
+	cfgGatewayBind           = "gateway.bind"
+	cfgGatewayRateLimit      = "gateway.rate_limit.rate"
+	cfgGatewayRateLimitBurst = "gateway.rate_limit.burst"
+	cfgGatewayMaxStreams     = "gateway.max_streams"
+)
+
+// Flags has the flags used by the gateway service.
+var Flags = flag.NewFlagSet("", flag.ContinueOnError)
+
+type gatewayService struct {
+	service.BaseBackgroundService
+
+	address string
+	handler http.Handler
+
+	listener net.Listener
+	server   *http.Server
+
+	ctx   context.Context
+	errCh chan error
+}
+
+func (g *gatewayService) Start() error {
+	if g.address == "" {
+		return nil
+	}
+
+	g.Logger.Info("JSON/HTTP gateway endpoint is enabled",
+		"address", g.address,
+	)
+
+	listener, err := net.Listen("tcp", g.address)
+	if err != nil {
+		return err
+	}
+
+	g.listener = listener
+	g.server = &http.Server{Handler: g.handler}
+
+	go func() {
+		if err := g.server.Serve(g.listener); err != nil {
+			g.BaseBackgroundService.Stop()
+			g.errCh <- err
+		}
+	}()
+
+	return nil
+}
+
+func (g *gatewayService) Stop() {
+	if g.server != nil {
+		select {
+		case err := <-g.errCh:
+			if err != nil {
+				g.Logger.Error("gateway server terminated uncleanly",
+					"err", err,
+				)
+			}
+		default:
+			_ = g.server.Shutdown(g.ctx)
+		}
+		g.server = nil
+	}
+}
+
+func (g *gatewayService) Cleanup() {
+	if g.listener != nil {
+		_ = g.listener.Close()
+		g.listener = nil
+	}
+}
+
+// New constructs a new JSON/HTTP gateway service serving the given handler.
+func New(ctx context.Context, handler http.Handler) (service.BackgroundService, error) {
+	address := viper.GetString(cfgGatewayBind)
+
+	return &gatewayService{
+		BaseBackgroundService: *service.NewBaseBackgroundService("gateway"),
+		address:               address,
+		handler:               handler,
+		ctx:                   ctx,
+		errCh:                 make(chan error),
+	}, nil
+}
+
+// Config returns the JSON/HTTP gateway configuration.
+func Config() *grpcGateway.Config {
+	cfg := &grpcGateway.Config{
+		MaxStreams: viper.GetInt(cfgGatewayMaxStreams),
+	}
+	if rate := viper.GetFloat64(cfgGatewayRateLimit); rate > 0 {
+		cfg.RateLimit = &cmnGrpc.RateLimitConfig{
+			Default: cmnGrpc.RateLimit{
+				Rate:  rate,
+				Burst: viper.GetUint64(cfgGatewayRateLimitBurst),
+			},
+		}
+	}
+	return cfg
+}
+
+// RegisterErrorStatuses registers the HTTP status codes of the errors that
+// the public services may return with the given gateway.
+func RegisterErrorStatuses(gw *grpcGateway.Gateway) {
+	gw.RegisterErrorStatus(http.StatusNotFound,
+		consensus.ErrNoCommittedBlocks,
+		consensus.ErrVersionNotFound,
+		registry.ErrNoSuchEntity,
+		registry.ErrNoSuchNode,
+		registry.ErrNoSuchRuntime,
+		registry.ErrNoSuchEntityMetadata,
+		roothash.ErrNotFound,
+		runtimeClient.ErrNotFound,
+		scheduler.ErrNoSuchElection,
+	)
+	gw.RegisterErrorStatus(http.StatusBadRequest,
+		registry.ErrInvalidArgument,
+		roothash.ErrInvalidArgument,
+		roothash.ErrInvalidRuntime,
+		scheduler.ErrInvalidArgument,
+		staking.ErrInvalidArgument,
+		staking.ErrInvalidThreshold,
+	)
+}
+
+func init() {
+	Flags.String(cfgGatewayBind, "", "enable JSON/HTTP gateway for read-only public services at given address")
+	Flags.Float64(cfgGatewayRateLimit, 0, "number of calls per second allowed for each client and method on the gateway (0 disables)")
+	Flags.Uint64(cfgGatewayRateLimitBurst, 0, "number of calls allowed in a burst for each client and method on the gateway")
+	Flags.Int(cfgGatewayMaxStreams, 100, "maximum number of concurrently open event streams on the gateway (0 disables)")
+
+	_ = viper.BindPFlags(Flags)
+}
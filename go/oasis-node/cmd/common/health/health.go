@@ -0,0 +1,145 @@
+// Package health implements a HTTP health and readiness check service.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasislabs/oasis-core/go/common/service"
+	control "github.com/oasislabs/oasis-core/go/control/api"
+)
+
+const (
+	cfgHealthBind = "health.bind"
+
+	// PathHealth is the path of the liveness check endpoint.
+	PathHealth = "/health"
+	// PathReady is the path of the readiness check endpoint.
+	PathReady = "/ready"
+)
+
+// Flags has the flags used by the health service.
+var Flags = flag.NewFlagSet("", flag.ContinueOnError)
+
+// ReadinessChecker is the interface used to query the node readiness.
+type ReadinessChecker interface {
+	// GetReadiness returns the readiness status of the node's services.
+	GetReadiness(ctx context.Context) (*control.ReadinessStatus, error)
+}
+
+type healthService struct {
+	service.BaseBackgroundService
+
+	address string
+	checker ReadinessChecker
+
+	listener net.Listener
+	server   *http.Server
+
+	ctx   context.Context
+	errCh chan error
+}
+
+func (h *healthService) handleHealth(w http.ResponseWriter, r *http.Request) {
+	// If we are able to serve the request, the node is alive.
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok\n"))
+}
+
+func (h *healthService) handleReady(w http.ResponseWriter, r *http.Request) {
+	rs, err := h.checker.GetReadiness(r.Context())
+	if err != nil {
+		h.Logger.Error("failed to query node readiness",
+			"err", err,
+		)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	status := http.StatusOK
+	if !rs.Ready {
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(rs)
+}
+
+func (h *healthService) Start() error {
+	if h.address == "" {
+		return nil
+	}
+
+	h.Logger.Info("health check HTTP endpoint is enabled",
+		"address", h.address,
+	)
+
+	listener, err := net.Listen("tcp", h.address)
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(PathHealth, h.handleHealth)
+	mux.HandleFunc(PathReady, h.handleReady)
+
+	h.listener = listener
+	h.server = &http.Server{Handler: mux}
+
+	go func() {
+		if err := h.server.Serve(h.listener); err != nil {
+			h.BaseBackgroundService.Stop()
+			h.errCh <- err
+		}
+	}()
+
+	return nil
+}
+
+func (h *healthService) Stop() {
+	if h.server != nil {
+		select {
+		case err := <-h.errCh:
+			if err != nil {
+				h.Logger.Error("health server terminated uncleanly",
+					"err", err,
+				)
+			}
+		default:
+			_ = h.server.Shutdown(h.ctx)
+		}
+		h.server = nil
+	}
+}
+
+func (h *healthService) Cleanup() {
+	if h.listener != nil {
+		_ = h.listener.Close()
+		h.listener = nil
+	}
+}
+
+// New constructs a new health check service.
+func New(ctx context.Context, checker ReadinessChecker) (service.BackgroundService, error) {
+	address := viper.GetString(cfgHealthBind)
+
+	return &healthService{
+		BaseBackgroundService: *service.NewBaseBackgroundService("health"),
+		address:               address,
+		checker:               checker,
+		ctx:                   ctx,
+		errCh:                 make(chan error),
+	}, nil
+}
+
+func init() {
+	Flags.String(cfgHealthBind, "", "enable health and readiness check endpoint at given address")
+
+	_ = viper.BindPFlags(Flags)
+}
@@ -21,28 +21,36 @@ const (
 // LoggingFlags has the logging flags.
 var loggingFlags = flag.NewFlagSet("", flag.ContinueOnError)
 
-func initLogging() error {
-	logFile := viper.GetString(cfgLogFile)
-
+func getLogLevels(v *viper.Viper) (logging.Level, map[string]logging.Level, error) {
 	var logLevel logging.Level
 	var moduleLevels = map[string]logging.Level{}
-	if err := logLevel.Set(viper.GetString(cfgLogLevel)); err != nil {
-		if errDefault := logLevel.Set(viper.GetString(cfgLogLevel + ".default")); errDefault != nil {
-			return errDefault
+	if err := logLevel.Set(v.GetString(cfgLogLevel)); err != nil {
+		if errDefault := logLevel.Set(v.GetString(cfgLogLevel + ".default")); errDefault != nil {
+			return logLevel, nil, errDefault
 		}
 
-		for k, v := range viper.GetStringMapString(cfgLogLevel) {
+		for k, v := range v.GetStringMapString(cfgLogLevel) {
 			if k == "default" {
 				continue
 			}
 
 			var lvl logging.Level
 			if err = lvl.Set(v); err != nil {
-				return err
+				return logLevel, nil, err
 			}
 			moduleLevels[k] = lvl
 		}
 	}
+	return logLevel, moduleLevels, nil
+}
+
+func initLogging() error {
+	logFile := viper.GetString(cfgLogFile)
+
+	logLevel, moduleLevels, err := getLogLevels(viper.GetViper())
+	if err != nil {
+		return err
+	}
 
 	var logFmt logging.Format
 	if err := logFmt.Set(viper.GetString(cfgLogFmt)); err != nil {
@@ -53,7 +61,6 @@ func initLogging() error {
 	if logFile != "" {
 		logFile = normalizePath(logFile)
 
-		var err error
 		if w, err = os.OpenFile(logFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600); err != nil {
 			return err
 		}
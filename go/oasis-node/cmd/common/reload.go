@@ -0,0 +1,173 @@
+package common
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/spf13/viper"
+
+	"github.com/oasislabs/oasis-core/go/common/logging"
+)
+
+// ReloadableKey is a configuration key that can be changed without
+// restarting the node.
+type ReloadableKey struct {
+	// Key is the configuration key.
+	Key string
+	// Parse parses and validates a value of the key and returns it in
+	// the form it should be compared in and handed to the subsystem that
+	// uses it (e.g., YAML lists are read as []interface{} while the
+	// corresponding flags are []string). If nil, values are used as read.
+	Parse func(value interface{}) (interface{}, error)
+}
+
+// reloadableKeys are the configuration keys handled by this package that
+// can be changed without restarting the node.
+var reloadableKeys = []ReloadableKey{
+	{Key: cfgLogLevel},
+}
+
+var (
+	reloadLock sync.Mutex
+	// appliedValues are the parsed values of the reloadable configuration
+	// keys as of the last reload. Keys that were never reloaded retain the
+	// values they were configured with on startup.
+	appliedValues = make(map[string]interface{})
+)
+
+// ReloadConfig re-reads the configuration file and applies the new values
+// of the log levels. All other configuration keys retain their current
+// values, and the global configuration is never modified, so it is up to
+// the caller to hand the returned values of the given configuration keys
+// to the subsystems that use them.
+//
+// Keys that are missing from the configuration file also retain their
+// current values. The new values of all keys are validated before any of
+// them is applied, so the current configuration is kept in case any of the
+// new values is invalid.
+//
+// It returns the parsed new values of the given keys whose values have
+// changed.
+func ReloadConfig(keys ...ReloadableKey) (map[string]interface{}, error) {
+	if cfgFile == "" {
+		return nil, fmt.Errorf("no configuration file to reload")
+	}
+
+	reloadLock.Lock()
+	defer reloadLock.Unlock()
+
+	v := viper.New()
+	v.SetConfigFile(cfgFile)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read configuration file: %w", err)
+	}
+
+	// Gather and validate the new values of all keys that have changed.
+	newValues := make(map[string]interface{})
+	for _, rk := range append(append([]ReloadableKey{}, reloadableKeys...), keys...) {
+		if !v.IsSet(rk.Key) {
+			continue
+		}
+
+		currentValue, applied := appliedValues[rk.Key]
+		newValue := v.Get(rk.Key)
+		if rk.Parse != nil {
+			var err error
+			if newValue, err = rk.Parse(newValue); err != nil {
+				return nil, fmt.Errorf("invalid value for '%s': %w", rk.Key, err)
+			}
+			if !applied {
+				if currentValue, err = rk.Parse(viper.Get(rk.Key)); err != nil {
+					return nil, fmt.Errorf("invalid current value for '%s': %w", rk.Key, err)
+				}
+			}
+		} else if !applied {
+			currentValue = viper.Get(rk.Key)
+		}
+		if !reflect.DeepEqual(newValue, currentValue) {
+			newValues[rk.Key] = newValue
+		}
+	}
+
+	// Apply the new configuration.
+	if newValue, ok := newValues[cfgLogLevel]; ok {
+		lv := viper.New()
+		lv.Set(cfgLogLevel, newValue)
+		logLevel, moduleLevels, err := getLogLevels(lv)
+		if err != nil {
+			return nil, fmt.Errorf("invalid logging configuration: %w", err)
+		}
+		if err = logging.SetLevels(logLevel, moduleLevels); err != nil {
+			return nil, fmt.Errorf("failed to reload logging configuration: %w", err)
+		}
+	}
+	for key, newValue := range newValues {
+		appliedValues[key] = newValue
+	}
+
+	changed := make(map[string]interface{})
+	changedKeys := []string{}
+	for _, rk := range keys {
+		if newValue, ok := newValues[rk.Key]; ok {
+			changed[rk.Key] = newValue
+			changedKeys = append(changedKeys, rk.Key)
+		}
+	}
+	sort.Strings(changedKeys)
+
+	rootLog.Info("configuration reloaded",
+		"changed", changedKeys,
+	)
+
+	return changed, nil
+}
+
+// ParseUint64 parses a configuration value as an unsigned integer.
+func ParseUint64(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case uint64:
+		return v, nil
+	case uint:
+		return uint64(v), nil
+	case int:
+		if v < 0 {
+			return nil, fmt.Errorf("negative value %d", v)
+		}
+		return uint64(v), nil
+	case int64:
+		if v < 0 {
+			return nil, fmt.Errorf("negative value %d", v)
+		}
+		return uint64(v), nil
+	case string:
+		return strconv.ParseUint(v, 10, 64)
+	default:
+		return nil, fmt.Errorf("unexpected type %T", value)
+	}
+}
+
+// ParseStringSlice parses a configuration value as a list of strings.
+func ParseStringSlice(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case []string:
+		return v, nil
+	case []interface{}:
+		ss := make([]string, 0, len(v))
+		for _, elem := range v {
+			s, ok := elem.(string)
+			if !ok {
+				return nil, fmt.Errorf("unexpected list element type %T", elem)
+			}
+			ss = append(ss, s)
+		}
+		return ss, nil
+	case string:
+		return strings.Fields(v), nil
+	default:
+		return nil, fmt.Errorf("unexpected type %T", value)
+	}
+}
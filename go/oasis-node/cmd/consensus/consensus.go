@@ -118,5 +118,8 @@ func Register(parentCmd *cobra.Command) {
 	showTxCmd.Flags().AddFlagSet(cmdConsensus.TxFileFlags)
 	showTxCmd.Flags().AddFlagSet(cmdFlags.GenesisFileFlags)
 
+	registerIndexerCmds(consensusCmd)
+	registerMempoolCmds(consensusCmd)
+
 	parentCmd.AddCommand(consensusCmd)
 }
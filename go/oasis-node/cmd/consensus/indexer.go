@@ -0,0 +1,195 @@
+package consensus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+
+	"github.com/oasislabs/oasis-core/go/common/crypto/hash"
+	indexer "github.com/oasislabs/oasis-core/go/consensus/indexer/api"
+	cmdCommon "github.com/oasislabs/oasis-core/go/oasis-node/cmd/common"
+	cmdGrpc "github.com/oasislabs/oasis-core/go/oasis-node/cmd/common/grpc"
+)
+
+const (
+	// CfgTxHash configures the hash of the transaction to look up.
+	CfgTxHash = "indexer.tx_hash"
+	// CfgTxSigner configures the signer of the transactions to look up.
+	CfgTxSigner = "indexer.signer"
+	// CfgHeight configures the height of the block to look up.
+	CfgHeight = "indexer.height"
+	// CfgHeightFrom configures the first height (inclusive) to look up.
+	CfgHeightFrom = "indexer.from"
+	// CfgHeightTo configures the last height (inclusive) to look up.
+	CfgHeightTo = "indexer.to"
+)
+
+var (
+	getTxFlags        = flag.NewFlagSet("", flag.ContinueOnError)
+	getSignerTxsFlags = flag.NewFlagSet("", flag.ContinueOnError)
+	getBlockTxsFlags  = flag.NewFlagSet("", flag.ContinueOnError)
+
+	getTxCmd = &cobra.Command{
+		Use:   "get_tx",
+		Short: "Look up an indexed transaction by hash",
+		Run:   doGetTx,
+	}
+
+	getSignerTxsCmd = &cobra.Command{
+		Use:   "get_signer_txs",
+		Short: "List indexed transactions of a signer",
+		Run:   doGetSignerTxs,
+	}
+
+	getBlockTxsCmd = &cobra.Command{
+		Use:   "get_block_txs",
+		Short: "List indexed transactions and their results at a height",
+		Run:   doGetBlockTxs,
+	}
+)
+
+func doConnectIndexer(cmd *cobra.Command) (*grpc.ClientConn, indexer.Backend) {
+	conn, err := cmdGrpc.NewClient(cmd)
+	if err != nil {
+		logger.Error("failed to establish connection with node",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	client := indexer.NewIndexerClient(conn)
+	return conn, client
+}
+
+func printJSON(v interface{}) {
+	b, _ := json.Marshal(v)
+	fmt.Printf("%v\n", string(b))
+}
+
+func doGetTx(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	var txHash hash.Hash
+	if err := txHash.UnmarshalHex(viper.GetString(CfgTxHash)); err != nil {
+		logger.Error("failed to parse transaction hash",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	conn, client := doConnectIndexer(cmd)
+	defer conn.Close()
+
+	tx, err := client.GetTransactionByHash(context.Background(), txHash)
+	if err != nil {
+		logger.Error("failed to get transaction",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	printJSON(tx)
+}
+
+func doGetSignerTxs(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	req := indexer.GetTransactionsBySignerRequest{
+		From: viper.GetInt64(CfgHeightFrom),
+		To:   viper.GetInt64(CfgHeightTo),
+	}
+	if err := req.Signer.UnmarshalText([]byte(viper.GetString(CfgTxSigner))); err != nil {
+		logger.Error("failed to parse signer",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	conn, client := doConnectIndexer(cmd)
+	defer conn.Close()
+
+	ctx := context.Background()
+	if req.To == 0 {
+		lastHeight, err := client.GetLastHeight(ctx)
+		if err != nil {
+			logger.Error("failed to get last indexed height",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+		req.To = lastHeight
+		if req.To-req.From >= indexer.MaxHeightRange {
+			req.From = req.To - indexer.MaxHeightRange + 1
+		}
+	}
+
+	txs, err := client.GetTransactionsBySigner(ctx, &req)
+	if err != nil {
+		logger.Error("failed to get transactions",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	for _, tx := range txs {
+		printJSON(tx)
+	}
+}
+
+func doGetBlockTxs(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	conn, client := doConnectIndexer(cmd)
+	defer conn.Close()
+
+	txs, err := client.GetTransactionsWithResults(context.Background(), viper.GetInt64(CfgHeight))
+	if err != nil {
+		logger.Error("failed to get transactions",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	for _, tx := range txs {
+		printJSON(tx)
+	}
+}
+
+func registerIndexerCmds(parentCmd *cobra.Command) {
+	for _, v := range []*cobra.Command{
+		getTxCmd,
+		getSignerTxsCmd,
+		getBlockTxsCmd,
+	} {
+		parentCmd.AddCommand(v)
+	}
+
+	getTxCmd.Flags().AddFlagSet(getTxFlags)
+	getSignerTxsCmd.Flags().AddFlagSet(getSignerTxsFlags)
+	getBlockTxsCmd.Flags().AddFlagSet(getBlockTxsFlags)
+}
+
+func init() {
+	getTxFlags.String(CfgTxHash, "", "hash of the transaction")
+	_ = viper.BindPFlags(getTxFlags)
+	getTxFlags.AddFlagSet(cmdGrpc.ClientFlags)
+
+	getSignerTxsFlags.String(CfgTxSigner, "", "public key of the transaction signer")
+	getSignerTxsFlags.Int64(CfgHeightFrom, 1, "first height (inclusive)")
+	getSignerTxsFlags.Int64(CfgHeightTo, 0, "last height (inclusive, defaults to the last indexed height)")
+	_ = viper.BindPFlags(getSignerTxsFlags)
+	getSignerTxsFlags.AddFlagSet(cmdGrpc.ClientFlags)
+
+	getBlockTxsFlags.Int64(CfgHeight, 0, "block height")
+	_ = viper.BindPFlags(getBlockTxsFlags)
+	getBlockTxsFlags.AddFlagSet(cmdGrpc.ClientFlags)
+}
@@ -0,0 +1,176 @@
+package consensus
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasislabs/oasis-core/go/common/crypto/signature"
+	consensus "github.com/oasislabs/oasis-core/go/consensus/api"
+	"github.com/oasislabs/oasis-core/go/consensus/api/transaction"
+	cmdCommon "github.com/oasislabs/oasis-core/go/oasis-node/cmd/common"
+	cmdConsensus "github.com/oasislabs/oasis-core/go/oasis-node/cmd/common/consensus"
+	cmdFlags "github.com/oasislabs/oasis-core/go/oasis-node/cmd/common/flags"
+	cmdGrpc "github.com/oasislabs/oasis-core/go/oasis-node/cmd/common/grpc"
+	cmdSigner "github.com/oasislabs/oasis-core/go/oasis-node/cmd/common/signer"
+)
+
+const (
+	// CfgPendingSigner configures the signer of the pending transactions to list.
+	CfgPendingSigner = "pending.signer"
+
+	// CfgReplaceGasPrice configures the gas price used to estimate the fee
+	// of the replacement transaction.
+	CfgReplaceGasPrice = "replace.gas_price"
+	// CfgReplaceMaxFee configures the maximum fee of the replacement
+	// transaction.
+	CfgReplaceMaxFee = "replace.max_fee"
+)
+
+var (
+	listPendingTxsFlags = flag.NewFlagSet("", flag.ContinueOnError)
+	replaceTxFlags      = flag.NewFlagSet("", flag.ContinueOnError)
+
+	listPendingTxsCmd = &cobra.Command{
+		Use:   "list_pending_txs",
+		Short: "List transactions pending in the node's mempool",
+		Run:   doListPendingTxs,
+	}
+
+	replaceTxCmd = &cobra.Command{
+		Use:   "replace_tx",
+		Short: "Replace a pending transaction with a transaction with the same nonce and a higher estimated fee",
+		Run:   doReplaceTx,
+	}
+)
+
+func doListPendingTxs(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	var req consensus.GetUnconfirmedTransactionsRequest
+	if signerStr := viper.GetString(CfgPendingSigner); signerStr != "" {
+		var signer signature.PublicKey
+		if err := signer.UnmarshalText([]byte(signerStr)); err != nil {
+			logger.Error("failed to parse signer",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+		req.Signer = &signer
+	}
+
+	conn, client := doConnect(cmd)
+	defer conn.Close()
+
+	txs, err := client.GetUnconfirmedTransactions(context.Background(), &req)
+	if err != nil {
+		logger.Error("failed to get pending transactions",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	for _, tx := range txs {
+		printJSON(tx)
+	}
+}
+
+func doReplaceTx(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	cmdConsensus.InitGenesis()
+
+	sigTx := loadTx()
+	var tx transaction.Transaction
+	if err := sigTx.Open(&tx); err != nil {
+		logger.Error("failed to open transaction",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	entityDir, err := cmdSigner.CLIDirOrPwd()
+	if err != nil {
+		logger.Error("failed to retrieve signer dir",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	_, signer, err := cmdCommon.LoadEntity(cmdSigner.Backend(), entityDir)
+	if err != nil {
+		logger.Error("failed to load account entity",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	defer signer.Reset()
+
+	if !signer.Public().Equal(sigTx.Signature.PublicKey) {
+		logger.Error("transaction not signed by the account entity",
+			"signer", sigTx.Signature.PublicKey,
+			"entity", signer.Public(),
+		)
+		os.Exit(1)
+	}
+
+	pd, err := consensus.NewStaticPriceDiscovery(viper.GetUint64(CfgReplaceGasPrice))
+	if err != nil {
+		logger.Error("failed to create price discovery",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	conn, client := doConnect(cmd)
+	defer conn.Close()
+
+	// Estimate the fee so that it is higher than the fee of the pending
+	// transaction that will be replaced.
+	tx.Fee = nil
+	sm := consensus.NewSubmissionManager(client, pd, viper.GetUint64(CfgReplaceMaxFee))
+	if err = sm.SignAndReplaceTx(context.Background(), signer, &tx); err != nil {
+		logger.Error("failed to replace transaction",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	logger.Info("replaced pending transaction",
+		"nonce", tx.Nonce,
+		"fee", tx.Fee.Amount,
+	)
+}
+
+func registerMempoolCmds(parentCmd *cobra.Command) {
+	for _, v := range []*cobra.Command{
+		listPendingTxsCmd,
+		replaceTxCmd,
+	} {
+		parentCmd.AddCommand(v)
+	}
+
+	listPendingTxsCmd.Flags().AddFlagSet(listPendingTxsFlags)
+	replaceTxCmd.Flags().AddFlagSet(replaceTxFlags)
+}
+
+func init() {
+	listPendingTxsFlags.String(CfgPendingSigner, "", "only list transactions of the given signer")
+	_ = viper.BindPFlags(listPendingTxsFlags)
+	listPendingTxsFlags.AddFlagSet(cmdGrpc.ClientFlags)
+
+	replaceTxFlags.Uint64(CfgReplaceGasPrice, 1, "gas price used to estimate the fee")
+	replaceTxFlags.Uint64(CfgReplaceMaxFee, 0, "maximum fee (0 means no limit)")
+	_ = viper.BindPFlags(replaceTxFlags)
+	replaceTxFlags.AddFlagSet(cmdConsensus.TxFileFlags)
+	replaceTxFlags.AddFlagSet(cmdGrpc.ClientFlags)
+	replaceTxFlags.AddFlagSet(cmdFlags.DebugTestEntityFlags)
+	replaceTxFlags.AddFlagSet(cmdFlags.GenesisFileFlags)
+	replaceTxFlags.AddFlagSet(cmdSigner.Flags)
+	replaceTxFlags.AddFlagSet(cmdSigner.CLIFlags)
+}
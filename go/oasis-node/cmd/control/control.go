@@ -3,6 +3,7 @@ package control
 
 import (
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -18,8 +19,14 @@ import (
 	upgrade "github.com/oasislabs/oasis-core/go/upgrade/api"
 )
 
+const (
+	statusFormatPretty = "pretty"
+	statusFormatJSON   = "json"
+)
+
 var (
 	shutdownWait = false
+	statusFormat = statusFormatPretty
 
 	controlCmd = &cobra.Command{
 		Use:   "control",
@@ -27,15 +34,29 @@ var (
 	}
 
 	controlIsSyncedCmd = &cobra.Command{
-		Use:   "is-synced",
-		Short: "exit with 0 if the node completed initial syncing, 1 if not",
-		Run:   doIsSynced,
+		Use:        "is-synced",
+		Short:      "exit with 0 if the node completed initial syncing, 1 if not",
+		Deprecated: "use is-ready instead",
+		Run:        doIsSynced,
 	}
 
 	controlWaitSyncCmd = &cobra.Command{
-		Use:   "wait-sync",
-		Short: "wait for the node to complete initial syncing",
-		Run:   doWaitSync,
+		Use:        "wait-sync",
+		Short:      "wait for the node to complete initial syncing",
+		Deprecated: "use wait-ready instead",
+		Run:        doWaitSync,
+	}
+
+	controlIsReadyCmd = &cobra.Command{
+		Use:   "is-ready",
+		Short: "exit with 0 if all node services are ready, 1 if not",
+		Run:   doIsReady,
+	}
+
+	controlWaitReadyCmd = &cobra.Command{
+		Use:   "wait-ready",
+		Short: "wait for all node services to become ready",
+		Run:   doWaitReady,
 	}
 
 	controlShutdownCmd = &cobra.Command{
@@ -57,6 +78,18 @@ var (
 		Run:   doCancelUpgrade,
 	}
 
+	controlReloadConfigCmd = &cobra.Command{
+		Use:   "reload-config",
+		Short: "reload the reloadable subset of the node configuration",
+		Run:   doReloadConfig,
+	}
+
+	controlStatusCmd = &cobra.Command{
+		Use:   "status",
+		Short: "show node status",
+		Run:   doStatus,
+	}
+
 	logger = logging.GetLogger("cmd/control")
 )
 
@@ -118,6 +151,44 @@ func doWaitSync(cmd *cobra.Command, args []string) {
 	}
 }
 
+func doIsReady(cmd *cobra.Command, args []string) {
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	logger.Debug("querying ready status")
+
+	ready, err := client.IsReady(context.Background())
+	if err != nil {
+		logger.Error("failed to query ready status",
+			"err", err,
+		)
+		os.Exit(128)
+	}
+	if ready {
+		fmt.Println("node is ready")
+		os.Exit(0)
+	} else {
+		fmt.Println("node is not ready")
+		os.Exit(1)
+	}
+}
+
+func doWaitReady(cmd *cobra.Command, args []string) {
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	logger.Debug("waiting for ready status")
+
+	// Use background context to block until the result comes in.
+	err := client.WaitReady(context.Background())
+	if err != nil {
+		logger.Error("failed to wait for ready status",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+}
+
 func doShutdown(cmd *cobra.Command, args []string) {
 	conn, client := DoConnect(cmd)
 	defer conn.Close()
@@ -177,16 +248,144 @@ func doCancelUpgrade(cmd *cobra.Command, args []string) {
 	}
 }
 
+func doReloadConfig(cmd *cobra.Command, args []string) {
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	err := client.ReloadConfig(context.Background())
+	if err != nil {
+		logger.Error("failed to reload node configuration",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+}
+
+func doStatus(cmd *cobra.Command, args []string) {
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	logger.Debug("querying status")
+
+	status, err := client.GetStatus(context.Background())
+	if err != nil {
+		logger.Error("failed to query status",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	switch statusFormat {
+	case statusFormatPretty:
+		prettyPrintStatus(status)
+	case statusFormatJSON:
+		var b []byte
+		if b, err = json.MarshalIndent(status, "", "  "); err != nil {
+			logger.Error("failed to marshal status",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+		fmt.Printf("%s\n", b)
+	default:
+		logger.Error("unsupported output format",
+			"format", statusFormat,
+		)
+		os.Exit(1)
+	}
+}
+
+func prettyPrintStatus(status *control.Status) {
+	fmt.Printf("Software version: %s\n", status.SoftwareVersion)
+
+	fmt.Println("Identity:")
+	fmt.Printf("  Node:      %s\n", status.Identity.Node)
+	fmt.Printf("  P2P:       %s\n", status.Identity.P2P)
+	fmt.Printf("  Consensus: %s\n", status.Identity.Consensus)
+	fmt.Printf("  TLS:       %s\n", status.Identity.TLS)
+	if status.Identity.NextTLS != nil {
+		fmt.Printf("  Next TLS:  %s\n", status.Identity.NextTLS)
+	}
+
+	cs := status.Consensus
+	fmt.Println("Consensus:")
+	fmt.Printf("  Version:        %s (%s)\n", cs.ConsensusVersion, cs.Backend)
+	fmt.Printf("  Latest height:  %d\n", cs.LatestHeight)
+	fmt.Printf("  Latest hash:    %s\n", hex.EncodeToString(cs.LatestHash))
+	fmt.Printf("  Latest time:    %s\n", cs.LatestTime)
+	fmt.Printf("  Genesis height: %d\n", cs.GenesisHeight)
+	fmt.Printf("  Peers:          %d\n", len(cs.NodePeers))
+
+	fmt.Printf("Ready: %t\n", status.Readiness.Ready)
+	for _, svc := range status.Readiness.Services {
+		fmt.Printf("  %s: %t\n", svc.Name, svc.Ready)
+	}
+
+	rs := status.Registration
+	fmt.Println("Registration:")
+	if rs.Descriptor == nil {
+		fmt.Println("  Not registered")
+	} else {
+		fmt.Printf("  Last registration: %s\n", rs.LastRegistration)
+		fmt.Printf("  Entity:            %s\n", rs.Descriptor.EntityID)
+		fmt.Printf("  Roles:             %s\n", rs.Descriptor.Roles)
+		fmt.Printf("  Expiration epoch:  %d\n", rs.Descriptor.Expiration)
+	}
+
+	for id, rt := range status.Runtimes {
+		fmt.Printf("Runtime %s:\n", id)
+		fmt.Printf("  Latest round: %d\n", rt.LatestRound)
+		fmt.Printf("  Latest hash:  %s\n", rt.LatestHash)
+		if c := rt.Committee; c != nil {
+			fmt.Printf("  Committees (epoch %d):\n", c.Epoch)
+			fmt.Printf("    Executor:              %s\n", c.ExecutorRole)
+			fmt.Printf("    Transaction scheduler: %s\n", c.TransactionSchedulerRole)
+			fmt.Printf("    Merge:                 %s\n", c.MergeRole)
+			fmt.Printf("    Storage:               %s\n", c.StorageRole)
+		}
+		if rt.Storage != nil {
+			fmt.Printf("  Storage last synced round: %d\n", rt.Storage.LastSyncedRound)
+		}
+		if h := rt.Host; h != nil {
+			fmt.Printf("  Host state: %s\n", h.State)
+			if h.Version != nil {
+				fmt.Printf("  Host version: %s\n", h.Version)
+			}
+			if h.TEE != nil {
+				fmt.Printf("  TEE: %s (RAK: %s)\n", h.TEE.Hardware, h.TEE.RAK)
+			}
+			if h.Error != "" {
+				fmt.Printf("  Host error: %s\n", h.Error)
+			}
+		}
+	}
+
+	if km := status.Keymanager; km != nil {
+		fmt.Printf("Key manager %s:\n", km.RuntimeID)
+		fmt.Printf("  Initialized:  %t\n", km.Initialized)
+		fmt.Printf("  Secure:       %t\n", km.IsSecure)
+		fmt.Printf("  May generate: %t\n", km.MayGenerate)
+		if km.Initialized {
+			fmt.Printf("  Checksum:     %s\n", hex.EncodeToString(km.Checksum))
+		}
+	}
+}
+
 // Register registers the client sub-command and all of it's children.
 func Register(parentCmd *cobra.Command) {
 	controlCmd.PersistentFlags().AddFlagSet(cmdGrpc.ClientFlags)
 
 	controlShutdownCmd.Flags().BoolVarP(&shutdownWait, "wait", "w", false, "wait for the node to finish shutdown")
+	controlStatusCmd.Flags().StringVarP(&statusFormat, "format", "f", statusFormatPretty, "output format (pretty, json)")
 
 	controlCmd.AddCommand(controlIsSyncedCmd)
 	controlCmd.AddCommand(controlWaitSyncCmd)
+	controlCmd.AddCommand(controlIsReadyCmd)
+	controlCmd.AddCommand(controlWaitReadyCmd)
 	controlCmd.AddCommand(controlShutdownCmd)
 	controlCmd.AddCommand(controlUpgradeBinaryCmd)
 	controlCmd.AddCommand(controlCancelUpgradeCmd)
+	controlCmd.AddCommand(controlReloadConfigCmd)
+	controlCmd.AddCommand(controlStatusCmd)
 	parentCmd.AddCommand(controlCmd)
 }
@@ -0,0 +1,278 @@
+package storage
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasislabs/oasis-core/go/common"
+	cmdCommon "github.com/oasislabs/oasis-core/go/oasis-node/cmd/common"
+	registry "github.com/oasislabs/oasis-core/go/registry/api"
+	"github.com/oasislabs/oasis-core/go/roothash/api/block"
+	"github.com/oasislabs/oasis-core/go/runtime/history"
+	runtimeRegistry "github.com/oasislabs/oasis-core/go/runtime/registry"
+	storageAPI "github.com/oasislabs/oasis-core/go/storage/api"
+	"github.com/oasislabs/oasis-core/go/storage/mkvs"
+)
+
+const (
+	cfgRuntimeStateRuntimeID = "storage.runtime_state.runtime_id"
+	cfgRuntimeStateRound     = "storage.runtime_state.round"
+	cfgRuntimeStateOutput    = "storage.runtime_state.output"
+)
+
+var (
+	storageExportRuntimeCmd = &cobra.Command{
+		Use:   "export-runtime",
+		Short: "export a runtime's full state at a given round (default: latest round)",
+		Run:   doExportRuntime,
+	}
+
+	storageImportRuntimeCmd = &cobra.Command{
+		Use:   "import-runtime <state dump>",
+		Short: "convert an exported runtime state into a runtime genesis",
+		Args:  cobra.ExactArgs(1),
+		Run:   doImportRuntime,
+	}
+
+	storageRuntimeStateFlags = flag.NewFlagSet("", flag.ContinueOnError)
+)
+
+func doExportRuntime(cmd *cobra.Command, args []string) {
+	var ok bool
+	defer func() {
+		if !ok {
+			os.Exit(1)
+		}
+	}()
+
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	dataDir := cmdCommon.DataDir()
+	if dataDir == "" {
+		logger.Error("data directory must be set")
+		return
+	}
+
+	var id common.Namespace
+	if err := id.UnmarshalHex(viper.GetString(cfgRuntimeStateRuntimeID)); err != nil {
+		logger.Error("failed to decode runtime id",
+			"err", err,
+		)
+		return
+	}
+	rtDataDir := filepath.Join(dataDir, runtimeRegistry.RuntimesDir, id.String())
+
+	// Resolve the state root from the runtime's roothash block history.
+	var round *uint64
+	if cmd.Flags().Changed(cfgRuntimeStateRound) {
+		r := viper.GetUint64(cfgRuntimeStateRound)
+		round = &r
+	}
+	blk, err := getRuntimeBlock(rtDataDir, id, round)
+	if err != nil {
+		logger.Error("failed to get runtime block",
+			"err", err,
+		)
+		return
+	}
+	root := storageAPI.Root{
+		Namespace: id,
+		Version:   blk.Header.Round,
+		Hash:      blk.Header.StateRoot,
+	}
+
+	fn := viper.GetString(cfgRuntimeStateOutput)
+	if fn == "" {
+		fn = fmt.Sprintf("runtime-state-%v-%d.json", root.Namespace, root.Version)
+	}
+
+	// Initialize the storage backend.
+	storageBackend, err := newDirectStorageBackend(rtDataDir, id)
+	if err != nil {
+		logger.Error("failed to construct storage backend",
+			"err", err,
+		)
+		return
+	}
+
+	logger.Info("waiting for storage backend initialization")
+	<-storageBackend.Initialized()
+	defer storageBackend.Cleanup()
+
+	logger.Info("exporting runtime state",
+		"root", root,
+		"output", fn,
+	)
+
+	tree := mkvs.NewWithRoot(storageBackend, nil, root)
+	defer tree.Close()
+	it := tree.NewIterator(context.Background(), mkvs.IteratorPrefetch(10_000))
+	defer it.Close()
+
+	if err = exportIterator(fn, &root, it); err != nil {
+		return
+	}
+	if err = it.Err(); err != nil {
+		logger.Error("failed to iterate over runtime state",
+			"err", err,
+		)
+		return
+	}
+
+	ok = true
+}
+
+// getRuntimeBlock returns the runtime block at the given round (or the
+// latest block if round is nil) from the runtime's local block history.
+func getRuntimeBlock(dataDir string, id common.Namespace, round *uint64) (*block.Block, error) {
+	h, err := history.New(dataDir, id, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open runtime history: %w", err)
+	}
+	defer h.Close()
+
+	if round == nil {
+		return h.GetLatestBlock(context.Background())
+	}
+	return h.GetBlock(context.Background(), *round)
+}
+
+func doImportRuntime(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		logger.Error("failed to open state dump",
+			"err", err,
+			"fn", args[0],
+		)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	var id *common.Namespace
+	if idStr := viper.GetString(cfgRuntimeStateRuntimeID); idStr != "" {
+		id = new(common.Namespace)
+		if err = id.UnmarshalHex(idStr); err != nil {
+			logger.Error("failed to decode runtime id",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+	}
+	var round *uint64
+	if cmd.Flags().Changed(cfgRuntimeStateRound) {
+		r := viper.GetUint64(cfgRuntimeStateRound)
+		round = &r
+	}
+
+	rtg, err := importRuntimeState(bufio.NewReader(f), id, round)
+	if err != nil {
+		logger.Error("failed to import runtime state",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	out := os.Stdout
+	if fn := viper.GetString(cfgRuntimeStateOutput); fn != "" {
+		if out, err = os.Create(fn); err != nil {
+			logger.Error("failed to create runtime genesis file",
+				"err", err,
+				"fn", fn,
+			)
+			os.Exit(1)
+		}
+		defer out.Close()
+	}
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	if err = enc.Encode(rtg); err != nil {
+		logger.Error("failed to write runtime genesis",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+}
+
+// importRuntimeState reads a runtime state dump produced by exportIterator and
+// builds a runtime genesis containing the state and the corresponding state
+// root.
+//
+// Note: As node hashes include the version at which the node was created, the
+// resulting state root will generally differ from the exported state root even
+// though the state itself is the same.
+//
+// If id or round are non-nil, they override the runtime identifier and the
+// round of the exported state root, allowing the state to be imported as a
+// different runtime.
+func importRuntimeState(r io.Reader, id *common.Namespace, round *uint64) (*registry.RuntimeGenesis, error) {
+	dec := json.NewDecoder(r)
+
+	var root storageAPI.Root
+	if err := dec.Decode(&root); err != nil {
+		return nil, fmt.Errorf("failed to decode state root: %w", err)
+	}
+	if id != nil {
+		root.Namespace = *id
+	}
+	if round != nil {
+		root.Version = *round
+	}
+
+	ctx := context.Background()
+	tree := mkvs.New(nil, nil)
+	defer tree.Close()
+
+	var log storageAPI.WriteLog
+	for {
+		var entry [][]byte
+		err := dec.Decode(&entry)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode write log entry: %w", err)
+		}
+		if len(entry) != 2 {
+			return nil, fmt.Errorf("malformed write log entry")
+		}
+
+		if err = tree.Insert(ctx, entry[0], entry[1]); err != nil {
+			return nil, fmt.Errorf("failed to insert write log entry: %w", err)
+		}
+		log = append(log, storageAPI.LogEntry{Key: entry[0], Value: entry[1]})
+	}
+
+	_, stateRoot, err := tree.Commit(ctx, root.Namespace, root.Version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute state root: %w", err)
+	}
+
+	return &registry.RuntimeGenesis{
+		StateRoot: stateRoot,
+		State:     log,
+		Round:     root.Version,
+	}, nil
+}
+
+func init() {
+	storageRuntimeStateFlags.String(cfgRuntimeStateRuntimeID, "", "runtime identifier (hex), overrides the exported identifier on import")
+	storageRuntimeStateFlags.Uint64(cfgRuntimeStateRound, 0, "runtime round to export (default: latest round), overrides the exported round on import")
+	storageRuntimeStateFlags.String(cfgRuntimeStateOutput, "", "output file (default: state dump in the current directory on export, stdout on import)")
+	_ = viper.BindPFlags(storageRuntimeStateFlags)
+}
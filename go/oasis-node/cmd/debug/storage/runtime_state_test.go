@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasislabs/oasis-core/go/common"
+	storageAPI "github.com/oasislabs/oasis-core/go/storage/api"
+	"github.com/oasislabs/oasis-core/go/storage/mkvs"
+	"github.com/oasislabs/oasis-core/go/storage/mkvs/writelog"
+)
+
+func TestRuntimeStateExportImport(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	dir, err := ioutil.TempDir("", "oasis-runtime-state-test")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dir)
+
+	var srcID, dstID common.Namespace
+	_ = srcID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000000")
+	_ = dstID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000001")
+
+	// Build some runtime state.
+	tree := mkvs.New(nil, nil)
+	defer tree.Close()
+	for i := 0; i < 100; i++ {
+		err = tree.Insert(ctx, []byte(fmt.Sprintf("key %d", i)), []byte(fmt.Sprintf("value %d", i)))
+		require.NoError(err, "Insert")
+	}
+	_, rootHash, err := tree.Commit(ctx, srcID, 42)
+	require.NoError(err, "Commit")
+	root := storageAPI.Root{
+		Namespace: srcID,
+		Version:   42,
+		Hash:      rootHash,
+	}
+
+	// Export the state.
+	fn := filepath.Join(dir, "dump.json")
+	it := tree.NewIterator(ctx)
+	defer it.Close()
+	err = exportIterator(fn, &root, it)
+	require.NoError(err, "exportIterator")
+
+	// Import the state as a different runtime.
+	f, err := os.Open(fn)
+	require.NoError(err, "Open")
+	defer f.Close()
+
+	round := uint64(0)
+	rtg, err := importRuntimeState(f, &dstID, &round)
+	require.NoError(err, "importRuntimeState")
+	require.EqualValues(0, rtg.Round, "imported round should be overridden")
+	require.Len(rtg.State, 100, "imported state should contain all entries")
+	require.NoError(rtg.SanityCheck(false), "imported runtime genesis should be sane")
+
+	// The imported state root should match the state root of the same state
+	// committed at the new round.
+	newTree := mkvs.New(nil, nil)
+	defer newTree.Close()
+	err = newTree.ApplyWriteLog(ctx, writelog.NewStaticIterator(rtg.State))
+	require.NoError(err, "ApplyWriteLog")
+	_, newRootHash, err := newTree.Commit(ctx, dstID, 0)
+	require.NoError(err, "Commit")
+	require.EqualValues(newRootHash, rtg.StateRoot, "imported state root should be correct")
+
+	value, err := newTree.Get(ctx, []byte("key 42"))
+	require.NoError(err, "Get")
+	require.EqualValues([]byte("value 42"), value, "imported state should contain the exported values")
+}
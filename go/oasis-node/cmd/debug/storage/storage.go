@@ -284,11 +284,19 @@ func Register(parentCmd *cobra.Command) {
 	storageExportCmd.Flags().AddFlagSet(cmdFlags.DebugDontBlameOasisFlag)
 	storageExportCmd.Flags().AddFlagSet(storageExportFlags)
 
+	storageExportRuntimeCmd.Flags().AddFlagSet(storage.Flags)
+	storageExportRuntimeCmd.Flags().AddFlagSet(cmdFlags.DebugDontBlameOasisFlag)
+	storageExportRuntimeCmd.Flags().AddFlagSet(storageRuntimeStateFlags)
+
+	storageImportRuntimeCmd.Flags().AddFlagSet(storageRuntimeStateFlags)
+
 	storageBenchmarkCmd.Flags().AddFlagSet(storageBenchmarkFlags)
 
 	storageCmd.AddCommand(storageCheckRootsCmd)
 	storageCmd.AddCommand(storageForceFinalizeCmd)
 	storageCmd.AddCommand(storageExportCmd)
+	storageCmd.AddCommand(storageExportRuntimeCmd)
+	storageCmd.AddCommand(storageImportRuntimeCmd)
 	storageCmd.AddCommand(storageBenchmarkCmd)
 	parentCmd.AddCommand(storageCmd)
 }
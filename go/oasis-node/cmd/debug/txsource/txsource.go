@@ -94,13 +94,14 @@ func doRun(cmd *cobra.Command, args []string) error {
 	// Set up the consensus client.
 	cnsc := consensus.NewConsensusClient(conn)
 
-	// Wait for sync before transferring control to the workload.
+	// Wait for the node to become ready before transferring control to
+	// the workload.
 	ncc := api.NewNodeControllerClient(conn)
-	logger.Debug("waiting for node sync")
-	if err = ncc.WaitSync(context.Background()); err != nil {
-		return fmt.Errorf("node controller client WaitSync: %w", err)
+	logger.Debug("waiting for node to become ready")
+	if err = ncc.WaitReady(context.Background()); err != nil {
+		return fmt.Errorf("node controller client WaitReady: %w", err)
 	}
-	logger.Debug("node synced")
+	logger.Debug("node ready")
 
 	// Generate and fund the account that will be used for funding accounts
 	// during the workload.
@@ -214,7 +214,7 @@ func (q *queries) doRegistryQueries(ctx context.Context, rng *rand.Rand, height
 	}
 
 	// Runtimes.
-	runtimes, err := q.registry.GetRuntimes(ctx, height)
+	runtimes, err := q.registry.GetRuntimes(ctx, &registry.GetRuntimesQuery{Height: height})
 	if err != nil {
 		return fmt.Errorf("GetRuntimes error at height %d: %w", height, err)
 	}
@@ -66,13 +66,20 @@ const (
 	cfgSchedulerMaxValidatorsPerEntity = "scheduler.max_validators_per_entity"
 	cfgSchedulerDebugBypassStake       = "scheduler.debug.bypass_stake" // nolint: gosec
 	cfgSchedulerDebugStaticValidators  = "scheduler.debug.static_validators"
+	cfgSchedulerLivenessWindow         = "scheduler.validator_liveness.window"
+	cfgSchedulerLivenessMaxMissed      = "scheduler.validator_liveness.max_missed_percent"
+	cfgSchedulerJailEpochs             = "scheduler.validator_jail_epochs"
+	cfgSchedulerMaxPowerChange         = "scheduler.max_voting_power_change_percent"
 
 	// Beacon config flags.
-	cfgBeaconDebugDeterministic = "beacon.debug.deterministic"
+	cfgBeaconDebugDeterministic          = "beacon.debug.deterministic"
+	cfgBeaconCommitRevealEnabled         = "beacon.commit_reveal.enabled"
+	cfgBeaconCommitRevealMinParticipants = "beacon.commit_reveal.min_participants"
 
 	// EpochTime config flags.
 	cfgEpochTimeDebugMockBackend   = "epochtime.debug.mock_backend"
 	cfgEpochTimeTendermintInterval = "epochtime.tendermint.interval"
+	cfgEpochTimeTargetDuration     = "epochtime.tendermint.target_duration"
 
 	// Roothash config flags.
 	cfgRoothashDebugDoNotSuspendRuntimes = "roothash.debug.do_not_suspend_runtimes"
@@ -199,19 +206,32 @@ func doInitGenesis(cmd *cobra.Command, args []string) {
 			MaxValidatorsPerEntity: viper.GetInt(cfgSchedulerMaxValidatorsPerEntity),
 			DebugBypassStake:       viper.GetBool(cfgSchedulerDebugBypassStake),
 			DebugStaticValidators:  viper.GetBool(cfgSchedulerDebugStaticValidators),
+
+			ValidatorLivenessWindow:           viper.GetInt64(cfgSchedulerLivenessWindow),
+			ValidatorLivenessMaxMissedPercent: uint8(viper.GetUint(cfgSchedulerLivenessMaxMissed)),
+			ValidatorJailEpochs:               epochtime.EpochTime(viper.GetUint64(cfgSchedulerJailEpochs)),
+			MaxVotingPowerChangePercent:       uint8(viper.GetUint(cfgSchedulerMaxPowerChange)),
+			GasCosts:                          scheduler.DefaultGasCosts, // TODO: Make these configurable.
 		},
 	}
 
 	doc.Beacon = beacon.Genesis{
 		Parameters: beacon.ConsensusParameters{
 			DebugDeterministic: viper.GetBool(cfgBeaconDebugDeterministic),
+			GasCosts:           beacon.DefaultGasCosts, // TODO: Make these configurable.
 		},
 	}
+	if viper.GetBool(cfgBeaconCommitRevealEnabled) {
+		doc.Beacon.Parameters.CommitReveal = &beacon.CommitRevealParameters{
+			MinParticipants: viper.GetUint64(cfgBeaconCommitRevealMinParticipants),
+		}
+	}
 
 	doc.EpochTime = epochtime.Genesis{
 		Parameters: epochtime.ConsensusParameters{
 			DebugMockBackend: viper.GetBool(cfgEpochTimeDebugMockBackend),
 			Interval:         viper.GetInt64(cfgEpochTimeTendermintInterval),
+			TargetDuration:   viper.GetDuration(cfgEpochTimeTargetDuration),
 		},
 	}
 
@@ -700,16 +720,23 @@ func init() {
 	initGenesisFlags.Int(cfgSchedulerMaxValidatorsPerEntity, 1, "maximum number of validators per entity")
 	initGenesisFlags.Bool(cfgSchedulerDebugBypassStake, false, "bypass all stake checks and operations (UNSAFE)")
 	initGenesisFlags.Bool(cfgSchedulerDebugStaticValidators, false, "bypass all validator elections (UNSAFE)")
+	initGenesisFlags.Int64(cfgSchedulerLivenessWindow, 0, "validator liveness window in blocks (0 disables jailing)")
+	initGenesisFlags.Uint8(cfgSchedulerLivenessMaxMissed, 50, "maximum percentage of blocks a validator may miss in a liveness window")
+	initGenesisFlags.Uint64(cfgSchedulerJailEpochs, 1, "minimum number of epochs a jailed validator must wait before unjailing")
+	initGenesisFlags.Uint8(cfgSchedulerMaxPowerChange, 0, "maximum total voting power change per election in percent (0 disables the limit)")
 	_ = initGenesisFlags.MarkHidden(cfgSchedulerDebugBypassStake)
 	_ = initGenesisFlags.MarkHidden(cfgSchedulerDebugStaticValidators)
 
 	// Beacon config flags.
 	initGenesisFlags.Bool(cfgBeaconDebugDeterministic, false, "enable deterministic beacon output (UNSAFE)")
+	initGenesisFlags.Bool(cfgBeaconCommitRevealEnabled, false, "enable the commit-reveal beacon")
+	initGenesisFlags.Uint64(cfgBeaconCommitRevealMinParticipants, 3, "minimum number of reveals for a commit-reveal beacon")
 	_ = initGenesisFlags.MarkHidden(cfgBeaconDebugDeterministic)
 
 	// EpochTime config flags.
 	initGenesisFlags.Bool(cfgEpochTimeDebugMockBackend, false, "use debug mock Epoch time backend")
 	initGenesisFlags.Int64(cfgEpochTimeTendermintInterval, 86400, "Epoch interval (in blocks)")
+	initGenesisFlags.Duration(cfgEpochTimeTargetDuration, 0*time.Second, "target epoch duration (0 for fixed-interval epochs)")
 	_ = initGenesisFlags.MarkHidden(cfgEpochTimeDebugMockBackend)
 
 	// Roothash config flags.
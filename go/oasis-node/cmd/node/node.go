@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 	"sync/atomic"
 
 	"github.com/spf13/cobra"
@@ -16,11 +17,16 @@ import (
 	"github.com/oasislabs/oasis-core/go/common/crash"
 	"github.com/oasislabs/oasis-core/go/common/crypto/signature"
 	"github.com/oasislabs/oasis-core/go/common/grpc"
+	grpcGateway "github.com/oasislabs/oasis-core/go/common/grpc/gateway"
 	"github.com/oasislabs/oasis-core/go/common/identity"
 	"github.com/oasislabs/oasis-core/go/common/logging"
 	"github.com/oasislabs/oasis-core/go/common/persistent"
 	"github.com/oasislabs/oasis-core/go/common/service"
 	consensusAPI "github.com/oasislabs/oasis-core/go/consensus/api"
+	"github.com/oasislabs/oasis-core/go/consensus/exporter"
+	exporterAPI "github.com/oasislabs/oasis-core/go/consensus/exporter/api"
+	"github.com/oasislabs/oasis-core/go/consensus/indexer"
+	indexerAPI "github.com/oasislabs/oasis-core/go/consensus/indexer/api"
 	"github.com/oasislabs/oasis-core/go/consensus/tendermint"
 	tmService "github.com/oasislabs/oasis-core/go/consensus/tendermint/service"
 	tendermintTestsGenesis "github.com/oasislabs/oasis-core/go/consensus/tendermint/tests/genesis"
@@ -33,16 +39,20 @@ import (
 	"github.com/oasislabs/oasis-core/go/ias"
 	iasAPI "github.com/oasislabs/oasis-core/go/ias/api"
 	keymanagerAPI "github.com/oasislabs/oasis-core/go/keymanager/api"
+	keymanagerClient "github.com/oasislabs/oasis-core/go/keymanager/client"
 	cmdCommon "github.com/oasislabs/oasis-core/go/oasis-node/cmd/common"
 	"github.com/oasislabs/oasis-core/go/oasis-node/cmd/common/background"
 	"github.com/oasislabs/oasis-core/go/oasis-node/cmd/common/flags"
+	"github.com/oasislabs/oasis-core/go/oasis-node/cmd/common/gateway"
 	cmdGrpc "github.com/oasislabs/oasis-core/go/oasis-node/cmd/common/grpc"
+	"github.com/oasislabs/oasis-core/go/oasis-node/cmd/common/health"
 	"github.com/oasislabs/oasis-core/go/oasis-node/cmd/common/metrics"
 	"github.com/oasislabs/oasis-core/go/oasis-node/cmd/common/pprof"
 	cmdSigner "github.com/oasislabs/oasis-core/go/oasis-node/cmd/common/signer"
 	"github.com/oasislabs/oasis-core/go/oasis-node/cmd/common/tracing"
 	"github.com/oasislabs/oasis-core/go/oasis-node/cmd/debug/supplementarysanity"
 	registryAPI "github.com/oasislabs/oasis-core/go/registry/api"
+	roothashAPI "github.com/oasislabs/oasis-core/go/roothash/api"
 	runtimeClient "github.com/oasislabs/oasis-core/go/runtime/client"
 	runtimeClientAPI "github.com/oasislabs/oasis-core/go/runtime/client/api"
 	enclaverpc "github.com/oasislabs/oasis-core/go/runtime/enclaverpc/api"
@@ -69,8 +79,6 @@ import (
 )
 
 var (
-	_ controlAPI.Shutdownable = (*Node)(nil)
-
 	// Flags has the configuration flags.
 	Flags = flag.NewFlagSet("", flag.ContinueOnError)
 )
@@ -99,9 +107,12 @@ type Node struct {
 	grpcInternal *grpc.Server
 	svcTmnt      tmService.TendermintService
 	svcTmntSeed  *tendermint.SeedService
+	gateway      *grpcGateway.Gateway
 
 	stopping uint32
 
+	reloadLock sync.Mutex
+
 	commonStore *persistent.CommonStore
 
 	NodeController  controlAPI.NodeController
@@ -155,17 +166,6 @@ func (n *Node) Wait() {
 	n.svcMgr.Wait()
 }
 
-func (n *Node) RequestShutdown() (<-chan struct{}, error) {
-	if err := n.RegistrationWorker.RequestDeregistration(); err != nil {
-		return nil, err
-	}
-	// This returns only the registration worker's event channel,
-	// otherwise the caller (usually the control grpc server) will only
-	// get notified once everything is already torn down - perhaps
-	// including the server.
-	return n.RegistrationWorker.Quit(), nil
-}
-
 func (n *Node) RegistrationStopped() {
 	n.Stop()
 }
@@ -190,6 +190,43 @@ func (n *Node) initBackends() error {
 	stakingAPI.RegisterService(grpcSrv, n.Consensus.Staking())
 	keymanagerAPI.RegisterService(grpcSrv, n.Consensus.KeyManager())
 	consensusAPI.RegisterService(grpcSrv, n.Consensus)
+	roothashAPI.RegisterService(grpcSrv, n.Consensus.RootHash())
+
+	// Register the public read-only services with the JSON/HTTP gateway.
+	scheduler.RegisterService(n.gateway, n.Consensus.Scheduler())
+	registryAPI.RegisterService(n.gateway, n.Consensus.Registry())
+	stakingAPI.RegisterService(n.gateway, n.Consensus.Staking())
+	consensusAPI.RegisterService(n.gateway, n.Consensus)
+	roothashAPI.RegisterService(n.gateway, n.Consensus.RootHash())
+	gateway.RegisterErrorStatuses(n.gateway)
+
+	// Initialize and start the consensus event exporter if enabled.
+	if exporter.Enabled() {
+		var exporterSvc *exporter.Service
+		if exporterSvc, err = exporter.New(cmdCommon.DataDir(), n.Consensus); err != nil {
+			return err
+		}
+		n.svcMgr.Register(exporterSvc)
+		exporterAPI.RegisterService(grpcSrv, exporterSvc)
+		exporterAPI.RegisterService(n.gateway, exporterSvc)
+		if err = exporterSvc.Start(); err != nil {
+			return err
+		}
+	}
+
+	// Initialize and start the consensus transaction indexer if enabled.
+	if indexer.Enabled() {
+		var indexerSvc *indexer.Service
+		if indexerSvc, err = indexer.New(cmdCommon.DataDir(), n.Consensus); err != nil {
+			return err
+		}
+		n.svcMgr.Register(indexerSvc)
+		indexerAPI.RegisterService(grpcSrv, indexerSvc)
+		indexerAPI.RegisterService(n.gateway, indexerSvc)
+		if err = indexerSvc.Start(); err != nil {
+			return err
+		}
+	}
 
 	cmdCommon.Logger().Debug("backends initialized")
 
@@ -504,6 +541,8 @@ func newNode(testNode bool) (*Node, error) { // nolint: gocyclo
 		return nil, err
 	}
 
+	node.gateway = grpcGateway.New(gateway.Config())
+
 	dataDir := cmdCommon.DataDir()
 	if dataDir == "" {
 		logger.Error("data directory not configured")
@@ -692,6 +731,9 @@ func newNode(testNode bool) (*Node, error) { // nolint: gocyclo
 			return nil, err
 		}
 
+		// Reload the configuration on SIGHUP.
+		go node.reloadConfigOnSighup()
+
 		startOk = true
 
 		return node, nil
@@ -719,6 +761,7 @@ func newNode(testNode bool) (*Node, error) { // nolint: gocyclo
 	}
 	node.svcMgr.RegisterCleanupOnly(node.RuntimeClient, "client service")
 	runtimeClientAPI.RegisterService(node.grpcInternal.Server(), node.RuntimeClient)
+	runtimeClientAPI.RegisterService(node.gateway, node.RuntimeClient)
 	enclaverpc.RegisterService(node.grpcInternal.Server(), node.RuntimeClient)
 
 	// Start metric server.
@@ -754,6 +797,41 @@ func newNode(testNode bool) (*Node, error) { // nolint: gocyclo
 		controlAPI.RegisterDebugService(node.grpcInternal.Server(), node.DebugController)
 	}
 
+	// Reload the configuration on SIGHUP.
+	go node.reloadConfigOnSighup()
+
+	// Initialize and start the health check server.
+	healthSvc, err := health.New(node.svcMgr.Ctx, node)
+	if err != nil {
+		logger.Error("failed to initialize health server",
+			"err", err,
+		)
+		return nil, err
+	}
+	node.svcMgr.Register(healthSvc)
+	if err = healthSvc.Start(); err != nil {
+		logger.Error("failed to start health server",
+			"err", err,
+		)
+		return nil, err
+	}
+
+	// Initialize and start the JSON/HTTP gateway server.
+	gatewaySvc, err := gateway.New(node.svcMgr.Ctx, node.gateway)
+	if err != nil {
+		logger.Error("failed to initialize gateway server",
+			"err", err,
+		)
+		return nil, err
+	}
+	node.svcMgr.Register(gatewaySvc)
+	if err = gatewaySvc.Start(); err != nil {
+		logger.Error("failed to start gateway server",
+			"err", err,
+		)
+		return nil, err
+	}
+
 	// Start the tendermint service.
 	//
 	// Note: This will only start the node if it is required by
@@ -800,12 +878,18 @@ func init() {
 		cmdGrpc.ServerLocalFlags,
 		cmdSigner.Flags,
 		pprof.Flags,
+		health.Flags,
+		gateway.Flags,
 		storage.Flags,
 		supplementarysanity.Flags,
 		tendermint.Flags,
+		exporter.Flags,
+		indexer.Flags,
 		ias.Flags,
 		workerKeymanager.Flags,
 		runtimeRegistry.Flags,
+		runtimeClient.Flags,
+		keymanagerClient.Flags,
 		compute.Flags,
 		p2p.Flags,
 		registration.Flags,
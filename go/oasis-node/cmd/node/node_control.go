@@ -0,0 +1,244 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+
+	"github.com/oasislabs/oasis-core/go/common"
+	"github.com/oasislabs/oasis-core/go/common/node"
+	"github.com/oasislabs/oasis-core/go/consensus/tendermint"
+	controlAPI "github.com/oasislabs/oasis-core/go/control/api"
+	cmdCommon "github.com/oasislabs/oasis-core/go/oasis-node/cmd/common"
+	workerCommon "github.com/oasislabs/oasis-core/go/worker/common"
+)
+
+// reloadableConfigKeys are the configuration keys, in addition to the log
+// levels, that can be changed without restarting the node.
+var reloadableConfigKeys = []cmdCommon.ReloadableKey{
+	{
+		Key:   tendermint.CfgConsensusMinGasPrice,
+		Parse: cmdCommon.ParseUint64,
+	},
+	{
+		Key:   workerCommon.CfgSentryAddresses,
+		Parse: parseSentryAddresses,
+	},
+}
+
+func parseSentryAddresses(value interface{}) (interface{}, error) {
+	addrs, err := cmdCommon.ParseStringSlice(value)
+	if err != nil {
+		return nil, err
+	}
+	return workerCommon.ParseSentryAddressList(addrs.([]string))
+}
+
+var _ controlAPI.ControlledNode = (*Node)(nil)
+
+// RequestShutdown implements controlAPI.ControlledNode.
+func (n *Node) RequestShutdown() (<-chan struct{}, error) {
+	if err := n.RegistrationWorker.RequestDeregistration(); err != nil {
+		return nil, err
+	}
+	// This returns only the registration worker's event channel,
+	// otherwise the caller (usually the control grpc server) will only
+	// get notified once everything is already torn down - perhaps
+	// including the server.
+	return n.RegistrationWorker.Quit(), nil
+}
+
+// GetReadiness implements controlAPI.ControlledNode.
+func (n *Node) GetReadiness(ctx context.Context) (*controlAPI.ReadinessStatus, error) {
+	isClosed := func(ch <-chan struct{}) bool {
+		select {
+		case <-ch:
+			return true
+		default:
+			return false
+		}
+	}
+
+	services := []controlAPI.ServiceReadiness{
+		{
+			Name:  "consensus",
+			Ready: isClosed(n.Consensus.Synced()),
+		},
+	}
+
+	if n.RegistrationWorker != nil && !n.RegistrationWorker.WillNeverRegister() {
+		services = append(services, controlAPI.ServiceReadiness{
+			Name:  "registration",
+			Ready: isClosed(n.RegistrationWorker.InitialRegistrationCh()),
+		})
+	}
+
+	if n.CommonWorker != nil {
+		var ids []common.Namespace
+		for id := range n.CommonWorker.GetRuntimes() {
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool {
+			return bytes.Compare(ids[i][:], ids[j][:]) < 0
+		})
+
+		for _, id := range ids {
+			if n.StorageWorker != nil {
+				if srt := n.StorageWorker.GetRuntime(id); srt != nil {
+					services = append(services, controlAPI.ServiceReadiness{
+						Name:  fmt.Sprintf("runtime/%s/storage", id),
+						Ready: isClosed(srt.Initialized()),
+					})
+				}
+			}
+			if n.ExecutorWorker != nil {
+				if ert := n.ExecutorWorker.GetRuntime(id); ert != nil {
+					hs := ert.GetHostedRuntimeStatus()
+					services = append(services, controlAPI.ServiceReadiness{
+						Name:  fmt.Sprintf("runtime/%s/host", id),
+						Ready: hs != nil && hs.State == controlAPI.RuntimeHostStateStarted,
+					})
+				}
+			}
+		}
+	}
+
+	kms, err := n.GetKeymanagerStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if kms != nil {
+		services = append(services, controlAPI.ServiceReadiness{
+			Name:  "keymanager",
+			Ready: kms.Initialized,
+		})
+	}
+
+	return controlAPI.NewReadinessStatus(services), nil
+}
+
+// GetIdentity implements controlAPI.ControlledNode.
+func (n *Node) GetIdentity() controlAPI.IdentityStatus {
+	status := controlAPI.IdentityStatus{
+		Node:      n.Identity.NodeSigner.Public(),
+		P2P:       n.Identity.P2PSigner.Public(),
+		Consensus: n.Identity.ConsensusSigner.Public(),
+		TLS:       n.Identity.GetTLSSigner().Public(),
+	}
+	if s := n.Identity.GetNextTLSSigner(); s != nil {
+		nextTLS := s.Public()
+		status.NextTLS = &nextTLS
+	}
+	return status
+}
+
+// GetRegistrationStatus implements controlAPI.ControlledNode.
+func (n *Node) GetRegistrationStatus(ctx context.Context) (*controlAPI.RegistrationStatus, error) {
+	if n.RegistrationWorker == nil {
+		return &controlAPI.RegistrationStatus{}, nil
+	}
+	return n.RegistrationWorker.GetRegistrationStatus(ctx)
+}
+
+// GetRuntimeStatus implements controlAPI.ControlledNode.
+func (n *Node) GetRuntimeStatus(ctx context.Context) (map[common.Namespace]controlAPI.RuntimeStatus, error) {
+	runtimes := make(map[common.Namespace]controlAPI.RuntimeStatus)
+	if n.CommonWorker == nil {
+		return runtimes, nil
+	}
+
+	for id, rt := range n.CommonWorker.GetRuntimes() {
+		status, err := rt.GetStatus(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		// Storage worker status.
+		if n.StorageWorker != nil {
+			if srt := n.StorageWorker.GetRuntime(id); srt != nil {
+				round, _, _ := srt.GetLastSynced()
+				status.Storage = &controlAPI.StorageStatus{
+					LastSyncedRound: round,
+				}
+			}
+		}
+
+		// Hosted runtime status.
+		if n.ExecutorWorker != nil {
+			if ert := n.ExecutorWorker.GetRuntime(id); ert != nil {
+				status.Host = ert.GetHostedRuntimeStatus()
+			}
+		}
+		if status.Host == nil && n.TransactionSchedulerWorker != nil {
+			if trt := n.TransactionSchedulerWorker.GetRuntime(id); trt != nil {
+				status.Host = trt.GetHostedRuntimeStatus()
+			}
+		}
+
+		runtimes[id] = *status
+	}
+	return runtimes, nil
+}
+
+// GetKeymanagerStatus implements controlAPI.ControlledNode.
+func (n *Node) GetKeymanagerStatus(ctx context.Context) (*controlAPI.KeymanagerStatus, error) {
+	if n.KeymanagerWorker == nil || !n.KeymanagerWorker.Enabled() {
+		return nil, nil
+	}
+	return n.KeymanagerWorker.GetStatus(ctx)
+}
+
+// ReloadConfig implements controlAPI.ControlledNode.
+func (n *Node) ReloadConfig(ctx context.Context) error {
+	n.reloadLock.Lock()
+	defer n.reloadLock.Unlock()
+
+	changed, err := cmdCommon.ReloadConfig(reloadableConfigKeys...)
+	if err != nil {
+		return err
+	}
+
+	for key, value := range changed {
+		switch key {
+		case tendermint.CfgConsensusMinGasPrice:
+			if n.svcTmnt == nil {
+				continue
+			}
+			if err = n.svcTmnt.SetMinGasPrice(value.(uint64)); err != nil {
+				return fmt.Errorf("failed to reload consensus configuration: %w", err)
+			}
+		case workerCommon.CfgSentryAddresses:
+			if n.CommonWorker == nil {
+				continue
+			}
+			sentryAddrs := value.([]node.TLSAddress)
+			n.CommonWorker.SetSentryAddresses(sentryAddrs)
+			n.RegistrationWorker.SetSentryAddresses(sentryAddrs)
+		}
+	}
+
+	return nil
+}
+
+func (n *Node) reloadConfigOnSighup() {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGHUP)
+	defer signal.Stop(sigCh)
+
+	for {
+		select {
+		case <-sigCh:
+			if err := n.ReloadConfig(n.svcMgr.Ctx); err != nil {
+				cmdCommon.Logger().Error("failed to reload configuration",
+					"err", err,
+				)
+			}
+		case <-n.svcMgr.Ctx.Done():
+			return
+		}
+	}
+}
@@ -33,6 +33,12 @@ const (
 	CfgNodeDescriptor         = "entity.node.descriptor"
 	CfgReuseSigner            = "entity.reuse_signer"
 
+	CfgMetadataSerial  = "entity.metadata.serial"
+	CfgMetadataName    = "entity.metadata.name"
+	CfgMetadataURL     = "entity.metadata.url"
+	CfgMetadataEmail   = "entity.metadata.email"
+	CfgMetadataKeybase = "entity.metadata.keybase"
+
 	entityGenesisFilename = "entity_genesis.json"
 )
 
@@ -41,6 +47,7 @@ var (
 	initFlags                 = flag.NewFlagSet("", flag.ContinueOnError)
 	updateFlags               = flag.NewFlagSet("", flag.ContinueOnError)
 	registerOrDeregisterFlags = flag.NewFlagSet("", flag.ContinueOnError)
+	metadataFlags             = flag.NewFlagSet("", flag.ContinueOnError)
 
 	entityCmd = &cobra.Command{
 		Use:   "entity",
@@ -71,6 +78,12 @@ var (
 		Run:   doGenDeregister,
 	}
 
+	updateMetadataCmd = &cobra.Command{
+		Use:   "update-metadata",
+		Short: "generate a set entity metadata transaction",
+		Run:   doUpdateMetadata,
+	}
+
 	listCmd = &cobra.Command{
 		Use:   "list",
 		Short: "list registered entities",
@@ -322,6 +335,59 @@ func doGenDeregister(cmd *cobra.Command, args []string) {
 	cmdConsensus.SignAndSaveTx(tx)
 }
 
+func doUpdateMetadata(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	cmdConsensus.InitGenesis()
+	cmdConsensus.AssertTxFileOK()
+
+	entityDir, err := cmdSigner.CLIDirOrPwd()
+	if err != nil {
+		logger.Error("failed to retrieve entity dir",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	_, signer, err := cmdCommon.LoadEntity(cmdSigner.Backend(), entityDir)
+	if err != nil {
+		logger.Error("failed to load entity",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	defer signer.Reset()
+
+	metadata := &registry.EntityMetadata{
+		Serial:  viper.GetUint64(CfgMetadataSerial),
+		Name:    viper.GetString(CfgMetadataName),
+		URL:     viper.GetString(CfgMetadataURL),
+		Email:   viper.GetString(CfgMetadataEmail),
+		Keybase: viper.GetString(CfgMetadataKeybase),
+	}
+	if err = metadata.ValidateBasic(); err != nil {
+		logger.Error("invalid entity metadata",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	signed, err := registry.SignEntityMetadata(signer, metadata)
+	if err != nil {
+		logger.Error("failed to sign entity metadata",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	nonce, fee := cmdConsensus.GetTxNonceAndFee()
+	tx := registry.NewSetEntityMetadataTx(nonce, fee, signed)
+
+	cmdConsensus.SignAndSaveTx(tx)
+}
+
 func doList(cmd *cobra.Command, args []string) {
 	if err := cmdCommon.Init(); err != nil {
 		cmdCommon.EarlyLogAndExit(err)
@@ -400,6 +466,7 @@ func Register(parentCmd *cobra.Command) {
 		updateCmd,
 		registerCmd,
 		deregisterCmd,
+		updateMetadataCmd,
 		listCmd,
 	} {
 		entityCmd.AddCommand(v)
@@ -409,6 +476,7 @@ func Register(parentCmd *cobra.Command) {
 	updateCmd.Flags().AddFlagSet(updateFlags)
 	registerCmd.Flags().AddFlagSet(registerOrDeregisterFlags)
 	deregisterCmd.Flags().AddFlagSet(registerOrDeregisterFlags)
+	updateMetadataCmd.Flags().AddFlagSet(metadataFlags)
 
 	listCmd.Flags().AddFlagSet(cmdFlags.VerboseFlags)
 	listCmd.Flags().AddFlagSet(cmdGrpc.ClientFlags)
@@ -439,4 +507,12 @@ func init() {
 
 	registerOrDeregisterFlags.AddFlagSet(cmdFlags.DebugTestEntityFlags)
 	registerOrDeregisterFlags.AddFlagSet(cmdConsensus.TxFlags)
+
+	metadataFlags.Uint64(CfgMetadataSerial, 1, "Metadata serial number (must be increased on each update)")
+	metadataFlags.String(CfgMetadataName, "", "Entity operator name")
+	metadataFlags.String(CfgMetadataURL, "", "Entity operator website (HTTPS URL)")
+	metadataFlags.String(CfgMetadataEmail, "", "Entity operator contact email")
+	metadataFlags.String(CfgMetadataKeybase, "", "Entity operator keybase handle")
+	_ = viper.BindPFlags(metadataFlags)
+	metadataFlags.AddFlagSet(registerOrDeregisterFlags)
 }
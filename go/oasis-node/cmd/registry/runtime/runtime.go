@@ -8,6 +8,8 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -21,6 +23,7 @@ import (
 	"github.com/oasislabs/oasis-core/go/common/crypto/signature"
 	"github.com/oasislabs/oasis-core/go/common/logging"
 	"github.com/oasislabs/oasis-core/go/common/node"
+	"github.com/oasislabs/oasis-core/go/common/quantity"
 	"github.com/oasislabs/oasis-core/go/common/sgx"
 	"github.com/oasislabs/oasis-core/go/common/version"
 	consensus "github.com/oasislabs/oasis-core/go/consensus/api"
@@ -45,11 +48,18 @@ const (
 	CfgVersion        = "runtime.version"
 	CfgVersionEnclave = "runtime.version.enclave"
 
+	// CfgNewOwner is the flag for the new runtime owner in ownership transfers.
+	CfgNewOwner = "runtime.new_owner"
+	// CfgIncludeSuspended is the flag for including suspended runtimes when listing.
+	CfgIncludeSuspended = "include_suspended"
+
 	// Executor committee flags.
 	CfgExecutorGroupSize         = "runtime.executor.group_size"
 	CfgExecutorGroupBackupSize   = "runtime.executor.group_backup_size"
 	CfgExecutorAllowedStragglers = "runtime.executor.allowed_stragglers"
 	CfgExecutorRoundTimeout      = "runtime.executor.round_timeout"
+	CfgExecutorElectionMode      = "runtime.executor.election_mode"
+	CfgExecutorMaxNodesPerEntity = "runtime.executor.max_nodes_per_entity"
 
 	// Merge committee flags.
 	CfgMergeGroupSize         = "runtime.merge.group_size"
@@ -66,6 +76,8 @@ const (
 	CfgStorageCheckpointInterval      = "runtime.storage.checkpoint_interval"
 	CfgStorageCheckpointNumKept       = "runtime.storage.checkpoint_num_kept"
 	CfgStorageCheckpointChunkSize     = "runtime.storage.checkpoint_chunk_size"
+	CfgStorageElectionMode            = "runtime.storage.election_mode"
+	CfgStorageMaxNodesPerEntity       = "runtime.storage.max_nodes_per_entity"
 
 	// Transaction scheduler flags.
 	CfgTxnSchedulerGroupSize         = "runtime.txn_scheduler.group_size"
@@ -75,18 +87,26 @@ const (
 	CfgTxnSchedulerMaxBatchSizeBytes = "runtime.txn_scheduler.batching.max_batch_size_bytes"
 
 	// Admission policy flags.
-	CfgAdmissionPolicy                 = "runtime.admission_policy"
-	CfgAdmissionPolicyEntityWhitelist  = "runtime.admission_policy_entity_whitelist"
-	AdmissionPolicyNameAnyNode         = "any-node"
-	AdmissionPolicyNameEntityWhitelist = "entity-whitelist"
+	CfgAdmissionPolicy                    = "runtime.admission_policy"
+	CfgAdmissionPolicyEntityWhitelist     = "runtime.admission_policy_entity_whitelist"
+	CfgAdmissionPolicyMinEscrow           = "runtime.admission_policy_min_escrow"
+	CfgAdmissionPolicyPerEntityNodeLimit  = "runtime.admission_policy_per_entity_node_limit"
+	AdmissionPolicyNameAnyNode            = "any-node"
+	AdmissionPolicyNameEntityWhitelist    = "entity-whitelist"
+	AdmissionPolicyNameMinEscrow          = "min-escrow"
+	AdmissionPolicyNamePerEntityNodeLimit = "per-entity-node-limit"
 
 	runtimeGenesisFilename = "runtime_genesis.json"
 )
 
 var (
-	outputFlags   = flag.NewFlagSet("", flag.ContinueOnError)
-	runtimeFlags  = flag.NewFlagSet("", flag.ContinueOnError)
-	registerFlags = flag.NewFlagSet("", flag.ContinueOnError)
+	outputFlags    = flag.NewFlagSet("", flag.ContinueOnError)
+	runtimeIDFlags = flag.NewFlagSet("", flag.ContinueOnError)
+	runtimeFlags   = flag.NewFlagSet("", flag.ContinueOnError)
+	registerFlags  = flag.NewFlagSet("", flag.ContinueOnError)
+	lifecycleFlags = flag.NewFlagSet("", flag.ContinueOnError)
+	transferFlags  = flag.NewFlagSet("", flag.ContinueOnError)
+	listFlags      = flag.NewFlagSet("", flag.ContinueOnError)
 
 	runtimeCmd = &cobra.Command{
 		Use:   "runtime",
@@ -105,6 +125,30 @@ var (
 		Run:   doGenRegister,
 	}
 
+	suspendCmd = &cobra.Command{
+		Use:   "gen_suspend",
+		Short: "generate a suspend runtime transaction",
+		Run:   doGenSuspend,
+	}
+
+	resumeCmd = &cobra.Command{
+		Use:   "gen_resume",
+		Short: "generate a resume runtime transaction",
+		Run:   doGenResume,
+	}
+
+	transferOwnershipCmd = &cobra.Command{
+		Use:   "gen_transfer_ownership",
+		Short: "generate a transfer runtime ownership transaction",
+		Run:   doGenTransferOwnership,
+	}
+
+	acceptOwnershipCmd = &cobra.Command{
+		Use:   "gen_accept_ownership",
+		Short: "generate an accept runtime ownership transaction",
+		Run:   doGenAcceptOwnership,
+	}
+
 	listCmd = &cobra.Command{
 		Use:   "list",
 		Short: "list registered runtimes",
@@ -202,7 +246,11 @@ func doList(cmd *cobra.Command, args []string) {
 	conn, client := doConnect(cmd)
 	defer conn.Close()
 
-	runtimes, err := client.GetRuntimes(context.Background(), consensus.HeightLatest)
+	query := &registry.GetRuntimesQuery{
+		Height:           consensus.HeightLatest,
+		IncludeSuspended: viper.GetBool(CfgIncludeSuspended),
+	}
+	runtimes, err := client.GetRuntimes(context.Background(), query)
 	if err != nil {
 		logger.Error("failed to query runtimes",
 			"err", err,
@@ -224,12 +272,121 @@ func doList(cmd *cobra.Command, args []string) {
 	}
 }
 
-func runtimeFromFlags() (*registry.Runtime, signature.Signer, error) {
+func doGenSuspend(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	cmdConsensus.InitGenesis()
+	cmdConsensus.AssertTxFileOK()
+
+	id, err := runtimeIDFromFlags()
+	if err != nil {
+		os.Exit(1)
+	}
+
+	nonce, fee := cmdConsensus.GetTxNonceAndFee()
+	tx := registry.NewSuspendRuntimeTx(nonce, fee, &registry.SuspendRuntime{RuntimeID: id})
+
+	cmdConsensus.SignAndSaveTx(tx)
+}
+
+func doGenResume(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	cmdConsensus.InitGenesis()
+	cmdConsensus.AssertTxFileOK()
+
+	id, err := runtimeIDFromFlags()
+	if err != nil {
+		os.Exit(1)
+	}
+
+	nonce, fee := cmdConsensus.GetTxNonceAndFee()
+	tx := registry.NewResumeRuntimeTx(nonce, fee, &registry.ResumeRuntime{RuntimeID: id})
+
+	cmdConsensus.SignAndSaveTx(tx)
+}
+
+func doGenTransferOwnership(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	cmdConsensus.InitGenesis()
+	cmdConsensus.AssertTxFileOK()
+
+	id, err := runtimeIDFromFlags()
+	if err != nil {
+		os.Exit(1)
+	}
+
+	var newOwner signature.PublicKey
+	if err = newOwner.UnmarshalText([]byte(viper.GetString(CfgNewOwner))); err != nil {
+		logger.Error("failed to parse new owner",
+			"err", err,
+			CfgNewOwner, viper.GetString(CfgNewOwner),
+		)
+		os.Exit(1)
+	}
+
+	nonce, fee := cmdConsensus.GetTxNonceAndFee()
+	tx := registry.NewTransferRuntimeOwnershipTx(nonce, fee, &registry.TransferRuntimeOwnership{
+		RuntimeID: id,
+		NewOwner:  newOwner,
+	})
+
+	cmdConsensus.SignAndSaveTx(tx)
+}
+
+func doGenAcceptOwnership(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	cmdConsensus.InitGenesis()
+	cmdConsensus.AssertTxFileOK()
+
+	// The runtime descriptor must be identical to the registered one, with
+	// the entity (the new owner) being derived from the signer.
+	rt, signer, err := runtimeFromFlags()
+	if err != nil {
+		logger.Info("failed to get runtime",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	signed, err := signForRegistration(rt, signer, false)
+	if err != nil {
+		logger.Info("failed to sign runtime descriptor",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	nonce, fee := cmdConsensus.GetTxNonceAndFee()
+	tx := registry.NewAcceptRuntimeOwnershipTx(nonce, fee, signed)
+
+	cmdConsensus.SignAndSaveTx(tx)
+}
+
+func runtimeIDFromFlags() (common.Namespace, error) {
 	var id common.Namespace
 	if err := id.UnmarshalHex(viper.GetString(CfgID)); err != nil {
 		logger.Error("failed to parse runtime ID",
 			"err", err,
 		)
+		return id, err
+	}
+	return id, nil
+}
+
+func runtimeFromFlags() (*registry.Runtime, signature.Signer, error) {
+	id, err := runtimeIDFromFlags()
+	if err != nil {
 		return nil, nil, err
 	}
 
@@ -268,6 +425,22 @@ func runtimeFromFlags() (*registry.Runtime, signature.Signer, error) {
 		)
 		return nil, nil, fmt.Errorf("invalid runtime kind")
 	}
+	var executorElectionMode, storageElectionMode registry.CommitteeElectionMode
+	s = viper.GetString(CfgExecutorElectionMode)
+	if err = executorElectionMode.FromString(s); err != nil {
+		logger.Error("invalid executor election mode",
+			CfgExecutorElectionMode, s,
+		)
+		return nil, nil, fmt.Errorf("invalid executor election mode")
+	}
+	s = viper.GetString(CfgStorageElectionMode)
+	if err = storageElectionMode.FromString(s); err != nil {
+		logger.Error("invalid storage election mode",
+			CfgStorageElectionMode, s,
+		)
+		return nil, nil, fmt.Errorf("invalid storage election mode")
+	}
+
 	switch kind {
 	case registry.KindCompute:
 		if viper.GetString(CfgKeyManager) != "" {
@@ -368,6 +541,8 @@ func runtimeFromFlags() (*registry.Runtime, signature.Signer, error) {
 			GroupBackupSize:   viper.GetUint64(CfgExecutorGroupBackupSize),
 			AllowedStragglers: viper.GetUint64(CfgExecutorAllowedStragglers),
 			RoundTimeout:      viper.GetDuration(CfgExecutorRoundTimeout),
+			ElectionMode:      executorElectionMode,
+			MaxNodesPerEntity: viper.GetUint64(CfgExecutorMaxNodesPerEntity),
 		},
 		Merge: registry.MergeParameters{
 			GroupSize:         viper.GetUint64(CfgMergeGroupSize),
@@ -391,6 +566,8 @@ func runtimeFromFlags() (*registry.Runtime, signature.Signer, error) {
 			CheckpointInterval:      viper.GetUint64(CfgStorageCheckpointInterval),
 			CheckpointNumKept:       viper.GetUint64(CfgStorageCheckpointNumKept),
 			CheckpointChunkSize:     viper.GetUint64(CfgStorageCheckpointChunkSize),
+			ElectionMode:            storageElectionMode,
+			MaxNodesPerEntity:       viper.GetUint64(CfgStorageMaxNodesPerEntity),
 		},
 	}
 	if teeHardware == node.TEEHardwareIntelSGX {
@@ -426,6 +603,37 @@ func runtimeFromFlags() (*registry.Runtime, signature.Signer, error) {
 		rt.AdmissionPolicy.EntityWhitelist = &registry.EntityWhitelistRuntimeAdmissionPolicy{
 			Entities: entities,
 		}
+	case AdmissionPolicyNameMinEscrow:
+		var minEscrow quantity.Quantity
+		if err = minEscrow.UnmarshalText([]byte(viper.GetString(CfgAdmissionPolicyMinEscrow))); err != nil {
+			logger.Error("failed to parse minimum escrow",
+				"err", err,
+				CfgAdmissionPolicyMinEscrow, viper.GetString(CfgAdmissionPolicyMinEscrow),
+			)
+			return nil, nil, fmt.Errorf("min escrow runtime admission policy parse min escrow: %w", err)
+		}
+		rt.AdmissionPolicy.MinEscrow = &registry.MinEscrowRuntimeAdmissionPolicy{
+			MinEscrow: minEscrow,
+		}
+	case AdmissionPolicyNamePerEntityNodeLimit:
+		limits := make(map[node.RolesMask]uint64)
+		for _, sl := range viper.GetStringSlice(CfgAdmissionPolicyPerEntityNodeLimit) {
+			var (
+				role  node.RolesMask
+				limit uint64
+			)
+			if role, limit, err = parseRoleLimit(sl); err != nil {
+				logger.Error("failed to parse per-entity node limit",
+					"err", err,
+					CfgAdmissionPolicyPerEntityNodeLimit, sl,
+				)
+				return nil, nil, fmt.Errorf("per-entity node limit runtime admission policy parse limit: %w", err)
+			}
+			limits[role] = limit
+		}
+		rt.AdmissionPolicy.PerEntityNodeLimit = &registry.PerEntityNodeLimitRuntimeAdmissionPolicy{
+			Limits: limits,
+		}
 	default:
 		logger.Error("invalid runtime admission policy",
 			CfgAdmissionPolicy, sap,
@@ -466,6 +674,10 @@ func Register(parentCmd *cobra.Command) {
 	for _, v := range []*cobra.Command{
 		initGenesisCmd,
 		registerCmd,
+		suspendCmd,
+		resumeCmd,
+		transferOwnershipCmd,
+		acceptOwnershipCmd,
 		listCmd,
 	} {
 		runtimeCmd.AddCommand(v)
@@ -474,6 +686,7 @@ func Register(parentCmd *cobra.Command) {
 	for _, v := range []*cobra.Command{
 		initGenesisCmd,
 		registerCmd,
+		acceptOwnershipCmd,
 	} {
 		v.Flags().AddFlagSet(cmdFlags.DebugTestEntityFlags)
 	}
@@ -483,11 +696,23 @@ func Register(parentCmd *cobra.Command) {
 
 	listCmd.Flags().AddFlagSet(cmdGrpc.ClientFlags)
 	listCmd.Flags().AddFlagSet(cmdFlags.VerboseFlags)
+	listCmd.Flags().AddFlagSet(listFlags)
 
 	registerCmd.Flags().AddFlagSet(registerFlags)
 
 	registerCmd.Flags().AddFlagSet(runtimeFlags)
 
+	for _, v := range []*cobra.Command{
+		suspendCmd,
+		resumeCmd,
+	} {
+		v.Flags().AddFlagSet(lifecycleFlags)
+	}
+	transferOwnershipCmd.Flags().AddFlagSet(transferFlags)
+
+	acceptOwnershipCmd.Flags().AddFlagSet(registerFlags)
+	acceptOwnershipCmd.Flags().AddFlagSet(runtimeFlags)
+
 	parentCmd.AddCommand(runtimeCmd)
 }
 
@@ -495,7 +720,10 @@ func init() {
 	outputFlags.String(cfgOutput, runtimeGenesisFilename, "File name of the document to be written under datadir")
 	_ = viper.BindPFlags(outputFlags)
 
-	runtimeFlags.String(CfgID, "", "Runtime ID")
+	runtimeIDFlags.String(CfgID, "", "Runtime ID")
+	_ = viper.BindPFlags(runtimeIDFlags)
+
+	runtimeFlags.AddFlagSet(runtimeIDFlags)
 	runtimeFlags.String(CfgTEEHardware, "invalid", "Type of TEE hardware.  Supported values are \"invalid\" and \"intel-sgx\"")
 	runtimeFlags.String(CfgGenesisState, "", "Runtime state at genesis")
 	runtimeFlags.Uint64(CfgGenesisRound, 0, "Runtime round at genesis")
@@ -509,6 +737,8 @@ func init() {
 	runtimeFlags.Uint64(CfgExecutorGroupBackupSize, 0, "Number of backup workers in the runtime executor group/committee")
 	runtimeFlags.Uint64(CfgExecutorAllowedStragglers, 0, "Number of stragglers allowed per round in the runtime executor group")
 	runtimeFlags.Duration(CfgExecutorRoundTimeout, 10*time.Second, "Executor committee round timeout for this runtime")
+	runtimeFlags.String(CfgExecutorElectionMode, "uniform", "Election mode for all compute committees.  Supported values are \"uniform\" and \"stake_weighted\"")
+	runtimeFlags.Uint64(CfgExecutorMaxNodesPerEntity, 0, "Maximum number of nodes of a single entity in each compute committee (0 means no limit)")
 
 	// Init Merge committee flags.
 	runtimeFlags.Uint64(CfgMergeGroupSize, 1, "Number of workers in the runtime merge group/committee")
@@ -532,10 +762,14 @@ func init() {
 	runtimeFlags.Uint64(CfgStorageCheckpointInterval, 0, "Storage checkpoint interval (in rounds)")
 	runtimeFlags.Uint64(CfgStorageCheckpointNumKept, 0, "Number of storage checkpoints to keep")
 	runtimeFlags.Uint64(CfgStorageCheckpointChunkSize, 0, "Storage checkpoint chunk size")
+	runtimeFlags.String(CfgStorageElectionMode, "uniform", "Election mode for the storage committee.  Supported values are \"uniform\" and \"stake_weighted\"")
+	runtimeFlags.Uint64(CfgStorageMaxNodesPerEntity, 0, "Maximum number of nodes of a single entity in the storage committee (0 means no limit)")
 
 	// Init Admission policy flags.
 	runtimeFlags.String(CfgAdmissionPolicy, "", "What type of node admission policy to have")
 	runtimeFlags.StringSlice(CfgAdmissionPolicyEntityWhitelist, nil, "For entity whitelist node admission policies, the IDs (hex) of the entities in the whitelist")
+	runtimeFlags.String(CfgAdmissionPolicyMinEscrow, "0", "For min escrow node admission policies, the minimum amount of escrow an entity must have")
+	runtimeFlags.StringSlice(CfgAdmissionPolicyPerEntityNodeLimit, nil, "For per-entity node limit node admission policies, the node limits in <role>=<limit> format (e.g., compute=2)")
 
 	_ = viper.BindPFlags(runtimeFlags)
 	runtimeFlags.AddFlagSet(cmdSigner.Flags)
@@ -543,4 +777,45 @@ func init() {
 
 	registerFlags.AddFlagSet(cmdFlags.DebugTestEntityFlags)
 	registerFlags.AddFlagSet(cmdConsensus.TxFlags)
+
+	lifecycleFlags.AddFlagSet(runtimeIDFlags)
+	lifecycleFlags.AddFlagSet(cmdFlags.DebugTestEntityFlags)
+	lifecycleFlags.AddFlagSet(cmdConsensus.TxFlags)
+
+	transferFlags.String(CfgNewOwner, "", "ID (hex) of the entity to transfer the runtime ownership to")
+	_ = viper.BindPFlags(transferFlags)
+	transferFlags.AddFlagSet(lifecycleFlags)
+
+	listFlags.Bool(CfgIncludeSuspended, false, "Also list suspended runtimes")
+	_ = viper.BindPFlags(listFlags)
+}
+
+func parseRoleLimit(s string) (node.RolesMask, uint64, error) {
+	parts := strings.SplitN(s, "=", 2)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("malformed role limit: '%s'", s)
+	}
+
+	var role node.RolesMask
+	for _, r := range []node.RolesMask{
+		node.RoleComputeWorker,
+		node.RoleStorageWorker,
+		node.RoleKeyManager,
+		node.RoleValidator,
+		node.RoleConsensusRPC,
+	} {
+		if r.String() == parts[0] {
+			role = r
+			break
+		}
+	}
+	if role == 0 {
+		return 0, 0, fmt.Errorf("invalid role: '%s'", parts[0])
+	}
+
+	limit, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid limit: %w", err)
+	}
+	return role, limit, nil
 }
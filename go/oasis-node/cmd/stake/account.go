@@ -12,6 +12,8 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/oasislabs/oasis-core/go/common/crypto/signature"
+	consensus "github.com/oasislabs/oasis-core/go/consensus/api"
+	epochtime "github.com/oasislabs/oasis-core/go/epochtime/api"
 	cmdCommon "github.com/oasislabs/oasis-core/go/oasis-node/cmd/common"
 	cmdConsensus "github.com/oasislabs/oasis-core/go/oasis-node/cmd/common/consensus"
 	cmdFlags "github.com/oasislabs/oasis-core/go/oasis-node/cmd/common/flags"
@@ -40,6 +42,18 @@ const (
 
 	// CfgCommissionScheduleBounds configures the commission schedule rate bound steps.
 	CfgCommissionScheduleBounds = "stake.commission_schedule.bounds"
+
+	// CfgRewardsEscrowAccount configures the escrow address to query the
+	// delegation rewards for.
+	CfgRewardsEscrowAccount = "stake.rewards.escrow_account"
+
+	// CfgRewardsFromEpoch configures the first epoch (inclusive) to query
+	// the delegation rewards for.
+	CfgRewardsFromEpoch = "stake.rewards.from_epoch"
+
+	// CfgRewardsToEpoch configures the last epoch (exclusive) to query the
+	// delegation rewards for.
+	CfgRewardsToEpoch = "stake.rewards.to_epoch"
 )
 
 var (
@@ -49,6 +63,7 @@ var (
 	commonEscrowFlags       = flag.NewFlagSet("", flag.ContinueOnError)
 	commissionScheduleFlags = flag.NewFlagSet("", flag.ContinueOnError)
 	accountTransferFlags    = flag.NewFlagSet("", flag.ContinueOnError)
+	accountRewardsFlags     = flag.NewFlagSet("", flag.ContinueOnError)
 
 	accountCmd = &cobra.Command{
 		Use:   "account",
@@ -61,6 +76,12 @@ var (
 		Run:   doAccountInfo,
 	}
 
+	accountRewardsCmd = &cobra.Command{
+		Use:   "rewards",
+		Short: "query delegation rewards",
+		Run:   doAccountRewards,
+	}
+
 	accountTransferCmd = &cobra.Command{
 		Use:   "gen_transfer",
 		Short: "generate a transfer transaction",
@@ -114,6 +135,51 @@ func doAccountInfo(cmd *cobra.Command, args []string) {
 	fmt.Printf("%v\n", string(b))
 }
 
+func doAccountRewards(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	var id signature.PublicKey
+	if err := id.UnmarshalText([]byte(viper.GetString(CfgAccountID))); err != nil {
+		logger.Error("failed to parse account ID",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	// Default to the account's own escrow account.
+	escrow := id
+	if raw := viper.GetString(CfgRewardsEscrowAccount); raw != "" {
+		if err := escrow.UnmarshalText([]byte(raw)); err != nil {
+			logger.Error("failed to parse escrow account",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+	}
+
+	conn, client := doConnect(cmd)
+	defer conn.Close()
+
+	ctx := context.Background()
+	query := &staking.DelegationRewardsQuery{
+		Height: consensus.HeightLatest,
+		Owner:  id,
+		Escrow: escrow,
+		From:   epochtime.EpochTime(viper.GetUint64(CfgRewardsFromEpoch)),
+		To:     epochtime.EpochTime(viper.GetUint64(CfgRewardsToEpoch)),
+	}
+	var rewards *staking.DelegationRewards
+	doWithRetries(cmd, "query delegation rewards of "+id.String(), func() error {
+		var err error
+		rewards, err = client.DelegationRewards(ctx, query)
+		return err
+	})
+
+	b, _ := json.Marshal(rewards)
+	fmt.Printf("%v\n", string(b))
+}
+
 func doAccountTransfer(cmd *cobra.Command, args []string) {
 	if err := cmdCommon.Init(); err != nil {
 		cmdCommon.EarlyLogAndExit(err)
@@ -301,6 +367,7 @@ func doAccountAmendCommissionSchedule(cmd *cobra.Command, args []string) {
 func registerAccountCmd() {
 	for _, v := range []*cobra.Command{
 		accountInfoCmd,
+		accountRewardsCmd,
 		accountTransferCmd,
 		accountBurnCmd,
 		accountEscrowCmd,
@@ -311,6 +378,8 @@ func registerAccountCmd() {
 	}
 
 	accountInfoCmd.Flags().AddFlagSet(accountInfoFlags)
+	accountRewardsCmd.Flags().AddFlagSet(accountInfoFlags)
+	accountRewardsCmd.Flags().AddFlagSet(accountRewardsFlags)
 	accountTransferCmd.Flags().AddFlagSet(accountTransferFlags)
 	accountBurnCmd.Flags().AddFlagSet(cmdConsensus.TxFlags)
 	accountBurnCmd.Flags().AddFlagSet(amountFlags)
@@ -327,6 +396,11 @@ func init() {
 	accountInfoFlags.AddFlagSet(cmdFlags.RetriesFlags)
 	accountInfoFlags.AddFlagSet(cmdGrpc.ClientFlags)
 
+	accountRewardsFlags.String(CfgRewardsEscrowAccount, "", "ID of the escrow account (defaults to the account itself)")
+	accountRewardsFlags.Uint64(CfgRewardsFromEpoch, 0, "first epoch (inclusive) to query the rewards for")
+	accountRewardsFlags.Uint64(CfgRewardsToEpoch, uint64(epochtime.EpochInvalid), "last epoch (exclusive) to query the rewards for")
+	_ = viper.BindPFlags(accountRewardsFlags)
+
 	amountFlags.String(CfgAmount, "0", "amount of tokens for the transaction")
 	_ = viper.BindPFlags(amountFlags)
 
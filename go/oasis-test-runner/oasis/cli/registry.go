@@ -104,6 +104,20 @@ func (r *RegistryHelpers) GenerateRegisterRuntimeTx(
 				"--"+cmdRegRt.CfgAdmissionPolicyEntityWhitelist, e.String(),
 			)
 		}
+	} else if runtime.AdmissionPolicy.MinEscrow != nil {
+		args = append(args,
+			"--"+cmdRegRt.CfgAdmissionPolicy, cmdRegRt.AdmissionPolicyNameMinEscrow,
+			"--"+cmdRegRt.CfgAdmissionPolicyMinEscrow, runtime.AdmissionPolicy.MinEscrow.MinEscrow.String(),
+		)
+	} else if runtime.AdmissionPolicy.PerEntityNodeLimit != nil {
+		args = append(args,
+			"--"+cmdRegRt.CfgAdmissionPolicy, cmdRegRt.AdmissionPolicyNamePerEntityNodeLimit,
+		)
+		for role, limit := range runtime.AdmissionPolicy.PerEntityNodeLimit.Limits {
+			args = append(args,
+				"--"+cmdRegRt.CfgAdmissionPolicyPerEntityNodeLimit, fmt.Sprintf("%s=%d", role, limit),
+			)
+		}
 	} else {
 		return fmt.Errorf("invalid admission policy")
 	}
@@ -218,6 +218,20 @@ func (net *Network) NewRuntime(cfg *RuntimeCfg) (*Runtime, error) {
 				"--"+cmdRegRt.CfgAdmissionPolicyEntityWhitelist, e.String(),
 			)
 		}
+	} else if cfg.AdmissionPolicy.MinEscrow != nil {
+		args = append(args,
+			"--"+cmdRegRt.CfgAdmissionPolicy, cmdRegRt.AdmissionPolicyNameMinEscrow,
+			"--"+cmdRegRt.CfgAdmissionPolicyMinEscrow, cfg.AdmissionPolicy.MinEscrow.MinEscrow.String(),
+		)
+	} else if cfg.AdmissionPolicy.PerEntityNodeLimit != nil {
+		args = append(args,
+			"--"+cmdRegRt.CfgAdmissionPolicy, cmdRegRt.AdmissionPolicyNamePerEntityNodeLimit,
+		)
+		for role, limit := range cfg.AdmissionPolicy.PerEntityNodeLimit.Limits {
+			args = append(args,
+				"--"+cmdRegRt.CfgAdmissionPolicyPerEntityNodeLimit, fmt.Sprintf("%s=%d", role, limit),
+			)
+		}
 	} else {
 		return nil, fmt.Errorf("invalid admission policy")
 	}
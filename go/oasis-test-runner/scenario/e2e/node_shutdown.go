@@ -53,12 +53,12 @@ func (sc *nodeShutdownImpl) Run(childEnv *env.Env) error {
 	sc.logger.Info("requesting node shutdown")
 	computeWorker := sc.runtimeImpl.net.ComputeWorkers()[0]
 
-	// Wait for the node to be ready since we didn't wait for any clients.
+	// Wait for the node to sync since we didn't wait for any clients.
 	nodeCtrl, err := oasis.NewController(computeWorker.SocketPath())
 	if err != nil {
 		return err
 	}
-	if err = nodeCtrl.WaitSync(context.Background()); err != nil {
+	if err = waitConsensusSynced(context.Background(), nodeCtrl); err != nil {
 		return err
 	}
 
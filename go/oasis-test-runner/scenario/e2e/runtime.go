@@ -558,6 +558,32 @@ func (sc *runtimeImpl) submitKeyValueRuntimeInsertTx(ctx context.Context, id com
 	return err
 }
 
+// waitConsensusSynced waits for the consensus service of the node to become
+// ready. Unlike WaitReady, this does not wait for the node to register, which
+// may require further epoch transitions.
+func waitConsensusSynced(ctx context.Context, c *oasis.Controller) error {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		status, err := c.GetStatus(ctx)
+		if err != nil {
+			return err
+		}
+		for _, svc := range status.Readiness.Services {
+			if svc.Name == "consensus" && svc.Ready {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
 func (sc *runtimeImpl) waitNodesSynced() error {
 	ctx := context.Background()
 
@@ -568,7 +594,7 @@ func (sc *runtimeImpl) waitNodesSynced() error {
 		}
 		defer c.Close()
 
-		if err = c.WaitSync(ctx); err != nil {
+		if err = waitConsensusSynced(ctx, c); err != nil {
 			return fmt.Errorf("failed to wait for node to sync: %w", err)
 		}
 		return nil
@@ -100,7 +100,7 @@ func (sc *nodeUpgradeImpl) restart(wait bool) error {
 		case ev := <-sc.nodeCh:
 			if ev.IsRegistration && ev.Node.ID.Equal(sc.validator.NodeID) {
 				// Nothing else is restarted, so no need to check for specifics here.
-				_ = sc.controller.WaitSync(sc.ctx)
+				_ = sc.controller.WaitReady(sc.ctx)
 				return nil
 			}
 		case <-time.After(60 * time.Second):
@@ -198,7 +198,7 @@ func (sc *nodeUpgradeImpl) Run(childEnv *env.Env) error {
 	if err != nil {
 		return err
 	}
-	if err = sc.controller.WaitSync(sc.ctx); err != nil {
+	if err = sc.controller.WaitReady(sc.ctx); err != nil {
 		return err
 	}
 
@@ -11,6 +11,7 @@ import (
 
 	"github.com/oasislabs/oasis-core/go/common"
 	"github.com/oasislabs/oasis-core/go/common/cbor"
+	"github.com/oasislabs/oasis-core/go/common/crypto/hash"
 	"github.com/oasislabs/oasis-core/go/common/crypto/signature"
 	"github.com/oasislabs/oasis-core/go/common/entity"
 	"github.com/oasislabs/oasis-core/go/common/errors"
@@ -130,24 +131,52 @@ var (
 	// has runtimes.
 	ErrEntityHasRuntimes = errors.New(ModuleName, 19, "registry: entity still has runtimes")
 
+	// ErrNoPendingOwnershipTransfer is the error returned when accepting a
+	// runtime ownership transfer that has not been initiated by the owner.
+	ErrNoPendingOwnershipTransfer = errors.New(ModuleName, 20, "registry: no pending runtime ownership transfer")
+
+	// ErrNoSuchEntityMetadata is the error returned when an entity has no
+	// metadata document.
+	ErrNoSuchEntityMetadata = errors.New(ModuleName, 21, "registry: no such entity metadata")
+
 	// MethodRegisterEntity is the method name for entity registrations.
 	MethodRegisterEntity = transaction.NewMethodName(ModuleName, "RegisterEntity", entity.SignedEntity{})
 	// MethodDeregisterEntity is the method name for entity deregistrations.
 	MethodDeregisterEntity = transaction.NewMethodName(ModuleName, "DeregisterEntity", nil)
+	// MethodSetEntityMetadata is the method name for setting entity metadata.
+	MethodSetEntityMetadata = transaction.NewMethodName(ModuleName, "SetEntityMetadata", SignedEntityMetadata{})
 	// MethodRegisterNode is the method name for node registrations.
 	MethodRegisterNode = transaction.NewMethodName(ModuleName, "RegisterNode", node.MultiSignedNode{})
 	// MethodUnfreezeNode is the method name for unfreezing nodes.
 	MethodUnfreezeNode = transaction.NewMethodName(ModuleName, "UnfreezeNode", UnfreezeNode{})
+	// MethodDeregisterNode is the method name for node deregistrations.
+	MethodDeregisterNode = transaction.NewMethodName(ModuleName, "DeregisterNode", DeregisterNode{})
 	// MethodRegisterRuntime is the method name for registering runtimes.
 	MethodRegisterRuntime = transaction.NewMethodName(ModuleName, "RegisterRuntime", SignedRuntime{})
+	// MethodSuspendRuntime is the method name for suspending runtimes.
+	MethodSuspendRuntime = transaction.NewMethodName(ModuleName, "SuspendRuntime", SuspendRuntime{})
+	// MethodResumeRuntime is the method name for resuming runtimes.
+	MethodResumeRuntime = transaction.NewMethodName(ModuleName, "ResumeRuntime", ResumeRuntime{})
+	// MethodTransferRuntimeOwnership is the method name for initiating
+	// runtime ownership transfers.
+	MethodTransferRuntimeOwnership = transaction.NewMethodName(ModuleName, "TransferRuntimeOwnership", TransferRuntimeOwnership{})
+	// MethodAcceptRuntimeOwnership is the method name for accepting
+	// runtime ownership transfers.
+	MethodAcceptRuntimeOwnership = transaction.NewMethodName(ModuleName, "AcceptRuntimeOwnership", SignedRuntime{})
 
 	// Methods is the list of all methods supported by the registry backend.
 	Methods = []transaction.MethodName{
 		MethodRegisterEntity,
 		MethodDeregisterEntity,
+		MethodSetEntityMetadata,
 		MethodRegisterNode,
 		MethodUnfreezeNode,
+		MethodDeregisterNode,
 		MethodRegisterRuntime,
+		MethodSuspendRuntime,
+		MethodResumeRuntime,
+		MethodTransferRuntimeOwnership,
+		MethodAcceptRuntimeOwnership,
 	}
 
 	// RuntimesRequiredRoles are the Node roles that require runtimes.
@@ -186,6 +215,13 @@ type Backend interface {
 	// EntityEvent on entity registration changes.
 	WatchEntities(context.Context) (<-chan *EntityEvent, pubsub.ClosableSubscription, error)
 
+	// GetEntityMetadata gets the signed metadata document of an entity.
+	GetEntityMetadata(context.Context, *IDQuery) (*SignedEntityMetadata, error)
+
+	// WatchEntityMetadata returns a channel that produces a stream of
+	// EntityMetadataEvent on entity metadata changes.
+	WatchEntityMetadata(context.Context) (<-chan *EntityMetadataEvent, pubsub.ClosableSubscription, error)
+
 	// GetNode gets a node by ID.
 	GetNode(context.Context, *IDQuery) (*node.Node, error)
 
@@ -210,9 +246,12 @@ type Backend interface {
 	// GetRuntime gets a runtime by ID.
 	GetRuntime(context.Context, *NamespaceQuery) (*Runtime, error)
 
+	// GetRuntimeStatus returns a runtime's status.
+	GetRuntimeStatus(context.Context, *NamespaceQuery) (*RuntimeStatus, error)
+
 	// GetRuntimes returns the registered Runtimes at the specified
 	// block height.
-	GetRuntimes(context.Context, int64) ([]*Runtime, error)
+	GetRuntimes(context.Context, *GetRuntimesQuery) ([]*Runtime, error)
 
 	// GetNodeList returns the NodeList at the specified block height.
 	GetNodeList(context.Context, int64) (*NodeList, error)
@@ -243,6 +282,14 @@ type NamespaceQuery struct {
 	ID     common.Namespace `json:"id"`
 }
 
+// GetRuntimesQuery is a registry query for all runtimes.
+type GetRuntimesQuery struct {
+	Height int64 `json:"height"`
+	// IncludeSuspended specifies whether suspended runtimes should also
+	// be returned.
+	IncludeSuspended bool `json:"include_suspended"`
+}
+
 // NewRegisterEntityTx creates a new register entity transaction.
 func NewRegisterEntityTx(nonce uint64, fee *transaction.Fee, sigEnt *entity.SignedEntity) *transaction.Transaction {
 	return transaction.NewTransaction(nonce, fee, MethodRegisterEntity, sigEnt)
@@ -253,6 +300,11 @@ func NewDeregisterEntityTx(nonce uint64, fee *transaction.Fee) *transaction.Tran
 	return transaction.NewTransaction(nonce, fee, MethodDeregisterEntity, nil)
 }
 
+// NewSetEntityMetadataTx creates a new set entity metadata transaction.
+func NewSetEntityMetadataTx(nonce uint64, fee *transaction.Fee, sigMeta *SignedEntityMetadata) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodSetEntityMetadata, sigMeta)
+}
+
 // NewRegisterNodeTx creates a new register node transaction.
 func NewRegisterNodeTx(nonce uint64, fee *transaction.Fee, sigNode *node.MultiSignedNode) *transaction.Transaction {
 	return transaction.NewTransaction(nonce, fee, MethodRegisterNode, sigNode)
@@ -263,11 +315,41 @@ func NewUnfreezeNodeTx(nonce uint64, fee *transaction.Fee, unfreeze *UnfreezeNod
 	return transaction.NewTransaction(nonce, fee, MethodUnfreezeNode, unfreeze)
 }
 
+// NewDeregisterNodeTx creates a new deregister node transaction.
+func NewDeregisterNodeTx(nonce uint64, fee *transaction.Fee, dereg *DeregisterNode) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodDeregisterNode, dereg)
+}
+
 // NewRegisterRuntimeTx creates a new register runtime transaction.
 func NewRegisterRuntimeTx(nonce uint64, fee *transaction.Fee, sigRt *SignedRuntime) *transaction.Transaction {
 	return transaction.NewTransaction(nonce, fee, MethodRegisterRuntime, sigRt)
 }
 
+// NewSuspendRuntimeTx creates a new suspend runtime transaction.
+func NewSuspendRuntimeTx(nonce uint64, fee *transaction.Fee, suspend *SuspendRuntime) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodSuspendRuntime, suspend)
+}
+
+// NewResumeRuntimeTx creates a new resume runtime transaction.
+func NewResumeRuntimeTx(nonce uint64, fee *transaction.Fee, resume *ResumeRuntime) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodResumeRuntime, resume)
+}
+
+// NewTransferRuntimeOwnershipTx creates a new transfer runtime ownership
+// transaction.
+func NewTransferRuntimeOwnershipTx(nonce uint64, fee *transaction.Fee, transfer *TransferRuntimeOwnership) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodTransferRuntimeOwnership, transfer)
+}
+
+// NewAcceptRuntimeOwnershipTx creates a new accept runtime ownership
+// transaction.
+//
+// The signed runtime descriptor must be signed by the new owner and be
+// identical to the existing descriptor except for the entity identifier.
+func NewAcceptRuntimeOwnershipTx(nonce uint64, fee *transaction.Fee, sigRt *SignedRuntime) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodAcceptRuntimeOwnership, sigRt)
+}
+
 // EntityEvent is the event that is returned via WatchEntities to signify
 // entity registration changes and updates.
 type EntityEvent struct {
@@ -282,9 +364,16 @@ type NodeEvent struct {
 	IsRegistration bool       `json:"is_registration"`
 }
 
-// RuntimeEvent signifies new runtime registration.
+// RuntimeEvent signifies new runtime registration or a runtime lifecycle
+// change.
 type RuntimeEvent struct {
 	Runtime *Runtime `json:"runtime"`
+
+	// Suspended is true if the runtime has been suspended by its owner.
+	Suspended bool `json:"suspended,omitempty"`
+	// PreviousOwner is set if the runtime's ownership has been transferred
+	// and contains the identifier of the previous owner.
+	PreviousOwner *signature.PublicKey `json:"previous_owner,omitempty"`
 }
 
 // NodeUnfrozenEvent signifies when node becomes unfrozen.
@@ -294,10 +383,13 @@ type NodeUnfrozenEvent struct {
 
 // Event is a registry event returned via GetEvents.
 type Event struct {
-	RuntimeEvent      *RuntimeEvent      `json:"runtime,omitempty"`
-	EntityEvent       *EntityEvent       `json:"entity,omitempty"`
-	NodeEvent         *NodeEvent         `json:"node,omitempty"`
-	NodeUnfrozenEvent *NodeUnfrozenEvent `json:"node_unfrozen,omitempty"`
+	TxHash hash.Hash `json:"tx_hash,omitempty"`
+
+	RuntimeEvent        *RuntimeEvent        `json:"runtime,omitempty"`
+	EntityEvent         *EntityEvent         `json:"entity,omitempty"`
+	EntityMetadataEvent *EntityMetadataEvent `json:"entity_metadata,omitempty"`
+	NodeEvent           *NodeEvent           `json:"node,omitempty"`
+	NodeUnfrozenEvent   *NodeUnfrozenEvent   `json:"node_unfrozen,omitempty"`
 }
 
 // NodeList is a per-epoch immutable node list.
@@ -1077,6 +1169,14 @@ func VerifyRegisterRuntimeArgs(
 			return nil, fmt.Errorf("%w: transaction scheduler group to small", ErrInvalidArgument)
 		}
 
+		// Ensure the compute committee election mode is supported.
+		if !rt.Executor.ElectionMode.IsValid() {
+			logger.Error("RegisterRuntime: unsupported executor election mode",
+				"runtime", rt,
+			)
+			return nil, fmt.Errorf("%w: unsupported executor election mode", ErrInvalidArgument)
+		}
+
 		// Ensure storage parameters have sensible values.
 		if err := VerifyRegisterRuntimeStorageArgs(&rt, logger); err != nil {
 			return nil, err
@@ -1123,12 +1223,34 @@ func VerifyRegisterRuntimeArgs(
 	}
 
 	// Ensure there's a valid admission policy.
-	if !exactlyOneTrue(rt.AdmissionPolicy.AnyNode != nil, rt.AdmissionPolicy.EntityWhitelist != nil) {
+	if !exactlyOneTrue(
+		rt.AdmissionPolicy.AnyNode != nil,
+		rt.AdmissionPolicy.EntityWhitelist != nil,
+		rt.AdmissionPolicy.MinEscrow != nil,
+		rt.AdmissionPolicy.PerEntityNodeLimit != nil,
+	) {
 		logger.Error("RegisterRuntime: invalid admission policy. exactly one policy should be non-nil",
 			"admission_policy", rt.AdmissionPolicy,
 		)
 		return nil, fmt.Errorf("%w: invalid admission policy", ErrInvalidArgument)
 	}
+	if pol := rt.AdmissionPolicy.MinEscrow; pol != nil && !pol.MinEscrow.IsValid() {
+		logger.Error("RegisterRuntime: invalid admission policy minimum escrow",
+			"admission_policy", rt.AdmissionPolicy,
+		)
+		return nil, fmt.Errorf("%w: invalid admission policy minimum escrow", ErrInvalidArgument)
+	}
+	if pol := rt.AdmissionPolicy.PerEntityNodeLimit; pol != nil {
+		for role := range pol.Limits {
+			if !role.IsSingleRole() {
+				logger.Error("RegisterRuntime: invalid admission policy node limit role",
+					"admission_policy", rt.AdmissionPolicy,
+					"role", role,
+				)
+				return nil, fmt.Errorf("%w: invalid admission policy node limit role", ErrInvalidArgument)
+			}
+		}
+	}
 
 	return &rt, nil
 }
@@ -1145,6 +1267,14 @@ func VerifyRegisterRuntimeStorageArgs(rt *Runtime, logger *logging.Logger) error
 		return fmt.Errorf("%w: storage group too small", ErrInvalidArgument)
 	}
 
+	// Ensure the storage committee election mode is supported.
+	if !params.ElectionMode.IsValid() {
+		logger.Error("RegisterRuntime: unsupported storage election mode",
+			"runtime", rt,
+		)
+		return fmt.Errorf("%w: unsupported storage election mode", ErrInvalidArgument)
+	}
+
 	// Ensure limit parameters have sensible values.
 	if params.MaxApplyWriteLogEntries < 10 {
 		logger.Error("RegisterRuntime: storage MaxApplyWriteLogEntries parameter too small",
@@ -1282,6 +1412,8 @@ type Genesis struct {
 
 	// Entities is the initial list of entities.
 	Entities []*entity.SignedEntity `json:"entities,omitempty"`
+	// EntityMetadata is the initial list of entity metadata documents.
+	EntityMetadata []*SignedEntityMetadata `json:"entity_metadata,omitempty"`
 
 	// Runtimes is the initial list of runtimes.
 	Runtimes []*SignedRuntime `json:"runtimes,omitempty"`
@@ -1293,6 +1425,9 @@ type Genesis struct {
 
 	// NodeStatuses is a set of node statuses.
 	NodeStatuses map[signature.PublicKey]*NodeStatus `json:"node_statuses,omitempty"`
+
+	// RuntimeStatuses is a set of runtime statuses.
+	RuntimeStatuses map[common.Namespace]*RuntimeStatus `json:"runtime_statuses,omitempty"`
 }
 
 // ConsensusParameters are the registry consensus parameters.
@@ -1334,10 +1469,14 @@ const (
 	GasOpRegisterEntity transaction.Op = "register_entity"
 	// GasOpDeregisterEntity is the gas operation identifier for entity deregistration.
 	GasOpDeregisterEntity transaction.Op = "deregister_entity"
+	// GasOpSetEntityMetadata is the gas operation identifier for setting entity metadata.
+	GasOpSetEntityMetadata transaction.Op = "set_entity_metadata"
 	// GasOpRegisterNode is the gas operation identifier for entity registration.
 	GasOpRegisterNode transaction.Op = "register_node"
 	// GasOpUnfreezeNode is the gas operation identifier for unfreezing nodes.
 	GasOpUnfreezeNode transaction.Op = "unfreeze_node"
+	// GasOpDeregisterNode is the gas operation identifier for node deregistration.
+	GasOpDeregisterNode transaction.Op = "deregister_node"
 	// GasOpRegisterRuntime is the gas operation identifier for runtime registration.
 	GasOpRegisterRuntime transaction.Op = "register_runtime"
 	// GasOpRuntimeEpochMaintenance is the gas operation identifier for per-epoch
@@ -1346,6 +1485,9 @@ const (
 	// GasOpUpdateKeyManager is the gas operation identifier for key manager
 	// policy updates costs.
 	GasOpUpdateKeyManager transaction.Op = "update_keymanager"
+	// GasOpRuntimeLifecycle is the gas operation identifier for runtime
+	// suspension, resumption and ownership transfers.
+	GasOpRuntimeLifecycle transaction.Op = "runtime_lifecycle"
 )
 
 // XXX: Define reasonable default gas costs.
@@ -1354,11 +1496,14 @@ const (
 var DefaultGasCosts = transaction.Costs{
 	GasOpRegisterEntity:          1000,
 	GasOpDeregisterEntity:        1000,
+	GasOpSetEntityMetadata:       1000,
 	GasOpRegisterNode:            1000,
 	GasOpUnfreezeNode:            1000,
+	GasOpDeregisterNode:          1000,
 	GasOpRegisterRuntime:         1000,
 	GasOpRuntimeEpochMaintenance: 1000,
 	GasOpUpdateKeyManager:        1000,
+	GasOpRuntimeLifecycle:        1000,
 }
 
 const (
@@ -16,34 +16,40 @@ var (
 	serviceName = cmnGrpc.NewServiceName("Registry")
 
 	// methodGetEntity is the GetEntity method.
-	methodGetEntity = serviceName.NewMethod("GetEntity", IDQuery{})
+	methodGetEntity = serviceName.NewMethod("GetEntity", IDQuery{}).WithReadOnly()
 	// methodGetEntities is the GetEntities method.
-	methodGetEntities = serviceName.NewMethod("GetEntities", int64(0))
+	methodGetEntities = serviceName.NewMethod("GetEntities", int64(0)).WithReadOnly()
+	// methodGetEntityMetadata is the GetEntityMetadata method.
+	methodGetEntityMetadata = serviceName.NewMethod("GetEntityMetadata", IDQuery{}).WithReadOnly()
 	// methodGetNode is the GetNode method.
-	methodGetNode = serviceName.NewMethod("GetNode", IDQuery{})
+	methodGetNode = serviceName.NewMethod("GetNode", IDQuery{}).WithReadOnly()
 	// methodGetNodeStatus is the GetNodeStatus method.
-	methodGetNodeStatus = serviceName.NewMethod("GetNodeStatus", IDQuery{})
+	methodGetNodeStatus = serviceName.NewMethod("GetNodeStatus", IDQuery{}).WithReadOnly()
 	// methodGetNodes is the GetNodes method.
-	methodGetNodes = serviceName.NewMethod("GetNodes", int64(0))
+	methodGetNodes = serviceName.NewMethod("GetNodes", int64(0)).WithReadOnly()
 	// methodGetRuntime is the GetRuntime method.
-	methodGetRuntime = serviceName.NewMethod("GetRuntime", NamespaceQuery{})
+	methodGetRuntime = serviceName.NewMethod("GetRuntime", NamespaceQuery{}).WithReadOnly()
+	// methodGetRuntimeStatus is the GetRuntimeStatus method.
+	methodGetRuntimeStatus = serviceName.NewMethod("GetRuntimeStatus", NamespaceQuery{}).WithReadOnly()
 	// methodGetRuntimes is the GetRuntimes method.
-	methodGetRuntimes = serviceName.NewMethod("GetRuntimes", int64(0))
+	methodGetRuntimes = serviceName.NewMethod("GetRuntimes", GetRuntimesQuery{}).WithReadOnly()
 	// methodGetNodeList is the GetNodeList method.
-	methodGetNodeList = serviceName.NewMethod("GetNodeList", int64(0))
+	methodGetNodeList = serviceName.NewMethod("GetNodeList", int64(0)).WithReadOnly()
 	// methodStateToGenesis is the StateToGenesis method.
-	methodStateToGenesis = serviceName.NewMethod("StateToGenesis", int64(0))
+	methodStateToGenesis = serviceName.NewMethod("StateToGenesis", int64(0)).WithReadOnly()
 	// methodGetEvents is the GetEvents method.
-	methodGetEvents = serviceName.NewMethod("GetEvents", int64(0))
+	methodGetEvents = serviceName.NewMethod("GetEvents", int64(0)).WithReadOnly()
 
 	// methodWatchEntities is the WatchEntities method.
-	methodWatchEntities = serviceName.NewMethod("WatchEntities", nil)
+	methodWatchEntities = serviceName.NewMethod("WatchEntities", nil).WithReadOnly()
 	// methodWatchNodes is the WatchNodes method.
-	methodWatchNodes = serviceName.NewMethod("WatchNodes", nil)
+	methodWatchNodes = serviceName.NewMethod("WatchNodes", nil).WithReadOnly()
 	// methodWatchNodeList is the WatchNodeList method.
-	methodWatchNodeList = serviceName.NewMethod("WatchNodeList", nil)
+	methodWatchNodeList = serviceName.NewMethod("WatchNodeList", nil).WithReadOnly()
 	// methodWatchRuntimes is the WatchRuntimes method.
-	methodWatchRuntimes = serviceName.NewMethod("WatchRuntimes", nil)
+	methodWatchRuntimes = serviceName.NewMethod("WatchRuntimes", nil).WithReadOnly()
+	// methodWatchEntityMetadata is the WatchEntityMetadata method.
+	methodWatchEntityMetadata = serviceName.NewMethod("WatchEntityMetadata", nil).WithReadOnly()
 
 	// serviceDesc is the gRPC service descriptor.
 	serviceDesc = grpc.ServiceDesc{
@@ -58,6 +64,10 @@ var (
 				MethodName: methodGetEntities.ShortName(),
 				Handler:    handlerGetEntities,
 			},
+			{
+				MethodName: methodGetEntityMetadata.ShortName(),
+				Handler:    handlerGetEntityMetadata,
+			},
 			{
 				MethodName: methodGetNode.ShortName(),
 				Handler:    handlerGetNode,
@@ -74,6 +84,10 @@ var (
 				MethodName: methodGetRuntime.ShortName(),
 				Handler:    handlerGetRuntime,
 			},
+			{
+				MethodName: methodGetRuntimeStatus.ShortName(),
+				Handler:    handlerGetRuntimeStatus,
+			},
 			{
 				MethodName: methodGetRuntimes.ShortName(),
 				Handler:    handlerGetRuntimes,
@@ -112,6 +126,11 @@ var (
 				Handler:       handlerWatchRuntimes,
 				ServerStreams: true,
 			},
+			{
+				StreamName:    methodWatchEntityMetadata.ShortName(),
+				Handler:       handlerWatchEntityMetadata,
+				ServerStreams: true,
+			},
 		},
 	}
 )
@@ -185,6 +204,29 @@ func handlerGetNode( // nolint: golint
 	return interceptor(ctx, &query, info, handler)
 }
 
+func handlerGetEntityMetadata( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query IDQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetEntityMetadata(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetEntityMetadata.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetEntityMetadata(ctx, req.(*IDQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
 func handlerGetNodeStatus( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -254,27 +296,50 @@ func handlerGetRuntime( // nolint: golint
 	return interceptor(ctx, &query, info, handler)
 }
 
+func handlerGetRuntimeStatus( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query NamespaceQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetRuntimeStatus(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetRuntimeStatus.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetRuntimeStatus(ctx, req.(*NamespaceQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
 func handlerGetRuntimes( // nolint: golint
 	srv interface{},
 	ctx context.Context,
 	dec func(interface{}) error,
 	interceptor grpc.UnaryServerInterceptor,
 ) (interface{}, error) {
-	var height int64
-	if err := dec(&height); err != nil {
+	var query GetRuntimesQuery
+	if err := dec(&query); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(Backend).GetRuntimes(ctx, height)
+		return srv.(Backend).GetRuntimes(ctx, &query)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
 		FullMethod: methodGetRuntimes.FullName(),
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(Backend).GetRuntimes(ctx, req.(int64))
+		return srv.(Backend).GetRuntimes(ctx, req.(*GetRuntimesQuery))
 	}
-	return interceptor(ctx, height, info, handler)
+	return interceptor(ctx, &query, info, handler)
 }
 
 func handlerGetNodeList( // nolint: golint
@@ -374,6 +439,34 @@ func handlerWatchEntities(srv interface{}, stream grpc.ServerStream) error {
 	}
 }
 
+func handlerWatchEntityMetadata(srv interface{}, stream grpc.ServerStream) error {
+	if err := stream.RecvMsg(nil); err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	ch, sub, err := srv.(Backend).WatchEntityMetadata(ctx)
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+
+	for {
+		select {
+		case ev, ok := <-ch:
+			if !ok {
+				return nil
+			}
+
+			if err := stream.SendMsg(ev); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
 func handlerWatchNodes(srv interface{}, stream grpc.ServerStream) error {
 	if err := stream.RecvMsg(nil); err != nil {
 		return err
@@ -459,7 +552,7 @@ func handlerWatchRuntimes(srv interface{}, stream grpc.ServerStream) error {
 }
 
 // RegisterService registers a new registry backend service with the given gRPC server.
-func RegisterService(server *grpc.Server, service Backend) {
+func RegisterService(server cmnGrpc.ServiceRegistrar, service Backend) {
 	server.RegisterService(&serviceDesc, service)
 }
 
@@ -518,6 +611,49 @@ func (c *registryClient) WatchEntities(ctx context.Context) (<-chan *EntityEvent
 	return ch, sub, nil
 }
 
+func (c *registryClient) GetEntityMetadata(ctx context.Context, query *IDQuery) (*SignedEntityMetadata, error) {
+	var rsp SignedEntityMetadata
+	if err := c.conn.Invoke(ctx, methodGetEntityMetadata.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (c *registryClient) WatchEntityMetadata(ctx context.Context) (<-chan *EntityMetadataEvent, pubsub.ClosableSubscription, error) {
+	ctx, sub := pubsub.NewContextSubscription(ctx)
+
+	stream, err := c.conn.NewStream(ctx, &serviceDesc.Streams[4], methodWatchEntityMetadata.FullName())
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = stream.SendMsg(nil); err != nil {
+		return nil, nil, err
+	}
+	if err = stream.CloseSend(); err != nil {
+		return nil, nil, err
+	}
+
+	ch := make(chan *EntityMetadataEvent)
+	go func() {
+		defer close(ch)
+
+		for {
+			var ev EntityMetadataEvent
+			if serr := stream.RecvMsg(&ev); serr != nil {
+				return
+			}
+
+			select {
+			case ch <- &ev:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, sub, nil
+}
+
 func (c *registryClient) GetNode(ctx context.Context, query *IDQuery) (*node.Node, error) {
 	var rsp node.Node
 	if err := c.conn.Invoke(ctx, methodGetNode.FullName(), query, &rsp); err != nil {
@@ -620,9 +756,17 @@ func (c *registryClient) GetRuntime(ctx context.Context, query *NamespaceQuery)
 	return &rsp, nil
 }
 
-func (c *registryClient) GetRuntimes(ctx context.Context, height int64) ([]*Runtime, error) {
+func (c *registryClient) GetRuntimeStatus(ctx context.Context, query *NamespaceQuery) (*RuntimeStatus, error) {
+	var rsp RuntimeStatus
+	if err := c.conn.Invoke(ctx, methodGetRuntimeStatus.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (c *registryClient) GetRuntimes(ctx context.Context, query *GetRuntimesQuery) ([]*Runtime, error) {
 	var rsp []*Runtime
-	if err := c.conn.Invoke(ctx, methodGetRuntimes.FullName(), height, &rsp); err != nil {
+	if err := c.conn.Invoke(ctx, methodGetRuntimes.FullName(), query, &rsp); err != nil {
 		return nil, err
 	}
 	return rsp, nil
@@ -0,0 +1,160 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/mail"
+	"net/url"
+	"regexp"
+
+	"github.com/oasislabs/oasis-core/go/common/cbor"
+	"github.com/oasislabs/oasis-core/go/common/crypto/signature"
+	"github.com/oasislabs/oasis-core/go/common/prettyprint"
+)
+
+const (
+	// MaxEntityMetadataNameLength is the maximum length of the name field.
+	MaxEntityMetadataNameLength = 50
+	// MaxEntityMetadataURLLength is the maximum length of the URL field.
+	MaxEntityMetadataURLLength = 64
+	// MaxEntityMetadataEmailLength is the maximum length of the email field.
+	MaxEntityMetadataEmailLength = 32
+	// MaxEntityMetadataKeybaseLength is the maximum length of the keybase
+	// handle field.
+	MaxEntityMetadataKeybaseLength = 32
+	// MaxEntityMetadataBlobSize is the maximum size of a serialized
+	// metadata document.
+	MaxEntityMetadataBlobSize = 512
+)
+
+var (
+	// EntityMetadataSignatureContext is the context used for entity
+	// metadata documents.
+	EntityMetadataSignatureContext = signature.NewContext("oasis-core/registry: entity metadata")
+
+	keybaseHandleRegexp = regexp.MustCompile("^[a-zA-Z0-9_]+$")
+
+	_ prettyprint.PrettyPrinter = (*SignedEntityMetadata)(nil)
+)
+
+// EntityMetadata is optional, self-reported information about the operator
+// of an entity.
+type EntityMetadata struct {
+	// Serial is the serial number of the metadata document. It must be
+	// increased on each update so that old documents cannot be replayed.
+	Serial uint64 `json:"serial"`
+
+	// Name is the human readable name of the entity operator.
+	Name string `json:"name,omitempty"`
+	// URL is the (HTTPS) URL of the entity operator's website.
+	URL string `json:"url,omitempty"`
+	// Email is the contact email address of the entity operator.
+	Email string `json:"email,omitempty"`
+	// Keybase is the keybase.io handle of the entity operator.
+	Keybase string `json:"keybase,omitempty"`
+}
+
+// ValidateBasic performs basic metadata validity checks.
+func (m *EntityMetadata) ValidateBasic() error {
+	if len(m.Name) > MaxEntityMetadataNameLength {
+		return fmt.Errorf("name too long (max: %d)", MaxEntityMetadataNameLength)
+	}
+	if m.URL != "" {
+		if len(m.URL) > MaxEntityMetadataURLLength {
+			return fmt.Errorf("URL too long (max: %d)", MaxEntityMetadataURLLength)
+		}
+		u, err := url.Parse(m.URL)
+		if err != nil {
+			return fmt.Errorf("malformed URL: %w", err)
+		}
+		if u.Scheme != "https" || u.Host == "" {
+			return fmt.Errorf("URL must be an absolute HTTPS URL")
+		}
+	}
+	if m.Email != "" {
+		if len(m.Email) > MaxEntityMetadataEmailLength {
+			return fmt.Errorf("email too long (max: %d)", MaxEntityMetadataEmailLength)
+		}
+		addr, err := mail.ParseAddress(m.Email)
+		if err != nil {
+			return fmt.Errorf("malformed email: %w", err)
+		}
+		if addr.Address != m.Email {
+			return fmt.Errorf("email must be a bare address")
+		}
+	}
+	if m.Keybase != "" {
+		if len(m.Keybase) > MaxEntityMetadataKeybaseLength {
+			return fmt.Errorf("keybase handle too long (max: %d)", MaxEntityMetadataKeybaseLength)
+		}
+		if !keybaseHandleRegexp.MatchString(m.Keybase) {
+			return fmt.Errorf("malformed keybase handle")
+		}
+	}
+	return nil
+}
+
+// SignedEntityMetadata is a signed blob containing a CBOR-serialized
+// EntityMetadata. The signer is the entity that the metadata describes.
+type SignedEntityMetadata struct {
+	signature.Signed
+}
+
+// Open first verifies the blob signature and then unmarshals the blob.
+func (s *SignedEntityMetadata) Open(metadata *EntityMetadata) error { // nolint: interfacer
+	return s.Signed.Open(EntityMetadataSignatureContext, metadata)
+}
+
+// PrettyPrint writes a pretty-printed representation of the type
+// to the given writer.
+func (s SignedEntityMetadata) PrettyPrint(prefix string, w io.Writer) {
+	var m EntityMetadata
+	if err := cbor.Unmarshal(s.Signed.Blob, &m); err != nil {
+		fmt.Fprintf(w, "%s<malformed: %s>\n", prefix, err)
+		return
+	}
+
+	pp := signature.NewPrettySigned(s.Signed, m)
+	pp.PrettyPrint(prefix, w)
+}
+
+// SignEntityMetadata serializes the metadata document and signs the result.
+func SignEntityMetadata(signer signature.Signer, metadata *EntityMetadata) (*SignedEntityMetadata, error) {
+	signed, err := signature.SignSigned(signer, EntityMetadataSignatureContext, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignedEntityMetadata{
+		Signed: *signed,
+	}, nil
+}
+
+// VerifyEntityMetadataArgs verifies the signed entity metadata document and
+// returns the entity identifier together with the opened metadata.
+func VerifyEntityMetadataArgs(sigMeta *SignedEntityMetadata) (signature.PublicKey, *EntityMetadata, error) {
+	var metadata EntityMetadata
+	if sigMeta == nil {
+		return signature.PublicKey{}, nil, ErrInvalidArgument
+	}
+	if len(sigMeta.Blob) > MaxEntityMetadataBlobSize {
+		return signature.PublicKey{}, nil, fmt.Errorf("%w: metadata too large (max: %d)", ErrInvalidArgument, MaxEntityMetadataBlobSize)
+	}
+	if err := sigMeta.Open(&metadata); err != nil {
+		return signature.PublicKey{}, nil, ErrInvalidSignature
+	}
+	if err := metadata.ValidateBasic(); err != nil {
+		return signature.PublicKey{}, nil, fmt.Errorf("%w: %s", ErrInvalidArgument, err)
+	}
+	return sigMeta.Signature.PublicKey, &metadata, nil
+}
+
+// EntityMetadataEvent is the event that is returned via WatchEntityMetadata
+// to signify entity metadata changes.
+type EntityMetadataEvent struct {
+	// EntityID is the entity whose metadata has changed.
+	EntityID signature.PublicKey `json:"entity_id"`
+	// Metadata is the new signed metadata document or nil in case the
+	// metadata has been removed (e.g., due to entity deregistration).
+	Metadata *SignedEntityMetadata `json:"metadata,omitempty"`
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	memorySigner "github.com/oasislabs/oasis-core/go/common/crypto/signature/signers/memory"
+)
+
+func TestEntityMetadataValidateBasic(t *testing.T) {
+	require := require.New(t)
+
+	for _, tc := range []struct {
+		metadata EntityMetadata
+		valid    bool
+		msg      string
+	}{
+		{EntityMetadata{}, true, "empty metadata should be valid"},
+		{EntityMetadata{Name: "Acme Validators", URL: "https://acme.example.com", Email: "ops@acme.example.com", Keybase: "acme_ops"}, true, "full metadata should be valid"},
+		{EntityMetadata{Name: strings.Repeat("a", MaxEntityMetadataNameLength+1)}, false, "too long name should be invalid"},
+		{EntityMetadata{URL: "http://acme.example.com"}, false, "non-HTTPS URL should be invalid"},
+		{EntityMetadata{URL: "https://"}, false, "URL without host should be invalid"},
+		{EntityMetadata{URL: "https://acme.example.com/" + strings.Repeat("a", MaxEntityMetadataURLLength)}, false, "too long URL should be invalid"},
+		{EntityMetadata{Email: "not an email"}, false, "malformed email should be invalid"},
+		{EntityMetadata{Email: "Acme <ops@acme.example.com>"}, false, "email with display name should be invalid"},
+		{EntityMetadata{Keybase: "acme-ops"}, false, "malformed keybase handle should be invalid"},
+	} {
+		err := tc.metadata.ValidateBasic()
+		if tc.valid {
+			require.NoError(err, tc.msg)
+		} else {
+			require.Error(err, tc.msg)
+		}
+	}
+}
+
+func TestVerifyEntityMetadataArgs(t *testing.T) {
+	require := require.New(t)
+
+	signer := memorySigner.NewTestSigner("registry/api: entity metadata signer")
+	metadata := &EntityMetadata{Serial: 1, Name: "Acme Validators"}
+
+	sigMeta, err := SignEntityMetadata(signer, metadata)
+	require.NoError(err, "SignEntityMetadata")
+
+	id, opened, err := VerifyEntityMetadataArgs(sigMeta)
+	require.NoError(err, "VerifyEntityMetadataArgs")
+	require.Equal(signer.Public(), id, "entity ID should be the signer")
+	require.EqualValues(metadata, opened, "opened metadata should match")
+
+	// Tampering with the blob should invalidate the signature.
+	sigMeta.Blob = append([]byte{}, sigMeta.Blob...)
+	sigMeta.Blob[len(sigMeta.Blob)-1] ^= 0xff
+	_, _, err = VerifyEntityMetadataArgs(sigMeta)
+	require.Equal(ErrInvalidSignature, err, "tampered metadata should be rejected")
+
+	// Invalid metadata should be rejected even if correctly signed.
+	sigMeta, err = SignEntityMetadata(signer, &EntityMetadata{Serial: 2, URL: "ftp://acme.example.com"})
+	require.NoError(err, "SignEntityMetadata")
+	_, _, err = VerifyEntityMetadataArgs(sigMeta)
+	require.Error(err, "invalid metadata should be rejected")
+
+	// Oversized metadata should be rejected before being opened.
+	sigMeta.Blob = make([]byte, MaxEntityMetadataBlobSize+1)
+	_, _, err = VerifyEntityMetadataArgs(sigMeta)
+	require.True(errors.Is(err, ErrInvalidArgument), "oversized metadata should be rejected")
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/oasislabs/oasis-core/go/common/crypto/signature"
 	"github.com/oasislabs/oasis-core/go/common/node"
 	"github.com/oasislabs/oasis-core/go/common/prettyprint"
+	"github.com/oasislabs/oasis-core/go/common/quantity"
 	"github.com/oasislabs/oasis-core/go/common/sgx"
 	"github.com/oasislabs/oasis-core/go/common/version"
 	storage "github.com/oasislabs/oasis-core/go/storage/api"
@@ -25,6 +26,9 @@ var (
 	// ErrMalformedStoreID is the error returned when a storage service
 	// ID is malformed.
 	ErrMalformedStoreID = errors.New("runtime: Malformed store ID")
+	// ErrUnsupportedElectionMode is the error returned when the parsed
+	// committee election mode is malformed or unknown.
+	ErrUnsupportedElectionMode = errors.New("runtime: unsupported committee election mode")
 
 	_ prettyprint.PrettyPrinter = (*SignedRuntime)(nil)
 )
@@ -78,6 +82,59 @@ func (k *RuntimeKind) FromString(str string) error {
 	return nil
 }
 
+// CommitteeElectionMode is the committee election mode.
+type CommitteeElectionMode uint8
+
+const (
+	// ElectionModeUniform elects committee members uniformly at random from
+	// all suitable nodes.
+	ElectionModeUniform CommitteeElectionMode = 0
+
+	// ElectionModeStakeWeighted elects committee members such that the
+	// probability of an entity getting a seat is proportional to its escrow
+	// balance, regardless of the number of nodes it operates.
+	ElectionModeStakeWeighted CommitteeElectionMode = 1
+
+	electionModeUniform       = "uniform"
+	electionModeStakeWeighted = "stake_weighted"
+)
+
+// String returns a string representation of a committee election mode.
+func (m CommitteeElectionMode) String() string {
+	switch m {
+	case ElectionModeUniform:
+		return electionModeUniform
+	case ElectionModeStakeWeighted:
+		return electionModeStakeWeighted
+	default:
+		return "[unsupported election mode]"
+	}
+}
+
+// FromString deserializes a string into a CommitteeElectionMode.
+func (m *CommitteeElectionMode) FromString(str string) error {
+	switch strings.ToLower(str) {
+	case electionModeUniform:
+		*m = ElectionModeUniform
+	case electionModeStakeWeighted:
+		*m = ElectionModeStakeWeighted
+	default:
+		return ErrUnsupportedElectionMode
+	}
+
+	return nil
+}
+
+// IsValid returns true iff the committee election mode is supported.
+func (m CommitteeElectionMode) IsValid() bool {
+	switch m {
+	case ElectionModeUniform, ElectionModeStakeWeighted:
+		return true
+	default:
+		return false
+	}
+}
+
 // ExecutorParameters are parameters for the executor committee.
 type ExecutorParameters struct {
 	// GroupSize is the size of the committee.
@@ -91,6 +148,15 @@ type ExecutorParameters struct {
 
 	// RoundTimeout is the round timeout of the nodes in the group.
 	RoundTimeout time.Duration `json:"round_timeout"`
+
+	// ElectionMode is the election mode used for all compute committees
+	// (executor, merge and transaction scheduler).
+	ElectionMode CommitteeElectionMode `json:"election_mode,omitempty"`
+
+	// MaxNodesPerEntity is the maximum number of nodes of a single entity
+	// in each compute committee (including backup workers). Zero means no
+	// limit.
+	MaxNodesPerEntity uint64 `json:"max_nodes_per_entity,omitempty"`
 }
 
 // MergeParameters are parameters for the merge committee.
@@ -155,6 +221,13 @@ type StorageParameters struct {
 
 	// CheckpointChunkSize is the chunk size parameter for checkpoint creation.
 	CheckpointChunkSize uint64 `json:"checkpoint_chunk_size"`
+
+	// ElectionMode is the election mode used for the storage committee.
+	ElectionMode CommitteeElectionMode `json:"election_mode,omitempty"`
+
+	// MaxNodesPerEntity is the maximum number of nodes of a single entity
+	// in the storage committee. Zero means no limit.
+	MaxNodesPerEntity uint64 `json:"max_nodes_per_entity,omitempty"`
 }
 
 // AnyNodeRuntimeAdmissionPolicy allows any node to register.
@@ -165,10 +238,26 @@ type EntityWhitelistRuntimeAdmissionPolicy struct {
 	Entities map[signature.PublicKey]bool `json:"entities"`
 }
 
+// MinEscrowRuntimeAdmissionPolicy allows only nodes of entities with at least
+// the given amount of stake in escrow to register.
+type MinEscrowRuntimeAdmissionPolicy struct {
+	MinEscrow quantity.Quantity `json:"min_escrow"`
+}
+
+// PerEntityNodeLimitRuntimeAdmissionPolicy limits the number of nodes of each
+// entity that can register for a runtime with a given role.
+type PerEntityNodeLimitRuntimeAdmissionPolicy struct {
+	// Limits is the maximum number of nodes per entity for each (single)
+	// role. Roles that are not present are not limited.
+	Limits map[node.RolesMask]uint64 `json:"limits"`
+}
+
 // RuntimeAdmissionPolicy is a specification of which nodes are allowed to register for a runtime.
 type RuntimeAdmissionPolicy struct {
-	AnyNode         *AnyNodeRuntimeAdmissionPolicy         `json:"any_node,omitempty"`
-	EntityWhitelist *EntityWhitelistRuntimeAdmissionPolicy `json:"entity_whitelist,omitempty"`
+	AnyNode            *AnyNodeRuntimeAdmissionPolicy            `json:"any_node,omitempty"`
+	EntityWhitelist    *EntityWhitelistRuntimeAdmissionPolicy    `json:"entity_whitelist,omitempty"`
+	MinEscrow          *MinEscrowRuntimeAdmissionPolicy          `json:"min_escrow,omitempty"`
+	PerEntityNodeLimit *PerEntityNodeLimitRuntimeAdmissionPolicy `json:"per_entity_node_limit,omitempty"`
 }
 
 const (
@@ -40,12 +40,45 @@ func (g *Genesis) SanityCheck(
 		return err
 	}
 
+	// Check entity metadata.
+	seenMetadata := make(map[signature.PublicKey]bool)
+	for _, sigMeta := range g.EntityMetadata {
+		id, _, merr := VerifyEntityMetadataArgs(sigMeta)
+		if merr != nil {
+			return fmt.Errorf("registry: sanity check failed: entity metadata: %w", merr)
+		}
+		if seenEntities[id] == nil {
+			return fmt.Errorf("registry: sanity check failed: metadata for unknown entity '%s'", id)
+		}
+		if seenMetadata[id] {
+			return fmt.Errorf("registry: sanity check failed: duplicate metadata for entity '%s'", id)
+		}
+		seenMetadata[id] = true
+	}
+
 	// Check runtimes.
 	runtimesLookup, err := SanityCheckRuntimes(logger, &g.Parameters, g.Runtimes, g.SuspendedRuntimes, true)
 	if err != nil {
 		return err
 	}
 
+	// Check runtime statuses.
+	for id, status := range g.RuntimeStatuses {
+		if status == nil {
+			return fmt.Errorf("registry: sanity check failed: runtime status for '%s' is nil", id)
+		}
+		if status.SuspendedByOwner {
+			if _, err = runtimesLookup.SuspendedRuntime(context.Background(), id); err != nil {
+				return fmt.Errorf("registry: sanity check failed: runtime '%s' suspended by owner is not suspended", id)
+			}
+		} else if _, err = runtimesLookup.AnyRuntime(context.Background(), id); err != nil {
+			return fmt.Errorf("registry: sanity check failed: runtime status for unknown runtime '%s'", id)
+		}
+		if status.PendingOwner != nil && seenEntities[*status.PendingOwner] == nil {
+			return fmt.Errorf("registry: sanity check failed: runtime '%s' pending owner is not a registered entity: '%s'", id, *status.PendingOwner)
+		}
+	}
+
 	// Check nodes.
 	nodeLookup, err := SanityCheckNodes(logger, &g.Parameters, g.Nodes, seenEntities, runtimesLookup, true, baseEpoch)
 	if err != nil {
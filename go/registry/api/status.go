@@ -1,7 +1,9 @@
 package api
 
 import (
+	"github.com/oasislabs/oasis-core/go/common"
 	"github.com/oasislabs/oasis-core/go/common/crypto/signature"
+	"github.com/oasislabs/oasis-core/go/common/node"
 	epochtime "github.com/oasislabs/oasis-core/go/epochtime/api"
 )
 
@@ -22,6 +24,13 @@ type NodeStatus struct {
 	// After the specified epoch passes, this flag needs to be explicitly
 	// cleared (set to zero) in order for the node to become unfrozen.
 	FreezeEndTime epochtime.EpochTime `json:"freeze_end_time"`
+	// DeregistrationRequested is a flag specifying whether the node has
+	// requested to be deregistered. Such nodes are no longer scheduled.
+	DeregistrationRequested bool `json:"deregistration_requested,omitempty"`
+	// DeregistrationEpoch is the epoch in which the node requested to be
+	// deregistered. From then on the node is treated as if its descriptor
+	// expired in that epoch.
+	DeregistrationEpoch epochtime.EpochTime `json:"deregistration_epoch,omitempty"`
 }
 
 // IsFrozen returns true if the node is currently frozen (prevented
@@ -30,6 +39,15 @@ func (ns NodeStatus) IsFrozen() bool {
 	return ns.FreezeEndTime > 0
 }
 
+// Expiration returns the effective expiration epoch of the given node,
+// taking a requested deregistration into account.
+func (ns NodeStatus) Expiration(n *node.Node) uint64 {
+	if ns.DeregistrationRequested && uint64(ns.DeregistrationEpoch) < n.Expiration {
+		return uint64(ns.DeregistrationEpoch)
+	}
+	return n.Expiration
+}
+
 // Unfreeze makes the node unfrozen.
 func (ns *NodeStatus) Unfreeze() {
 	ns.FreezeEndTime = 0
@@ -39,3 +57,50 @@ func (ns *NodeStatus) Unfreeze() {
 type UnfreezeNode struct {
 	NodeID signature.PublicKey `json:"node_id"`
 }
+
+// DeregisterNode is a request to deregister a node.
+type DeregisterNode struct {
+	NodeID signature.PublicKey `json:"node_id"`
+}
+
+// RuntimeStatus is live status of a runtime.
+type RuntimeStatus struct {
+	// Suspended is a flag specifying whether the runtime is currently
+	// suspended (either due to unpaid maintenance fees, insufficient
+	// stake or explicitly by its owner).
+	Suspended bool `json:"suspended,omitempty"`
+	// SuspendedByOwner is a flag specifying whether the runtime has been
+	// explicitly suspended by its owner. Such runtimes are not resumed
+	// automatically and must be resumed by the owner.
+	SuspendedByOwner bool `json:"suspended_by_owner,omitempty"`
+	// PendingOwner is the entity that the runtime ownership is being
+	// transferred to, waiting for its acceptance.
+	PendingOwner *signature.PublicKey `json:"pending_owner,omitempty"`
+}
+
+// IsEmpty returns true if the runtime status has no owner-controlled
+// fields set and does not need to be persisted.
+func (rs RuntimeStatus) IsEmpty() bool {
+	return !rs.SuspendedByOwner && rs.PendingOwner == nil
+}
+
+// SuspendRuntime is a request to suspend a runtime.
+type SuspendRuntime struct {
+	RuntimeID common.Namespace `json:"runtime_id"`
+}
+
+// ResumeRuntime is a request to resume a runtime suspended by its owner.
+type ResumeRuntime struct {
+	RuntimeID common.Namespace `json:"runtime_id"`
+}
+
+// TransferRuntimeOwnership is a request to transfer the ownership of a
+// runtime to another entity.
+//
+// The transfer only takes effect after the new owner accepts it by
+// submitting an AcceptRuntimeOwnership transaction. Transferring the
+// ownership to the current owner cancels any pending transfer.
+type TransferRuntimeOwnership struct {
+	RuntimeID common.Namespace    `json:"runtime_id"`
+	NewOwner  signature.PublicKey `json:"new_owner"`
+}
@@ -504,7 +504,7 @@ func testRegistryEntityNodes( // nolint: gocyclo
 func testRegistryRuntime(t *testing.T, backend api.Backend, consensus consensusAPI.Backend) (common.Namespace, common.Namespace) {
 	require := require.New(t)
 
-	existingRuntimes, err := backend.GetRuntimes(context.Background(), consensusAPI.HeightLatest)
+	existingRuntimes, err := backend.GetRuntimes(context.Background(), &api.GetRuntimesQuery{Height: consensusAPI.HeightLatest})
 	require.NoError(err, "GetRuntimes")
 
 	// We must use the test entity for runtime registrations as registering a runtime will prevent
@@ -561,7 +561,7 @@ func testRegistryRuntime(t *testing.T, backend api.Backend, consensus consensusA
 	rtKm.MustRegister(t, backend, consensus)
 	rtMap[rtKm.Runtime.ID] = rtKm.Runtime
 
-	registeredRuntimes, err := backend.GetRuntimes(context.Background(), consensusAPI.HeightLatest)
+	registeredRuntimes, err := backend.GetRuntimes(context.Background(), &api.GetRuntimesQuery{Height: consensusAPI.HeightLatest})
 	require.NoError(err, "GetRuntimes")
 	// NOTE: There can be two runtimes registered here instead of one because the worker
 	//       tests that run before this register their own runtime and this runtime
@@ -584,7 +584,7 @@ func testRegistryRuntime(t *testing.T, backend api.Backend, consensus consensusA
 
 	rtWrongKm.MustNotRegister(t, backend, consensus)
 
-	registeredRuntimesAfterFailures, err := backend.GetRuntimes(context.Background(), consensusAPI.HeightLatest)
+	registeredRuntimesAfterFailures, err := backend.GetRuntimes(context.Background(), &api.GetRuntimesQuery{Height: consensusAPI.HeightLatest})
 	require.NoError(err, "GetRuntimes")
 	require.Len(registeredRuntimesAfterFailures, len(registeredRuntimes), "wrong runtimes not registered")
 
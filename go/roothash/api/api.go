@@ -98,6 +98,12 @@ type Backend interface {
 	Cleanup()
 }
 
+// RuntimeRequest is a request for roothash state of a runtime.
+type RuntimeRequest struct {
+	RuntimeID common.Namespace `json:"runtime_id"`
+	Height    int64            `json:"height"`
+}
+
 // ExecutorCommit is the argument set for the ExecutorCommit method.
 type ExecutorCommit struct {
 	ID      common.Namespace                `json:"id"`
@@ -151,6 +157,8 @@ type MergeDiscrepancyDetectedEvent struct {
 
 // Event is a protocol event.
 type Event struct {
+	TxHash hash.Hash `json:"tx_hash,omitempty"`
+
 	ExecutionDiscrepancyDetected *ExecutionDiscrepancyDetectedEvent `json:"execution_discrepancy,omitempty"`
 	MergeDiscrepancyDetected     *MergeDiscrepancyDetectedEvent     `json:"merge_discrepancy,omitempty"`
 }
@@ -56,6 +56,10 @@ type ComputeBody struct {
 	StorageSignatures []signature.Signature  `json:"storage_signatures"`
 	RakSig            signature.RawSignature `json:"rak_sig"`
 
+	// StorageReceipt is an aggregated storage receipt which may be used instead of individual
+	// storage receipt signatures in StorageSignatures.
+	StorageReceipt *storage.AggregatedReceipt `json:"storage_receipt,omitempty"`
+
 	TxnSchedSig      signature.Signature   `json:"txn_sched_sig"`
 	InputRoot        hash.Hash             `json:"input_root"`
 	InputStorageSigs []signature.Signature `json:"input_storage_sigs"`
@@ -104,6 +108,28 @@ func (m *ComputeBody) VerifyStorageReceiptSignatures(ns common.Namespace, round
 	return nil
 }
 
+// VerifyAggregatedStorageReceipt validates that the aggregated storage receipt
+// matches the header and that it is correctly signed by members of the given
+// storage committee.
+//
+// The committee must be given in the same order as the members of the storage
+// committee as the aggregated receipt refers to signers by their index.
+func (m *ComputeBody) VerifyAggregatedStorageReceipt(ns common.Namespace, round uint64, committee []signature.PublicKey) error {
+	if m.StorageReceipt == nil {
+		return errors.New("roothash: missing aggregated storage receipt")
+	}
+	if len(m.StorageSignatures) > 0 {
+		return errors.New("roothash: both aggregated storage receipt and storage signatures present")
+	}
+	if m.StorageReceipt.Body.Version != 1 {
+		return errors.New("roothash: receipt has unexpected version")
+	}
+	if err := m.VerifyStorageReceipt(ns, round, &m.StorageReceipt.Body); err != nil {
+		return err
+	}
+	return m.StorageReceipt.Verify(committee)
+}
+
 // VerifyStorageReceipt validates that the provided storage receipt
 // matches the header.
 func (m *ComputeBody) VerifyStorageReceipt(ns common.Namespace, round uint64, receipt *storage.ReceiptBody) error {
@@ -43,6 +43,10 @@ type SignatureVerifier interface {
 	// VerifyCommitteeSignatures verifies that the given signatures come from
 	// the current committee members of the given kind.
 	VerifyCommitteeSignatures(kind scheduler.CommitteeKind, sigs []signature.Signature) error
+
+	// CommitteePublicKeys returns the public keys of the current committee
+	// members of the given kind, in committee order.
+	CommitteePublicKeys(kind scheduler.CommitteeKind) ([]signature.PublicKey, error)
 }
 
 // NodeLookup is an interface for looking up registry node descriptors.
@@ -244,21 +248,41 @@ func (p *Pool) addOpenExecutorCommitment(
 	}
 
 	// Check if the header refers to merkle roots in storage.
-	if err := sv.VerifyCommitteeSignatures(scheduler.KindStorage, body.StorageSignatures); err != nil {
-		logger.Debug("executor commitment has bad storage receipt signers",
-			"committee_id", cID,
-			"node_id", id,
-			"err", err,
-		)
-		return err
-	}
-	if err := body.VerifyStorageReceiptSignatures(blk.Header.Namespace, blk.Header.Round+1); err != nil {
-		logger.Debug("executor commitment has bad storage receipt signatures",
-			"committee_id", cID,
-			"node_id", id,
-			"err", err,
-		)
-		return err
+	if body.StorageReceipt != nil {
+		committee, err := sv.CommitteePublicKeys(scheduler.KindStorage)
+		if err != nil {
+			logger.Debug("failed to get storage committee public keys",
+				"committee_id", cID,
+				"node_id", id,
+				"err", err,
+			)
+			return err
+		}
+		if err = body.VerifyAggregatedStorageReceipt(blk.Header.Namespace, blk.Header.Round+1, committee); err != nil {
+			logger.Debug("executor commitment has bad aggregated storage receipt",
+				"committee_id", cID,
+				"node_id", id,
+				"err", err,
+			)
+			return err
+		}
+	} else {
+		if err := sv.VerifyCommitteeSignatures(scheduler.KindStorage, body.StorageSignatures); err != nil {
+			logger.Debug("executor commitment has bad storage receipt signers",
+				"committee_id", cID,
+				"node_id", id,
+				"err", err,
+			)
+			return err
+		}
+		if err := body.VerifyStorageReceiptSignatures(blk.Header.Namespace, blk.Header.Round+1); err != nil {
+			logger.Debug("executor commitment has bad storage receipt signatures",
+				"committee_id", cID,
+				"node_id", id,
+				"err", err,
+			)
+			return err
+		}
 	}
 
 	// Go through existing commitments and check if the txn scheduler signed
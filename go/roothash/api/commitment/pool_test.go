@@ -31,6 +31,10 @@ func (n *nopSignatureVerifier) VerifyCommitteeSignatures(kind scheduler.Committe
 	return nil
 }
 
+func (n *nopSignatureVerifier) CommitteePublicKeys(kind scheduler.CommitteeKind) ([]signature.PublicKey, error) {
+	return nil, nil
+}
+
 type staticSignatureVerifier struct {
 	storagePublicKey      signature.PublicKey
 	txnSchedulerPublicKey signature.PublicKey
@@ -55,6 +59,17 @@ func (n *staticSignatureVerifier) VerifyCommitteeSignatures(kind scheduler.Commi
 	return nil
 }
 
+func (n *staticSignatureVerifier) CommitteePublicKeys(kind scheduler.CommitteeKind) ([]signature.PublicKey, error) {
+	switch kind {
+	case scheduler.KindStorage:
+		return []signature.PublicKey{n.storagePublicKey}, nil
+	case scheduler.KindComputeTxnScheduler:
+		return []signature.PublicKey{n.txnSchedulerPublicKey}, nil
+	default:
+		return nil, errors.New("unsupported committee kind")
+	}
+}
+
 type staticNodeLookup struct {
 	runtime *node.Runtime
 }
@@ -205,6 +220,98 @@ func TestPoolSingleCommitment(t *testing.T) {
 	require.EqualValues(t, &body.Header, &header, "DD should return the same header")
 }
 
+func TestPoolAggregatedStorageReceipt(t *testing.T) {
+	genesisTestHelpers.SetTestChainContext()
+
+	// Generate a non-TEE runtime.
+	var rtID common.Namespace
+	_ = rtID.UnmarshalHex("0000000000000000000000000000000000000000000000000000000000000000")
+
+	rt := &registry.Runtime{
+		DescriptorVersion: registry.LatestRuntimeDescriptorVersion,
+		ID:                rtID,
+		Kind:              registry.KindCompute,
+		TEEHardware:       node.TEEHardwareInvalid,
+	}
+
+	// Generate a commitment signing key.
+	sk, err := memorySigner.NewSigner(rand.Reader)
+	require.NoError(t, err, "NewSigner")
+
+	// Generate a committee.
+	committee := &scheduler.Committee{
+		Kind: scheduler.KindComputeExecutor,
+		Members: []*scheduler.CommitteeNode{
+			&scheduler.CommitteeNode{
+				Role:      scheduler.Worker,
+				PublicKey: sk.Public(),
+			},
+		},
+	}
+
+	// Create a pool.
+	pool := Pool{
+		Runtime:   rt,
+		Committee: committee,
+	}
+
+	// Generate a commitment with an aggregated storage receipt.
+	childBlk, parentBlk, body := generateComputeBody(t, committee)
+	storageSig := body.StorageSignatures[0]
+	body.StorageSignatures = nil
+	body.StorageReceipt = &storage.AggregatedReceipt{
+		Body: storage.ReceiptBody{
+			Version:   1,
+			Namespace: parentBlk.Header.Namespace,
+			Round:     parentBlk.Header.Round,
+			Roots:     body.RootsForStorageReceipt(),
+		},
+		Signers:    []uint16{0},
+		Signatures: []signature.RawSignature{storageSig.Signature},
+	}
+
+	sv := &staticSignatureVerifier{
+		storagePublicKey:      storageSig.PublicKey,
+		txnSchedulerPublicKey: body.TxnSchedSig.PublicKey,
+	}
+	nl := &staticNodeLookup{
+		runtime: &node.Runtime{
+			ID: rtID,
+		},
+	}
+
+	// Adding a commitment with an out of range signer index should fail.
+	bodyInvalidSigner := body
+	bodyInvalidSigner.StorageReceipt = &storage.AggregatedReceipt{
+		Body:       body.StorageReceipt.Body,
+		Signers:    []uint16{1},
+		Signatures: body.StorageReceipt.Signatures,
+	}
+	incorrectCommit, err := SignExecutorCommitment(sk, &bodyInvalidSigner)
+	require.NoError(t, err, "SignExecutorCommitment")
+	err = pool.AddExecutorCommitment(context.Background(), childBlk, sv, nl, incorrectCommit)
+	require.Error(t, err, "AddExecutorCommitment")
+
+	// Adding a commitment with both an aggregated receipt and individual
+	// storage signatures should fail.
+	bodyBoth := body
+	bodyBoth.StorageSignatures = []signature.Signature{storageSig}
+	incorrectCommit, err = SignExecutorCommitment(sk, &bodyBoth)
+	require.NoError(t, err, "SignExecutorCommitment")
+	err = pool.AddExecutorCommitment(context.Background(), childBlk, sv, nl, incorrectCommit)
+	require.Error(t, err, "AddExecutorCommitment")
+
+	// Adding a commitment should succeed.
+	commit, err := SignExecutorCommitment(sk, &body)
+	require.NoError(t, err, "SignExecutorCommitment")
+	err = pool.AddExecutorCommitment(context.Background(), childBlk, sv, nl, commit)
+	require.NoError(t, err, "AddExecutorCommitment")
+
+	// There should be enough executor commitments.
+	err = pool.CheckEnoughCommitments(false)
+	require.NoError(t, err, "CheckEnoughCommitments")
+}
+
 func TestPoolSingleCommitmentTEE(t *testing.T) {
 	genesisTestHelpers.SetTestChainContext()
 
@@ -23,26 +23,26 @@ var (
 	// methodSubmitTx is the SubmitTx method.
 	methodSubmitTx = serviceName.NewMethod("SubmitTx", SubmitTxRequest{})
 	// methodGetGenesisBlock is the GetGenesisBlock method.
-	methodGetGenesisBlock = serviceName.NewMethod("GetGenesisBlock", common.Namespace{})
+	methodGetGenesisBlock = serviceName.NewMethod("GetGenesisBlock", common.Namespace{}).WithReadOnly()
 	// methodGetBlock is the GetBlock method.
-	methodGetBlock = serviceName.NewMethod("GetBlock", GetBlockRequest{})
+	methodGetBlock = serviceName.NewMethod("GetBlock", GetBlockRequest{}).WithReadOnly()
 	// methodGetBlockByHash is the GetBlockByHash method.
-	methodGetBlockByHash = serviceName.NewMethod("GetBlockByHash", GetBlockByHashRequest{})
+	methodGetBlockByHash = serviceName.NewMethod("GetBlockByHash", GetBlockByHashRequest{}).WithReadOnly()
 	// methodGetTx is the GetTx method.
-	methodGetTx = serviceName.NewMethod("GetTx", GetTxRequest{})
+	methodGetTx = serviceName.NewMethod("GetTx", GetTxRequest{}).WithReadOnly()
 	// methodGetTxByBlockHash is the GetTxByBlockHash method.
-	methodGetTxByBlockHash = serviceName.NewMethod("GetTxByBlockHash", GetTxByBlockHashRequest{})
+	methodGetTxByBlockHash = serviceName.NewMethod("GetTxByBlockHash", GetTxByBlockHashRequest{}).WithReadOnly()
 	// methodGetTxs is the GetTxs method.
-	methodGetTxs = serviceName.NewMethod("GetTxs", GetTxsRequest{})
+	methodGetTxs = serviceName.NewMethod("GetTxs", GetTxsRequest{}).WithReadOnly()
 	// methodQueryTx is the QueryTx method.
-	methodQueryTx = serviceName.NewMethod("QueryTx", QueryTxRequest{})
+	methodQueryTx = serviceName.NewMethod("QueryTx", QueryTxRequest{}).WithReadOnly()
 	// methodQueryTxs is the QueryTxs method.
-	methodQueryTxs = serviceName.NewMethod("QueryTxs", QueryTxsRequest{})
+	methodQueryTxs = serviceName.NewMethod("QueryTxs", QueryTxsRequest{}).WithReadOnly()
 	// methodWaitBlockIndexed is the WaitBlockIndexed method.
-	methodWaitBlockIndexed = serviceName.NewMethod("WaitBlockIndexed", WaitBlockIndexedRequest{})
+	methodWaitBlockIndexed = serviceName.NewMethod("WaitBlockIndexed", WaitBlockIndexedRequest{}).WithReadOnly()
 
 	// methodWatchBlocks is the WatchBlocks method.
-	methodWatchBlocks = serviceName.NewMethod("WatchBlocks", common.Namespace{})
+	methodWatchBlocks = serviceName.NewMethod("WatchBlocks", common.Namespace{}).WithReadOnly()
 
 	// serviceDesc is the gRPC service descriptor.
 	serviceDesc = grpc.ServiceDesc{
@@ -399,7 +399,7 @@ func handlerWatchBlocks(srv interface{}, stream grpc.ServerStream) error {
 }
 
 // RegisterService registers a new runtime client service with the given gRPC server.
-func RegisterService(server *grpc.Server, service RuntimeClient) {
+func RegisterService(server cmnGrpc.ServiceRegistrar, service RuntimeClient) {
 	server.RegisterService(&serviceDesc, service)
 }
 
@@ -9,11 +9,14 @@ import (
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/oasislabs/oasis-core/go/common"
 	"github.com/oasislabs/oasis-core/go/common/crypto/hash"
+	"github.com/oasislabs/oasis-core/go/common/crypto/signature"
 	"github.com/oasislabs/oasis-core/go/common/logging"
 	"github.com/oasislabs/oasis-core/go/common/pubsub"
 	consensus "github.com/oasislabs/oasis-core/go/consensus/api"
@@ -22,11 +25,13 @@ import (
 	roothash "github.com/oasislabs/oasis-core/go/roothash/api"
 	"github.com/oasislabs/oasis-core/go/roothash/api/block"
 	"github.com/oasislabs/oasis-core/go/runtime/client/api"
+	"github.com/oasislabs/oasis-core/go/runtime/committee"
 	enclaverpc "github.com/oasislabs/oasis-core/go/runtime/enclaverpc/api"
 	runtimeRegistry "github.com/oasislabs/oasis-core/go/runtime/registry"
 	"github.com/oasislabs/oasis-core/go/runtime/tagindexer"
 	"github.com/oasislabs/oasis-core/go/runtime/transaction"
 	storage "github.com/oasislabs/oasis-core/go/storage/api"
+	"github.com/oasislabs/oasis-core/go/storage/mkvs"
 	txnscheduler "github.com/oasislabs/oasis-core/go/worker/compute/txnscheduler/api"
 )
 
@@ -35,9 +40,19 @@ var (
 	_ enclaverpc.Transport = (*runtimeClient)(nil)
 )
 
+// Flags has the configuration flags.
+var Flags = flag.NewFlagSet("", flag.ContinueOnError)
+
 const (
+	// CfgNodeSelectionPolicy configures the node selection policy used by the runtime client
+	// when submitting transactions to the transaction scheduler committee.
+	CfgNodeSelectionPolicy = "runtime.client.node_selection_policy"
+
 	maxRetryElapsedTime = 60 * time.Second
 	maxRetryInterval    = 10 * time.Second
+
+	// ioTreeSharedCacheSize is the size of the verified node cache shared between I/O trees.
+	ioTreeSharedCacheSize = 64 * 1024 * 1024
 )
 
 type clientCommon struct {
@@ -45,9 +60,17 @@ type clientCommon struct {
 	consensus       consensus.Backend
 	runtimeRegistry runtimeRegistry.Registry
 
+	// ioTreeCache is a verified node cache shared between all I/O trees so that multiple
+	// queries for the same block do not need to fetch the same nodes from storage.
+	ioTreeCache *mkvs.SharedCache
+
 	ctx context.Context
 }
 
+func (c *clientCommon) newTxnTree(ioRoot storage.Root) *transaction.Tree {
+	return transaction.NewTree(c.storage, ioRoot, mkvs.WithSharedCache(c.ioTreeCache))
+}
+
 type submitContext struct {
 	ctx        context.Context
 	cancelFunc func()
@@ -83,15 +106,24 @@ func (c *runtimeClient) doSubmitTxToLeader(
 	submitCtx *submitContext,
 	req *txnscheduler.SubmitTxRequest,
 	client txnscheduler.TransactionScheduler,
+	nodeID signature.PublicKey,
+	committeeClient committee.Client,
 	resultCh chan error,
 ) {
 	defer close(submitCtx.closeCh)
 
 	op := func() error {
+		start := time.Now()
 		_, err := client.SubmitTx(submitCtx.ctx, req)
 		if submitCtx.ctx.Err() != nil {
 			return backoff.Permanent(submitCtx.ctx.Err())
 		}
+		// Communicate the outcome of the request to the node selection policy.
+		committeeClient.UpdateNodeSelectionPolicy(committee.NodeSelectionFeedback{
+			ID:      nodeID,
+			Bad:     err,
+			Latency: time.Since(start),
+		})
 		if errors.Is(err, txnscheduler.ErrNotLeader) || status.Code(err) == codes.Unavailable {
 			return err
 		}
@@ -199,7 +231,7 @@ func (c *runtimeClient) SubmitTx(ctx context.Context, request *api.SubmitTxReque
 				Data:                request.Data,
 				ExpectedEpochNumber: resp.epochNumber,
 			}
-			go c.doSubmitTxToLeader(submitCtx, req, resp.newTxnschedulerClient, submitResultCh)
+			go c.doSubmitTxToLeader(submitCtx, req, resp.newTxnschedulerClient, resp.newTxnschedulerNodeID, watcher.committeeClient, submitResultCh)
 			continue
 		} else if resp.err != nil {
 			return nil, resp.err
@@ -241,7 +273,7 @@ func (c *runtimeClient) getTxnTree(blk *block.Block) *transaction.Tree {
 		Hash:      blk.Header.IORoot,
 	}
 
-	return transaction.NewTree(c.common.storage, ioRoot)
+	return c.common.newTxnTree(ioRoot)
 }
 
 func (c *runtimeClient) getTxnByHash(ctx context.Context, blk *block.Block, txHash hash.Hash) (*transaction.Transaction, error) {
@@ -323,7 +355,7 @@ func (c *runtimeClient) GetTxs(ctx context.Context, request *api.GetTxsRequest)
 	}
 	copy(ioRoot.Namespace[:], request.RuntimeID[:])
 
-	tree := transaction.NewTree(c.common.storage, ioRoot)
+	tree := c.common.newTxnTree(ioRoot)
 	defer tree.Close()
 
 	txs, err := tree.GetTransactions(ctx)
@@ -500,11 +532,17 @@ func New(
 	consensus consensus.Backend,
 	runtimeRegistry runtimeRegistry.Registry,
 ) (api.RuntimeClient, error) {
+	ioTreeCache, err := mkvs.NewSharedCache(ioTreeSharedCacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("runtime/client: failed to create I/O tree cache: %w", err)
+	}
+
 	c := &runtimeClient{
 		common: &clientCommon{
 			storage:         runtimeRegistry.StorageRouter(),
 			consensus:       consensus,
 			runtimeRegistry: runtimeRegistry,
+			ioTreeCache:     ioTreeCache,
 			ctx:             ctx,
 		},
 		watchers:  make(map[common.Namespace]*blockWatcher),
@@ -513,3 +551,9 @@ func New(
 	}
 	return c, nil
 }
+
+func init() {
+	Flags.String(CfgNodeSelectionPolicy, committee.NodeSelectionPolicyRoundRobin, "Runtime client transaction scheduler node selection policy (round-robin, latency)")
+
+	_ = viper.BindPFlags(Flags)
+}
@@ -4,13 +4,15 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/spf13/viper"
+
 	"github.com/oasislabs/oasis-core/go/common"
 	"github.com/oasislabs/oasis-core/go/common/crypto/hash"
+	"github.com/oasislabs/oasis-core/go/common/crypto/signature"
 	"github.com/oasislabs/oasis-core/go/common/service"
 	"github.com/oasislabs/oasis-core/go/epochtime/api"
 	"github.com/oasislabs/oasis-core/go/roothash/api/block"
 	"github.com/oasislabs/oasis-core/go/runtime/committee"
-	"github.com/oasislabs/oasis-core/go/runtime/transaction"
 	scheduler "github.com/oasislabs/oasis-core/go/scheduler/api"
 	storage "github.com/oasislabs/oasis-core/go/storage/api"
 	txnscheduler "github.com/oasislabs/oasis-core/go/worker/compute/txnscheduler/api"
@@ -35,6 +37,7 @@ type watchResult struct {
 	result                []byte
 	err                   error
 	newTxnschedulerClient txnscheduler.TransactionScheduler
+	newTxnschedulerNodeID signature.PublicKey
 	epochNumber           api.EpochTime
 }
 
@@ -65,7 +68,7 @@ func (w *blockWatcher) checkBlock(blk *block.Block) {
 		Hash:      blk.Header.IORoot,
 	}
 
-	tree := transaction.NewTree(w.common.storage, ioRoot)
+	tree := w.common.newTxnTree(ioRoot)
 	defer tree.Close()
 
 	// Check if there's anything interesting in this block.
@@ -130,10 +133,11 @@ func (w *blockWatcher) watch() {
 
 		case newWatch := <-w.newCh:
 			w.watched[*newWatch.id] = newWatch
-			if conn := w.committeeClient.GetConnection(); conn != nil {
-				client := txnscheduler.NewTransactionSchedulerClient(conn)
+			if conn := w.committeeClient.GetConnectionWithMeta(); conn != nil {
+				client := txnscheduler.NewTransactionSchedulerClient(conn.ClientConn)
 				res := &watchResult{
 					newTxnschedulerClient: client,
+					newTxnschedulerNodeID: conn.Node.ID,
 					epochNumber:           currentEpochNumber,
 				}
 				if newWatch.send(res) != nil {
@@ -179,14 +183,15 @@ func (w *blockWatcher) watch() {
 				w.Logger.Error("error waiting for committee update to complete",
 					"err", err)
 			}
-			conn := w.committeeClient.GetConnection()
+			conn := w.committeeClient.GetConnectionWithMeta()
 			if conn != nil {
-				client := txnscheduler.NewTransactionSchedulerClient(conn)
+				client := txnscheduler.NewTransactionSchedulerClient(conn.ClientConn)
 
 				// Tell every client to resubmit as nothing further can be finalized by this committee.
 				for key, watch := range w.watched {
 					res := &watchResult{
 						newTxnschedulerClient: client,
+						newTxnschedulerNodeID: conn.Node.ID,
 						epochNumber:           currentEpochNumber,
 					}
 					if watch.send(res) != nil {
@@ -232,7 +237,12 @@ func newWatcher(common *clientCommon, id common.Namespace) (*blockWatcher, error
 		return nil, fmt.Errorf("client/watcher: failed to create committee watcher: %w", err)
 	}
 
-	committeeClient, err := committee.NewClient(common.ctx, committeeWatcher.Nodes())
+	policy, err := committee.NewNodeSelectionPolicy(viper.GetString(CfgNodeSelectionPolicy), "runtime-client", id)
+	if err != nil {
+		return nil, fmt.Errorf("client/watcher: failed to create node selection policy: %w", err)
+	}
+
+	committeeClient, err := committee.NewClient(common.ctx, committeeWatcher.Nodes(), committee.WithNodeSelectionPolicy(policy))
 	if err != nil {
 		return nil, fmt.Errorf("client/watcher: failed to create committee client: %w", err)
 	}
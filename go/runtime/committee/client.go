@@ -6,6 +6,8 @@ import (
 	"crypto/tls"
 	"fmt"
 	"math/rand"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -13,6 +15,7 @@ import (
 	"google.golang.org/grpc/resolver"
 	"google.golang.org/grpc/resolver/manual"
 
+	"github.com/oasislabs/oasis-core/go/common"
 	"github.com/oasislabs/oasis-core/go/common/crypto/mathrand"
 	"github.com/oasislabs/oasis-core/go/common/crypto/signature"
 	cmnGrpc "github.com/oasislabs/oasis-core/go/common/grpc"
@@ -32,6 +35,10 @@ type NodeSelectionFeedback struct {
 	// Bad being non-nil signals that the currently selected node is bad and contains the reason
 	// that lead to the decision.
 	Bad error
+
+	// Latency is the observed latency of a request to the node. A zero value means that no
+	// latency has been measured.
+	Latency time.Duration
 }
 
 // NodeSelectionPolicy is a node selection policy.
@@ -45,6 +52,10 @@ type NodeSelectionPolicy interface {
 
 	// Pick picks a node from the set of available nodes accoording to the policy.
 	Pick() signature.PublicKey
+
+	// Rank orders the given nodes according to the policy, with the most preferred node first.
+	// Nodes which are not known to the policy are ranked last.
+	Rank(nodes []signature.PublicKey) []signature.PublicKey
 }
 
 type roundRobinNodeSelectionPolicy struct {
@@ -91,6 +102,27 @@ func (rr *roundRobinNodeSelectionPolicy) Pick() signature.PublicKey {
 	return rr.nodes[rr.index]
 }
 
+func (rr *roundRobinNodeSelectionPolicy) Rank(nodes []signature.PublicKey) []signature.PublicKey {
+	rr.Lock()
+	defer rr.Unlock()
+
+	// Rank nodes in the order in which they would be picked, starting at the current node.
+	order := make(map[signature.PublicKey]int)
+	for idx, n := range rr.nodes {
+		order[n] = (idx - rr.index + len(rr.nodes)) % len(rr.nodes)
+	}
+	ranked := append([]signature.PublicKey{}, nodes...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		oi, iok := order[ranked[i]]
+		oj, jok := order[ranked[j]]
+		if iok != jok {
+			return iok
+		}
+		return oi < oj
+	})
+	return ranked
+}
+
 func (rr *roundRobinNodeSelectionPolicy) UpdatePolicy(feedback NodeSelectionFeedback) {
 	if feedback.Bad == nil {
 		// Don't rotate nodes if the feedback was good.
@@ -113,6 +145,27 @@ func NewRoundRobinNodeSelectionPolicy() NodeSelectionPolicy {
 	return &roundRobinNodeSelectionPolicy{}
 }
 
+const (
+	// NodeSelectionPolicyRoundRobin is the name of the round-robin node selection policy.
+	NodeSelectionPolicyRoundRobin = "round-robin"
+	// NodeSelectionPolicyLatency is the name of the latency-aware node selection policy.
+	NodeSelectionPolicyLatency = "latency"
+)
+
+// NewNodeSelectionPolicy creates a new node selection policy given its name.
+//
+// The client name and runtime identifier are used to label any metrics exported by the policy.
+func NewNodeSelectionPolicy(name, client string, runtimeID common.Namespace) (NodeSelectionPolicy, error) {
+	switch strings.ToLower(name) {
+	case NodeSelectionPolicyRoundRobin:
+		return NewRoundRobinNodeSelectionPolicy(), nil
+	case NodeSelectionPolicyLatency:
+		return NewLatencyNodeSelectionPolicy(client, runtimeID), nil
+	default:
+		return nil, fmt.Errorf("committee: unsupported node selection policy: '%s'", name)
+	}
+}
+
 // ClientConnWithMeta is a gRPC client connection together with node metadata.
 type ClientConnWithMeta struct {
 	*grpc.ClientConn
@@ -130,11 +183,22 @@ type Client interface {
 	// metadata for each connection.
 	GetConnectionsWithMeta() []*ClientConnWithMeta
 
+	// GetRankedConnectionsWithMeta returns the set of connections to active committee nodes
+	// including node metadata for each connection, ordered by the configured node selection
+	// policy with the most preferred node first.
+	GetRankedConnectionsWithMeta() []*ClientConnWithMeta
+
 	// GetConnection returns a connection based on the configured node selection policy.
 	//
 	// If no connections are available this method will return nil.
 	GetConnection() *grpc.ClientConn
 
+	// GetConnectionWithMeta returns a connection based on the configured node selection policy
+	// including node metadata for the connection.
+	//
+	// If no connections are available this method will return nil.
+	GetConnectionWithMeta() *ClientConnWithMeta
+
 	// UpdateNodeSelectionPolicy submits feedback to the policy which can cause the policy to update
 	// its current node selection.
 	UpdateNodeSelectionPolicy(feedback NodeSelectionFeedback)
@@ -236,7 +300,35 @@ func (cc *committeeClient) GetConnectionsWithMeta() []*ClientConnWithMeta {
 	return conns
 }
 
+func (cc *committeeClient) GetRankedConnectionsWithMeta() []*ClientConnWithMeta {
+	cc.RLock()
+	defer cc.RUnlock()
+
+	ids := make([]signature.PublicKey, 0, len(cc.conns))
+	for id := range cc.conns {
+		ids = append(ids, id)
+	}
+
+	var conns []*ClientConnWithMeta
+	for _, id := range cc.nodeSelectionPolicy.Rank(ids) {
+		c := cc.conns[id]
+		conns = append(conns, &ClientConnWithMeta{
+			ClientConn: c.conn,
+			Node:       c.node,
+		})
+	}
+	return conns
+}
+
 func (cc *committeeClient) GetConnection() *grpc.ClientConn {
+	conn := cc.GetConnectionWithMeta()
+	if conn == nil {
+		return nil
+	}
+	return conn.ClientConn
+}
+
+func (cc *committeeClient) GetConnectionWithMeta() *ClientConnWithMeta {
 	cc.RLock()
 	defer cc.RUnlock()
 
@@ -250,7 +342,10 @@ func (cc *committeeClient) GetConnection() *grpc.ClientConn {
 		// Node selection policy may not have been updated yet.
 		return nil
 	}
-	return c.conn
+	return &ClientConnWithMeta{
+		ClientConn: c.conn,
+		Node:       c.node,
+	}
 }
 
 func (cc *committeeClient) UpdateNodeSelectionPolicy(feedback NodeSelectionFeedback) {
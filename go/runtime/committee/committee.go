@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"sync"
 
 	"github.com/oasislabs/oasis-core/go/common"
 	"github.com/oasislabs/oasis-core/go/common/crypto/hash"
@@ -19,11 +20,16 @@ type Watcher interface {
 	// Nodes returns a node descriptor lookup interface that watches all nodes in the committee.
 	Nodes() NodeDescriptorLookup
 
+	// Committee returns the current (unfiltered) committee or nil if no committee is available.
+	Committee() *scheduler.Committee
+
 	// EpochTransition signals an epoch transition to the committee watcher.
 	EpochTransition(ctx context.Context, height int64) error
 }
 
 type committeeWatcher struct { // nolint: maligned
+	sync.RWMutex
+
 	nw        NodeDescriptorWatcher
 	scheduler scheduler.Backend
 
@@ -34,6 +40,7 @@ type committeeWatcher struct { // nolint: maligned
 	autoEpoch bool
 
 	lastCommitteeID hash.Hash
+	committee       *scheduler.Committee
 
 	logger *logging.Logger
 }
@@ -42,6 +49,13 @@ func (cw *committeeWatcher) Nodes() NodeDescriptorLookup {
 	return cw.nw
 }
 
+func (cw *committeeWatcher) Committee() *scheduler.Committee {
+	cw.RLock()
+	defer cw.RUnlock()
+
+	return cw.committee
+}
+
 func (cw *committeeWatcher) EpochTransition(ctx context.Context, height int64) (err error) {
 	if cw.autoEpoch {
 		return fmt.Errorf("committee: manual epoch transition not allowed when automatic is enabled")
@@ -82,11 +96,17 @@ func (cw *committeeWatcher) EpochTransition(ctx context.Context, height int64) (
 
 func (cw *committeeWatcher) update(ctx context.Context, version int64, committee *scheduler.Committee) (err error) {
 	defer func() {
+		cw.Lock()
+		defer cw.Unlock()
+
 		// Make sure to not watch any nodes in case we fail to update the committee.
 		if err != nil {
 			cw.lastCommitteeID.Empty()
 			cw.nw.Reset()
+			cw.committee = nil
+			return
 		}
+		cw.committee = committee
 	}()
 
 	var filtered []*scheduler.CommitteeNode
@@ -0,0 +1,285 @@
+package committee
+
+import (
+	cryptorand "crypto/rand"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/oasislabs/oasis-core/go/common"
+	"github.com/oasislabs/oasis-core/go/common/crypto/mathrand"
+	"github.com/oasislabs/oasis-core/go/common/crypto/signature"
+)
+
+const (
+	// latencyEWMAWeight is the weight of a new latency sample in the moving average.
+	latencyEWMAWeight = 0.2
+	// errorRateEWMAWeight is the weight of a new success/failure sample in the moving average.
+	errorRateEWMAWeight = 0.1
+	// maxHealthyErrorRate is the error rate above which a node is considered unhealthy. Unhealthy
+	// nodes are only picked if no healthy nodes are available or when probing.
+	maxHealthyErrorRate = 0.5
+	// errorRatePenalty is the factor by which the error rate increases the score of a node.
+	errorRatePenalty = 10.0
+	// defaultProbeInterval is the default interval after which a node other than the best one
+	// is picked in order to refresh its statistics.
+	defaultProbeInterval = 30 * time.Second
+)
+
+var (
+	nodeSelectionLatency = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "oasis_committee_node_latency",
+			Help: "Moving average of committee node request latency (seconds).",
+		},
+		[]string{"client", "runtime", "node"},
+	)
+	nodeSelectionErrorRate = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "oasis_committee_node_error_rate",
+			Help: "Moving average of committee node request error rate.",
+		},
+		[]string{"client", "runtime", "node"},
+	)
+	nodeSelectionScore = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "oasis_committee_node_score",
+			Help: "Committee node selection score (lower is better).",
+		},
+		[]string{"client", "runtime", "node"},
+	)
+
+	nodeSelectionCollectors = []prometheus.Collector{
+		nodeSelectionLatency,
+		nodeSelectionErrorRate,
+		nodeSelectionScore,
+	}
+
+	metricsOnce sync.Once
+)
+
+type nodeStats struct {
+	// latency is the moving average of request latency in seconds.
+	latency float64
+	// errorRate is the moving average of the request error rate.
+	errorRate float64
+	// hasLatency is true iff at least one latency sample has been observed.
+	hasLatency bool
+	// lastPicked is the time when the node has last been picked.
+	lastPicked time.Time
+}
+
+func (s *nodeStats) isHealthy() bool {
+	return s.errorRate <= maxHealthyErrorRate
+}
+
+func (s *nodeStats) score() float64 {
+	return s.latency * (1 + errorRatePenalty*s.errorRate)
+}
+
+// rankScore is the score used for ranking nodes. Nodes without any latency samples have the
+// lowest possible score so that they get measured.
+func (s *nodeStats) rankScore() float64 {
+	if !s.hasLatency {
+		return 0
+	}
+	return s.score()
+}
+
+type latencyNodeSelectionPolicy struct {
+	sync.Mutex
+
+	client        string
+	runtimeID     string
+	probeInterval time.Duration
+
+	nodes     []signature.PublicKey
+	stats     map[signature.PublicKey]*nodeStats
+	current   signature.PublicKey
+	lastProbe time.Time
+
+	rng *rand.Rand
+}
+
+func (lp *latencyNodeSelectionPolicy) labels(id signature.PublicKey) prometheus.Labels {
+	return prometheus.Labels{
+		"client":  lp.client,
+		"runtime": lp.runtimeID,
+		"node":    id.String(),
+	}
+}
+
+func (lp *latencyNodeSelectionPolicy) UpdateNodes(nodes []signature.PublicKey) {
+	// Randomly shuffle the nodes so that nodes without statistics are not always probed in
+	// the same order.
+	nodes = append([]signature.PublicKey{}, nodes...)
+	lp.rng.Shuffle(len(nodes), func(i, j int) {
+		nodes[i], nodes[j] = nodes[j], nodes[i]
+	})
+
+	lp.Lock()
+	defer lp.Unlock()
+
+	// Keep statistics for nodes which are still available.
+	stats := make(map[signature.PublicKey]*nodeStats)
+	for _, id := range nodes {
+		if s := lp.stats[id]; s != nil {
+			stats[id] = s
+		} else {
+			stats[id] = &nodeStats{}
+		}
+	}
+	for id := range lp.stats {
+		if stats[id] == nil {
+			labels := lp.labels(id)
+			nodeSelectionLatency.Delete(labels)
+			nodeSelectionErrorRate.Delete(labels)
+			nodeSelectionScore.Delete(labels)
+		}
+	}
+
+	lp.nodes = nodes
+	lp.stats = stats
+}
+
+func (lp *latencyNodeSelectionPolicy) UpdatePolicy(feedback NodeSelectionFeedback) {
+	lp.Lock()
+	defer lp.Unlock()
+
+	// Feedback without a node identifier refers to the currently selected node.
+	id := feedback.ID
+	if id.Equal(signature.PublicKey{}) {
+		id = lp.current
+	}
+	s := lp.stats[id]
+	if s == nil {
+		return
+	}
+
+	var errorSample float64
+	if feedback.Bad != nil {
+		errorSample = 1.0
+	}
+	s.errorRate = errorRateEWMAWeight*errorSample + (1-errorRateEWMAWeight)*s.errorRate
+
+	if feedback.Bad == nil && feedback.Latency > 0 {
+		latency := feedback.Latency.Seconds()
+		if s.hasLatency {
+			s.latency = latencyEWMAWeight*latency + (1-latencyEWMAWeight)*s.latency
+		} else {
+			s.latency = latency
+			s.hasLatency = true
+		}
+	}
+
+	labels := lp.labels(id)
+	nodeSelectionLatency.With(labels).Set(s.latency)
+	nodeSelectionErrorRate.With(labels).Set(s.errorRate)
+	nodeSelectionScore.With(labels).Set(s.score())
+}
+
+func (lp *latencyNodeSelectionPolicy) Pick() signature.PublicKey {
+	lp.Lock()
+	defer lp.Unlock()
+
+	if len(lp.nodes) == 0 {
+		return signature.PublicKey{}
+	}
+
+	best := lp.bestLocked()
+	picked := best
+
+	// Periodically probe the node that has not been picked for the longest time so that its
+	// statistics are kept up to date.
+	now := time.Now()
+	if len(lp.nodes) > 1 && now.Sub(lp.lastProbe) >= lp.probeInterval {
+		var oldest *signature.PublicKey
+		for i, id := range lp.nodes {
+			if id.Equal(best) {
+				continue
+			}
+			if oldest == nil || lp.stats[id].lastPicked.Before(lp.stats[*oldest].lastPicked) {
+				oldest = &lp.nodes[i]
+			}
+		}
+		picked = *oldest
+		lp.lastProbe = now
+	}
+
+	lp.stats[picked].lastPicked = now
+	lp.current = picked
+	return picked
+}
+
+func (lp *latencyNodeSelectionPolicy) Rank(nodes []signature.PublicKey) []signature.PublicKey {
+	lp.Lock()
+	defer lp.Unlock()
+
+	ranked := append([]signature.PublicKey{}, nodes...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		si, sj := lp.stats[ranked[i]], lp.stats[ranked[j]]
+		if (si == nil) != (sj == nil) {
+			return sj == nil
+		}
+		if si == nil {
+			return false
+		}
+		return betterThan(si, sj)
+	})
+	return ranked
+}
+
+// bestLocked returns the node with the best score, preferring healthy nodes. Nodes without any
+// latency samples are preferred so that they get measured.
+func (lp *latencyNodeSelectionPolicy) bestLocked() signature.PublicKey {
+	var (
+		best      signature.PublicKey
+		bestStats *nodeStats
+	)
+	for _, id := range lp.nodes {
+		s := lp.stats[id]
+		if bestStats == nil || betterThan(s, bestStats) {
+			best = id
+			bestStats = s
+		}
+	}
+	return best
+}
+
+// betterThan returns true iff the node with statistics a should be preferred over the node with
+// statistics b. Healthy nodes are preferred over unhealthy ones and nodes without any latency
+// samples are preferred so that they get measured.
+func betterThan(a, b *nodeStats) bool {
+	aHealthy, bHealthy := a.isHealthy(), b.isHealthy()
+	if aHealthy != bHealthy {
+		return aHealthy
+	}
+	return a.rankScore() < b.rankScore()
+}
+
+// NewLatencyNodeSelectionPolicy creates a new latency-aware node selection policy.
+//
+// The policy tracks moving averages of request latency and error rate of each node based on the
+// submitted feedback and picks the healthy node with the lowest latency. Nodes with high error
+// rates are only picked if no healthy nodes are available. In order to keep statistics for other
+// nodes up to date, the node that has not been picked for the longest time is periodically
+// picked instead.
+//
+// The client name and runtime identifier are only used to label the exported metrics.
+func NewLatencyNodeSelectionPolicy(client string, runtimeID common.Namespace) NodeSelectionPolicy {
+	metricsOnce.Do(func() {
+		prometheus.MustRegister(nodeSelectionCollectors...)
+	})
+
+	return &latencyNodeSelectionPolicy{
+		client:        client,
+		runtimeID:     runtimeID.String(),
+		probeInterval: defaultProbeInterval,
+		lastProbe:     time.Now(),
+		stats:         make(map[signature.PublicKey]*nodeStats),
+		rng:           rand.New(mathrand.New(cryptorand.Reader)),
+	}
+}
@@ -0,0 +1,80 @@
+package committee
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasislabs/oasis-core/go/common"
+	"github.com/oasislabs/oasis-core/go/common/crypto/signature"
+)
+
+func TestLatencyNodeSelectionPolicy(t *testing.T) {
+	require := require.New(t)
+
+	var fast, slow, failing signature.PublicKey
+	_ = fast.UnmarshalHex("0000000000000000000000000000000000000000000000000000000000000001")
+	_ = slow.UnmarshalHex("0000000000000000000000000000000000000000000000000000000000000002")
+	_ = failing.UnmarshalHex("0000000000000000000000000000000000000000000000000000000000000003")
+
+	policy := NewLatencyNodeSelectionPolicy("test", common.Namespace{})
+	lp := policy.(*latencyNodeSelectionPolicy)
+	// Disable probing for the first part of the test.
+	lp.probeInterval = time.Hour
+
+	require.Equal(signature.PublicKey{}, policy.Pick(), "Pick without nodes should return an empty key")
+
+	policy.UpdateNodes([]signature.PublicKey{fast, slow, failing})
+
+	for i := 0; i < 10; i++ {
+		policy.UpdatePolicy(NodeSelectionFeedback{ID: fast, Latency: 10 * time.Millisecond})
+		policy.UpdatePolicy(NodeSelectionFeedback{ID: slow, Latency: 100 * time.Millisecond})
+		policy.UpdatePolicy(NodeSelectionFeedback{ID: failing, Latency: 1 * time.Millisecond})
+	}
+	require.Equal(failing, policy.Pick(), "Pick should return the node with the lowest latency")
+
+	for i := 0; i < 10; i++ {
+		policy.UpdatePolicy(NodeSelectionFeedback{ID: failing, Bad: errors.New("bad node")})
+	}
+	require.Equal(fast, policy.Pick(), "Pick should skip unhealthy nodes")
+
+	var unknown signature.PublicKey
+	_ = unknown.UnmarshalHex("0000000000000000000000000000000000000000000000000000000000000004")
+	require.Equal(
+		[]signature.PublicKey{fast, slow, failing, unknown},
+		policy.Rank([]signature.PublicKey{unknown, failing, slow, fast}),
+		"Rank should order nodes by health and latency and rank unknown nodes last",
+	)
+
+	// Feedback without an identifier should apply to the last picked node.
+	for i := 0; i < 20; i++ {
+		policy.UpdatePolicy(NodeSelectionFeedback{Latency: time.Second})
+	}
+	require.Equal(slow, policy.Pick(), "Pick should take anonymous feedback into account")
+
+	// Probing should pick the node that was not picked for the longest time.
+	lp.probeInterval = 0
+	require.Equal(failing, policy.Pick(), "Pick should probe other nodes")
+
+	// Removed nodes should not be picked anymore.
+	lp.probeInterval = time.Hour
+	policy.UpdateNodes([]signature.PublicKey{fast, failing})
+	require.Equal(fast, policy.Pick(), "Pick should only return available nodes")
+}
+
+func TestNewNodeSelectionPolicy(t *testing.T) {
+	require := require.New(t)
+
+	policy, err := NewNodeSelectionPolicy(NodeSelectionPolicyRoundRobin, "test", common.Namespace{})
+	require.NoError(err, "NewNodeSelectionPolicy")
+	require.IsType(&roundRobinNodeSelectionPolicy{}, policy)
+
+	policy, err = NewNodeSelectionPolicy(NodeSelectionPolicyLatency, "test", common.Namespace{})
+	require.NoError(err, "NewNodeSelectionPolicy")
+	require.IsType(&latencyNodeSelectionPolicy{}, policy)
+
+	_, err = NewNodeSelectionPolicy("invalid", "test", common.Namespace{})
+	require.Error(err, "NewNodeSelectionPolicy should fail for unknown policies")
+}
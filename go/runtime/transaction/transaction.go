@@ -157,10 +157,13 @@ type Tree struct {
 }
 
 // NewTree creates a new transaction artifacts tree.
-func NewTree(rs syncer.ReadSyncer, ioRoot node.Root) *Tree {
+//
+// Any additional options are passed to the underlying MKVS tree.
+func NewTree(rs syncer.ReadSyncer, ioRoot node.Root, options ...mkvs.Option) *Tree {
+	options = append([]mkvs.Option{mkvs.Capacity(50000, 16*1024*1024)}, options...)
 	return &Tree{
 		ioRoot: ioRoot,
-		tree:   mkvs.NewWithRoot(rs, nil, ioRoot, mkvs.Capacity(50000, 16*1024*1024)),
+		tree:   mkvs.NewWithRoot(rs, nil, ioRoot, options...),
 	}
 }
 
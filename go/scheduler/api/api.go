@@ -10,12 +10,50 @@ import (
 	"github.com/oasislabs/oasis-core/go/common"
 	"github.com/oasislabs/oasis-core/go/common/crypto/hash"
 	"github.com/oasislabs/oasis-core/go/common/crypto/signature"
+	"github.com/oasislabs/oasis-core/go/common/errors"
 	"github.com/oasislabs/oasis-core/go/common/pubsub"
 	"github.com/oasislabs/oasis-core/go/common/quantity"
+	"github.com/oasislabs/oasis-core/go/consensus/api/transaction"
 	epochtime "github.com/oasislabs/oasis-core/go/epochtime/api"
 	"github.com/oasislabs/oasis-core/go/oasis-node/cmd/common/flags"
 )
 
+// ModuleName is a unique module name for the scheduler module.
+const ModuleName = "scheduler"
+
+// MaxCommitteeReplayEpochs is the maximum number of preceding epochs whose
+// committees can be replayed by WatchCommitteesFromEpoch.
+const MaxCommitteeReplayEpochs = 256
+
+var (
+	// ErrNoSuchElection is the error returned when the election results for
+	// a given epoch are not available.
+	ErrNoSuchElection = errors.New(ModuleName, 1, "scheduler: no such election")
+
+	// ErrInvalidArgument is the error returned on malformed argument(s).
+	ErrInvalidArgument = errors.New(ModuleName, 2, "scheduler: invalid argument")
+
+	// ErrNotJailed is the error returned when trying to unjail a node that
+	// is not jailed.
+	ErrNotJailed = errors.New(ModuleName, 3, "scheduler: node is not jailed")
+
+	// ErrJailed is the error returned when trying to unjail a node before
+	// its minimum jail period has elapsed.
+	ErrJailed = errors.New(ModuleName, 4, "scheduler: node is still jailed")
+
+	// ErrForbidden is the error returned when an operation is forbidden by
+	// policy.
+	ErrForbidden = errors.New(ModuleName, 5, "scheduler: forbidden by policy")
+
+	// MethodUnjail is the method name for unjailing validator nodes.
+	MethodUnjail = transaction.NewMethodName(ModuleName, "Unjail", Unjail{})
+
+	// Methods is the list of all methods supported by the scheduler backend.
+	Methods = []transaction.MethodName{
+		MethodUnjail,
+	}
+)
+
 // Role is the role a given node plays in a committee.
 type Role uint8
 
@@ -198,6 +236,34 @@ type Backend interface {
 	// be sent immediately.
 	WatchCommittees(ctx context.Context) (<-chan *Committee, pubsub.ClosableSubscription, error)
 
+	// GetValidatorsAtEpoch returns the vector of consensus validators
+	// elected for the given epoch.
+	//
+	// Election results are archived by the node as they happen so they
+	// remain available even after the consensus state has been pruned.
+	// If the results for the given epoch were never archived and the
+	// consensus state has been pruned, ErrNoSuchElection is returned.
+	GetValidatorsAtEpoch(ctx context.Context, epoch epochtime.EpochTime) ([]*Validator, error)
+
+	// GetCommitteesAtEpoch returns the vector of committees for a given
+	// runtime ID elected for the given epoch.
+	GetCommitteesAtEpoch(ctx context.Context, request *GetCommitteesAtEpochRequest) ([]*Committee, error)
+
+	// WatchCommitteesFromEpoch returns a channel that produces a stream
+	// of Committee.
+	//
+	// Upon subscription, all committees elected for epochs starting with
+	// the given epoch (up to and including the current epoch) will be sent
+	// immediately in epoch order. If the election results for any of the
+	// preceding epochs are not available, ErrNoSuchElection is returned.
+	// At most MaxCommitteeReplayEpochs preceding epochs can be replayed,
+	// otherwise ErrInvalidArgument is returned.
+	WatchCommitteesFromEpoch(ctx context.Context, epoch epochtime.EpochTime) (<-chan *Committee, pubsub.ClosableSubscription, error)
+
+	// GetJailedNodes returns the jail status of all validator nodes that
+	// are jailed at the specified block height.
+	GetJailedNodes(ctx context.Context, height int64) ([]*JailStatus, error)
+
 	// StateToGenesis returns the genesis state at specified block height.
 	StateToGenesis(ctx context.Context, height int64) (*Genesis, error)
 
@@ -211,10 +277,60 @@ type GetCommitteesRequest struct {
 	RuntimeID common.Namespace `json:"runtime_id"`
 }
 
+// GetCommitteesAtEpochRequest is a GetCommitteesAtEpoch request.
+type GetCommitteesAtEpochRequest struct {
+	Epoch     epochtime.EpochTime `json:"epoch"`
+	RuntimeID common.Namespace    `json:"runtime_id"`
+}
+
+// Election is the result of the elections performed at an epoch
+// transition.
+type Election struct {
+	// Epoch is the epoch the election was performed for.
+	Epoch epochtime.EpochTime `json:"epoch"`
+	// Height is the consensus block height at which the election was
+	// performed.
+	Height int64 `json:"height"`
+
+	// Validators is the elected consensus validator set.
+	Validators []*Validator `json:"validators"`
+	// Committees are all the elected committees.
+	Committees []*Committee `json:"committees"`
+}
+
+// JailStatus is the jail status of a validator node that has been
+// jailed for missing too many blocks.
+type JailStatus struct {
+	// NodeID is the identifier of the jailed node.
+	NodeID signature.PublicKey `json:"node_id"`
+	// JailedAt is the epoch in which the node has been jailed.
+	JailedAt epochtime.EpochTime `json:"jailed_at"`
+}
+
+// CanUnjail returns true iff the node can be unjailed in the given epoch.
+func (s *JailStatus) CanUnjail(params *ConsensusParameters, epoch epochtime.EpochTime) bool {
+	return epoch >= s.JailedAt+params.ValidatorJailEpochs
+}
+
+// Unjail is a request to lift the jailing of a validator node, making it
+// eligible for validator elections again.
+type Unjail struct {
+	// NodeID is the identifier of the node to unjail.
+	NodeID signature.PublicKey `json:"node_id"`
+}
+
+// NewUnjailTx creates a new unjail transaction.
+func NewUnjailTx(nonce uint64, fee *transaction.Fee, unjail *Unjail) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodUnjail, unjail)
+}
+
 // Genesis is the committee scheduler genesis state.
 type Genesis struct {
 	// Parameters are the scheduler consensus parameters.
 	Parameters ConsensusParameters `json:"params"`
+
+	// JailedNodes are the validator nodes that are currently jailed.
+	JailedNodes []*JailStatus `json:"jailed_nodes,omitempty"`
 }
 
 // ConsensusParameters are the scheduler consensus parameters.
@@ -243,6 +359,40 @@ type ConsensusParameters struct {
 	// distributed per epoch to entities that have any node considered
 	// in any election.
 	RewardFactorEpochElectionAny quantity.Quantity `json:"reward_factor_epoch_election_any"`
+
+	// ValidatorLivenessWindow is the size (in blocks) of the window over
+	// which validator liveness is evaluated. Zero disables liveness
+	// tracking and jailing.
+	ValidatorLivenessWindow int64 `json:"validator_liveness_window,omitempty"`
+
+	// ValidatorLivenessMaxMissedPercent is the maximum percentage of
+	// blocks in a liveness window that a validator may fail to sign
+	// without being jailed.
+	ValidatorLivenessMaxMissedPercent uint8 `json:"validator_liveness_max_missed_percent,omitempty"`
+
+	// ValidatorJailEpochs is the minimum number of epochs that a jailed
+	// validator node must wait before it can be unjailed.
+	ValidatorJailEpochs epochtime.EpochTime `json:"validator_jail_epochs,omitempty"`
+
+	// MaxVotingPowerChangePercent is the maximum total change in voting
+	// power (as a percentage of the current total voting power) that a
+	// single validator election may cause. Zero means no limit.
+	MaxVotingPowerChangePercent uint8 `json:"max_voting_power_change_percent,omitempty"`
+
+	// GasCosts are the scheduler transaction gas costs.
+	GasCosts transaction.Costs `json:"gas_costs,omitempty"`
+}
+
+const (
+	// GasOpUnjail is the gas operation identifier for unjailing nodes.
+	GasOpUnjail transaction.Op = "unjail"
+)
+
+// XXX: Define reasonable default gas costs.
+
+// DefaultGasCosts are the "default" gas costs for operations.
+var DefaultGasCosts = transaction.Costs{
+	GasOpUnjail: 1000,
 }
 
 // SanityCheck does basic sanity checking on the genesis state.
@@ -252,6 +402,21 @@ func (g *Genesis) SanityCheck(stakingTotalSupply *quantity.Quantity) error {
 		return fmt.Errorf("scheduler: sanity check failed: one or more unsafe debug flags set")
 	}
 
+	if g.Parameters.ValidatorLivenessWindow < 0 {
+		return fmt.Errorf("scheduler: sanity check failed: validator liveness window must be >= 0")
+	}
+	if g.Parameters.ValidatorLivenessMaxMissedPercent > 100 {
+		return fmt.Errorf("scheduler: sanity check failed: validator liveness max missed percent must be <= 100")
+	}
+	if g.Parameters.MaxVotingPowerChangePercent > 100 {
+		return fmt.Errorf("scheduler: sanity check failed: max voting power change percent must be <= 100")
+	}
+	for _, js := range g.JailedNodes {
+		if js == nil || !js.NodeID.IsValid() {
+			return fmt.Errorf("scheduler: sanity check failed: invalid jailed node")
+		}
+	}
+
 	if !g.Parameters.DebugBypassStake {
 		supplyPower, err := VotingPowerFromTokens(stakingTotalSupply)
 		if err != nil {
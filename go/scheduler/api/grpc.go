@@ -7,6 +7,7 @@ import (
 
 	cmnGrpc "github.com/oasislabs/oasis-core/go/common/grpc"
 	"github.com/oasislabs/oasis-core/go/common/pubsub"
+	epochtime "github.com/oasislabs/oasis-core/go/epochtime/api"
 )
 
 var (
@@ -14,14 +15,22 @@ var (
 	serviceName = cmnGrpc.NewServiceName("Scheduler")
 
 	// methodGetValidators is the GetValidators method.
-	methodGetValidators = serviceName.NewMethod("GetValidators", int64(0))
+	methodGetValidators = serviceName.NewMethod("GetValidators", int64(0)).WithReadOnly()
 	// methodGetCommittees is the GetCommittees method.
-	methodGetCommittees = serviceName.NewMethod("GetCommittees", GetCommitteesRequest{})
+	methodGetCommittees = serviceName.NewMethod("GetCommittees", GetCommitteesRequest{}).WithReadOnly()
+	// methodGetValidatorsAtEpoch is the GetValidatorsAtEpoch method.
+	methodGetValidatorsAtEpoch = serviceName.NewMethod("GetValidatorsAtEpoch", epochtime.EpochTime(0)).WithReadOnly()
+	// methodGetCommitteesAtEpoch is the GetCommitteesAtEpoch method.
+	methodGetCommitteesAtEpoch = serviceName.NewMethod("GetCommitteesAtEpoch", GetCommitteesAtEpochRequest{}).WithReadOnly()
+	// methodGetJailedNodes is the GetJailedNodes method.
+	methodGetJailedNodes = serviceName.NewMethod("GetJailedNodes", int64(0)).WithReadOnly()
 	// methodStateToGenesis is the StateToGenesis method.
-	methodStateToGenesis = serviceName.NewMethod("StateToGenesis", int64(0))
+	methodStateToGenesis = serviceName.NewMethod("StateToGenesis", int64(0)).WithReadOnly()
 
 	// methodWatchCommittees is the WatchCommittees method.
-	methodWatchCommittees = serviceName.NewMethod("WatchCommittees", nil)
+	methodWatchCommittees = serviceName.NewMethod("WatchCommittees", nil).WithReadOnly()
+	// methodWatchCommitteesFromEpoch is the WatchCommitteesFromEpoch method.
+	methodWatchCommitteesFromEpoch = serviceName.NewMethod("WatchCommitteesFromEpoch", epochtime.EpochTime(0)).WithReadOnly()
 
 	// serviceDesc is the gRPC service descriptor.
 	serviceDesc = grpc.ServiceDesc{
@@ -36,6 +45,18 @@ var (
 				MethodName: methodGetCommittees.ShortName(),
 				Handler:    handlerGetCommittees,
 			},
+			{
+				MethodName: methodGetValidatorsAtEpoch.ShortName(),
+				Handler:    handlerGetValidatorsAtEpoch,
+			},
+			{
+				MethodName: methodGetCommitteesAtEpoch.ShortName(),
+				Handler:    handlerGetCommitteesAtEpoch,
+			},
+			{
+				MethodName: methodGetJailedNodes.ShortName(),
+				Handler:    handlerGetJailedNodes,
+			},
 			{
 				MethodName: methodStateToGenesis.ShortName(),
 				Handler:    handlerStateToGenesis,
@@ -47,6 +68,11 @@ var (
 				Handler:       handlerWatchCommittees,
 				ServerStreams: true,
 			},
+			{
+				StreamName:    methodWatchCommitteesFromEpoch.ShortName(),
+				Handler:       handlerWatchCommitteesFromEpoch,
+				ServerStreams: true,
+			},
 		},
 	}
 )
@@ -97,6 +123,75 @@ func handlerGetCommittees( // nolint: golint
 	return interceptor(ctx, &req, info, handler)
 }
 
+func handlerGetValidatorsAtEpoch( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var epoch epochtime.EpochTime
+	if err := dec(&epoch); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetValidatorsAtEpoch(ctx, epoch)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetValidatorsAtEpoch.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetValidatorsAtEpoch(ctx, req.(epochtime.EpochTime))
+	}
+	return interceptor(ctx, epoch, info, handler)
+}
+
+func handlerGetCommitteesAtEpoch( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var req GetCommitteesAtEpochRequest
+	if err := dec(&req); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetCommitteesAtEpoch(ctx, &req)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetCommitteesAtEpoch.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetCommitteesAtEpoch(ctx, req.(*GetCommitteesAtEpochRequest))
+	}
+	return interceptor(ctx, &req, info, handler)
+}
+
+func handlerGetJailedNodes( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var height int64
+	if err := dec(&height); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetJailedNodes(ctx, height)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetJailedNodes.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetJailedNodes(ctx, req.(int64))
+	}
+	return interceptor(ctx, height, info, handler)
+}
+
 func handlerStateToGenesis( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -148,8 +243,37 @@ func handlerWatchCommittees(srv interface{}, stream grpc.ServerStream) error {
 	}
 }
 
+func handlerWatchCommitteesFromEpoch(srv interface{}, stream grpc.ServerStream) error {
+	var epoch epochtime.EpochTime
+	if err := stream.RecvMsg(&epoch); err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	ch, sub, err := srv.(Backend).WatchCommitteesFromEpoch(ctx, epoch)
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+
+	for {
+		select {
+		case c, ok := <-ch:
+			if !ok {
+				return nil
+			}
+
+			if err := stream.SendMsg(c); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
 // RegisterService registers a new scheduler service with the given gRPC server.
-func RegisterService(server *grpc.Server, service Backend) {
+func RegisterService(server cmnGrpc.ServiceRegistrar, service Backend) {
 	server.RegisterService(&serviceDesc, service)
 }
 
@@ -173,6 +297,30 @@ func (c *schedulerClient) GetCommittees(ctx context.Context, request *GetCommitt
 	return rsp, nil
 }
 
+func (c *schedulerClient) GetValidatorsAtEpoch(ctx context.Context, epoch epochtime.EpochTime) ([]*Validator, error) {
+	var rsp []*Validator
+	if err := c.conn.Invoke(ctx, methodGetValidatorsAtEpoch.FullName(), epoch, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+func (c *schedulerClient) GetCommitteesAtEpoch(ctx context.Context, request *GetCommitteesAtEpochRequest) ([]*Committee, error) {
+	var rsp []*Committee
+	if err := c.conn.Invoke(ctx, methodGetCommitteesAtEpoch.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+func (c *schedulerClient) GetJailedNodes(ctx context.Context, height int64) ([]*JailStatus, error) {
+	var rsp []*JailStatus
+	if err := c.conn.Invoke(ctx, methodGetJailedNodes.FullName(), height, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
 func (c *schedulerClient) StateToGenesis(ctx context.Context, height int64) (*Genesis, error) {
 	var rsp Genesis
 	if err := c.conn.Invoke(ctx, methodStateToGenesis.FullName(), height, &rsp); err != nil {
@@ -216,6 +364,41 @@ func (c *schedulerClient) WatchCommittees(ctx context.Context) (<-chan *Committe
 	return ch, sub, nil
 }
 
+func (c *schedulerClient) WatchCommitteesFromEpoch(ctx context.Context, epoch epochtime.EpochTime) (<-chan *Committee, pubsub.ClosableSubscription, error) {
+	ctx, sub := pubsub.NewContextSubscription(ctx)
+
+	stream, err := c.conn.NewStream(ctx, &serviceDesc.Streams[1], methodWatchCommitteesFromEpoch.FullName())
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = stream.SendMsg(epoch); err != nil {
+		return nil, nil, err
+	}
+	if err = stream.CloseSend(); err != nil {
+		return nil, nil, err
+	}
+
+	ch := make(chan *Committee)
+	go func() {
+		defer close(ch)
+
+		for {
+			var ev Committee
+			if serr := stream.RecvMsg(&ev); serr != nil {
+				return
+			}
+
+			select {
+			case ch <- &ev:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, sub, nil
+}
+
 func (c *schedulerClient) Cleanup() {
 }
 
@@ -107,6 +107,14 @@ type Backend interface {
 	// the given owner (delegator).
 	DebondingDelegations(ctx context.Context, query *OwnerQuery) (map[signature.PublicKey][]*DebondingDelegation, error)
 
+	// DelegationRewards returns the staking rewards earned by the given
+	// owner's delegation to the given escrow account over a range of epochs.
+	//
+	// The delegation's share of each epoch's escrow pool rewards is
+	// computed using the delegation's shares at the end of that epoch. Only
+	// epochs that are still in the reward history are included.
+	DelegationRewards(ctx context.Context, query *DelegationRewardsQuery) (*DelegationRewards, error)
+
 	// StateToGenesis returns the genesis state at specified block height.
 	StateToGenesis(ctx context.Context, height int64) (*Genesis, error)
 
@@ -164,6 +172,7 @@ type EscrowEvent struct {
 	Add     *AddEscrowEvent     `json:"add,omitempty"`
 	Take    *TakeEscrowEvent    `json:"take,omitempty"`
 	Reclaim *ReclaimEscrowEvent `json:"reclaim,omitempty"`
+	Reward  *RewardEvent        `json:"reward,omitempty"`
 }
 
 // Event signifies a staking event, returned via GetEvents.
@@ -541,6 +550,12 @@ type Genesis struct {
 
 	Delegations          map[signature.PublicKey]map[signature.PublicKey]*Delegation            `json:"delegations,omitempty"`
 	DebondingDelegations map[signature.PublicKey]map[signature.PublicKey][]*DebondingDelegation `json:"debonding_delegations,omitempty"`
+
+	// EpochRewards is the reward history of each escrow account.
+	EpochRewards map[signature.PublicKey][]*EpochRewards `json:"epoch_rewards,omitempty"`
+	// DelegationShares is the share history of each delegation, by escrow
+	// account and delegator.
+	DelegationShares map[signature.PublicKey]map[signature.PublicKey][]*EpochDelegationShares `json:"delegation_shares,omitempty"`
 }
 
 // ConsensusParameters are the staking consensus parameters.
@@ -572,6 +587,11 @@ type ConsensusParameters struct {
 	// RewardFactorBlockProposed is the factor for a reward distributed per block
 	// to the entity that proposed the block.
 	RewardFactorBlockProposed quantity.Quantity `json:"reward_factor_block_proposed"`
+
+	// RewardHistoryEpochs is the number of epochs for which the reward
+	// history used to compute delegation rewards is kept. If zero, no reward
+	// history is kept.
+	RewardHistoryEpochs epochtime.EpochTime `json:"reward_history_epochs,omitempty"`
 }
 
 const (
@@ -16,34 +16,36 @@ var (
 	serviceName = cmnGrpc.NewServiceName("Staking")
 
 	// methodTotalSupply is the TotalSupply method.
-	methodTotalSupply = serviceName.NewMethod("TotalSupply", int64(0))
+	methodTotalSupply = serviceName.NewMethod("TotalSupply", int64(0)).WithReadOnly()
 	// methodCommonPool is the CommonPool method.
-	methodCommonPool = serviceName.NewMethod("CommonPool", int64(0))
+	methodCommonPool = serviceName.NewMethod("CommonPool", int64(0)).WithReadOnly()
 	// methodLastBlockFees is the LastBlockFees method.
-	methodLastBlockFees = serviceName.NewMethod("LastBlockFees", int64(0))
+	methodLastBlockFees = serviceName.NewMethod("LastBlockFees", int64(0)).WithReadOnly()
 	// methodThreshold is the Threshold method.
-	methodThreshold = serviceName.NewMethod("Threshold", ThresholdQuery{})
+	methodThreshold = serviceName.NewMethod("Threshold", ThresholdQuery{}).WithReadOnly()
 	// methodAccounts is the Accounts method.
-	methodAccounts = serviceName.NewMethod("Accounts", int64(0))
+	methodAccounts = serviceName.NewMethod("Accounts", int64(0)).WithReadOnly()
 	// methodAccountInfo is the AccountInfo method.
-	methodAccountInfo = serviceName.NewMethod("AccountInfo", OwnerQuery{})
+	methodAccountInfo = serviceName.NewMethod("AccountInfo", OwnerQuery{}).WithReadOnly()
 	// methodDelegations is the Delegations method.
-	methodDelegations = serviceName.NewMethod("Delegations", OwnerQuery{})
+	methodDelegations = serviceName.NewMethod("Delegations", OwnerQuery{}).WithReadOnly()
 	// methodDebondingDelegations is the DebondingDelegations method.
-	methodDebondingDelegations = serviceName.NewMethod("DebondingDelegations", OwnerQuery{})
+	methodDebondingDelegations = serviceName.NewMethod("DebondingDelegations", OwnerQuery{}).WithReadOnly()
+	// methodDelegationRewards is the DelegationRewards method.
+	methodDelegationRewards = serviceName.NewMethod("DelegationRewards", DelegationRewardsQuery{}).WithReadOnly()
 	// methodStateToGenesis is the StateToGenesis method.
-	methodStateToGenesis = serviceName.NewMethod("StateToGenesis", int64(0))
+	methodStateToGenesis = serviceName.NewMethod("StateToGenesis", int64(0)).WithReadOnly()
 	// methodConsensusParameters is the ConsensusParameters method.
-	methodConsensusParameters = serviceName.NewMethod("ConsensusParameters", int64(0))
+	methodConsensusParameters = serviceName.NewMethod("ConsensusParameters", int64(0)).WithReadOnly()
 	// methodGetEvents is the GetEvents method.
-	methodGetEvents = serviceName.NewMethod("GetEvents", int64(0))
+	methodGetEvents = serviceName.NewMethod("GetEvents", int64(0)).WithReadOnly()
 
 	// methodWatchTransfers is the WatchTransfers method.
-	methodWatchTransfers = serviceName.NewMethod("WatchTransfers", nil)
+	methodWatchTransfers = serviceName.NewMethod("WatchTransfers", nil).WithReadOnly()
 	// methodWatchBurns is the WatchBurns method.
-	methodWatchBurns = serviceName.NewMethod("WatchBurns", nil)
+	methodWatchBurns = serviceName.NewMethod("WatchBurns", nil).WithReadOnly()
 	// methodWatchEscrows is the WatchEscrows method.
-	methodWatchEscrows = serviceName.NewMethod("WatchEscrows", nil)
+	methodWatchEscrows = serviceName.NewMethod("WatchEscrows", nil).WithReadOnly()
 
 	// serviceDesc is the gRPC service descriptor.
 	serviceDesc = grpc.ServiceDesc{
@@ -82,6 +84,10 @@ var (
 				MethodName: methodDebondingDelegations.ShortName(),
 				Handler:    handlerDebondingDelegations,
 			},
+			{
+				MethodName: methodDelegationRewards.ShortName(),
+				Handler:    handlerDelegationRewards,
+			},
 			{
 				MethodName: methodStateToGenesis.ShortName(),
 				Handler:    handlerStateToGenesis,
@@ -299,6 +305,29 @@ func handlerDebondingDelegations( // nolint: golint
 	return interceptor(ctx, &query, info, handler)
 }
 
+func handlerDelegationRewards( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query DelegationRewardsQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).DelegationRewards(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodDelegationRewards.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).DelegationRewards(ctx, req.(*DelegationRewardsQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
 func handlerStateToGenesis( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -453,7 +482,7 @@ func handlerWatchEscrows(srv interface{}, stream grpc.ServerStream) error {
 }
 
 // RegisterService registers a new staking backend service with the given gRPC server.
-func RegisterService(server *grpc.Server, service Backend) {
+func RegisterService(server cmnGrpc.ServiceRegistrar, service Backend) {
 	server.RegisterService(&serviceDesc, service)
 }
 
@@ -525,6 +554,14 @@ func (c *stakingClient) DebondingDelegations(ctx context.Context, query *OwnerQu
 	return rsp, nil
 }
 
+func (c *stakingClient) DelegationRewards(ctx context.Context, query *DelegationRewardsQuery) (*DelegationRewards, error) {
+	var rsp DelegationRewards
+	if err := c.conn.Invoke(ctx, methodDelegationRewards.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *stakingClient) StateToGenesis(ctx context.Context, height int64) (*Genesis, error) {
 	var rsp Genesis
 	if err := c.conn.Invoke(ctx, methodStateToGenesis.FullName(), height, &rsp); err != nil {
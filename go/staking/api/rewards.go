@@ -3,6 +3,7 @@ package api
 import (
 	"math/big"
 
+	"github.com/oasislabs/oasis-core/go/common/crypto/signature"
 	"github.com/oasislabs/oasis-core/go/common/quantity"
 	epochtime "github.com/oasislabs/oasis-core/go/epochtime/api"
 )
@@ -24,3 +25,78 @@ func init() {
 		panic(err)
 	}
 }
+
+// RewardEvent is the event emitted when a staking reward is added to an
+// escrow account.
+type RewardEvent struct {
+	// Epoch is the epoch in which the reward has been added.
+	Epoch epochtime.EpochTime `json:"epoch"`
+	// Escrow is the escrow account the reward has been added to.
+	Escrow signature.PublicKey `json:"escrow"`
+	// Rewards is the part of the reward that has been added to the escrow
+	// pool, increasing the value of all delegators' shares.
+	Rewards quantity.Quantity `json:"rewards"`
+	// Commission is the part of the reward that has been taken as
+	// commission and deposited into the escrow account owner's own
+	// delegation.
+	Commission quantity.Quantity `json:"commission"`
+}
+
+// EpochRewards is the sum of all staking rewards added to an escrow account
+// during an epoch.
+type EpochRewards struct {
+	// Epoch is the epoch the rewards have been added in.
+	Epoch epochtime.EpochTime `json:"epoch"`
+	// Rewards is the total amount added to the escrow pool.
+	Rewards quantity.Quantity `json:"rewards"`
+	// Commission is the total amount taken as commission.
+	Commission quantity.Quantity `json:"commission"`
+	// TotalShares is the total number of shares in the escrow pool at the
+	// time the first reward of the epoch has been added.
+	TotalShares quantity.Quantity `json:"total_shares"`
+}
+
+// EpochDelegationShares are the shares of a delegation that changed during
+// an epoch.
+type EpochDelegationShares struct {
+	// Epoch is the epoch the delegation changed in.
+	Epoch epochtime.EpochTime `json:"epoch"`
+	// Previous is the number of shares at the start of the epoch.
+	Previous quantity.Quantity `json:"previous"`
+	// Shares is the number of shares at the end of the epoch.
+	Shares quantity.Quantity `json:"shares"`
+}
+
+// DelegationRewardsQuery is a delegation rewards query.
+type DelegationRewardsQuery struct {
+	Height int64               `json:"height"`
+	Owner  signature.PublicKey `json:"owner"`
+	Escrow signature.PublicKey `json:"escrow"`
+	// From is the first epoch (inclusive) to sum the rewards for.
+	From epochtime.EpochTime `json:"from"`
+	// To is the last epoch (exclusive) to sum the rewards for.
+	To epochtime.EpochTime `json:"to"`
+}
+
+// DelegationRewards are the staking rewards earned by a delegation over a
+// range of epochs.
+type DelegationRewards struct {
+	// Total is the total amount earned by the delegation.
+	Total quantity.Quantity `json:"total"`
+	// Epochs are the amounts earned by the delegation in each epoch that
+	// the escrow account received any rewards in.
+	Epochs []*EpochDelegationRewards `json:"epochs,omitempty"`
+}
+
+// EpochDelegationRewards are the staking rewards earned by a delegation in
+// a single epoch.
+type EpochDelegationRewards struct {
+	// Epoch is the epoch the rewards have been earned in.
+	Epoch epochtime.EpochTime `json:"epoch"`
+	// Rewards is the delegation's share of the rewards added to the escrow
+	// pool.
+	Rewards quantity.Quantity `json:"rewards"`
+	// Commission is the commission earned in the epoch. It is only
+	// non-zero if the delegator is the escrow account owner.
+	Commission quantity.Quantity `json:"commission"`
+}
@@ -11,8 +11,11 @@ type SlashReason int
 const (
 	// SlashDoubleSigning is slashing due to double signing.
 	SlashDoubleSigning SlashReason = 0
+	// SlashBeaconNonReveal is slashing due to not revealing a committed
+	// random beacon secret.
+	SlashBeaconNonReveal SlashReason = 1
 
-	SlashMax = SlashDoubleSigning
+	SlashMax = SlashBeaconNonReveal
 )
 
 // String returns a string representation of a SlashReason.
@@ -20,6 +23,8 @@ func (s SlashReason) String() string {
 	switch s {
 	case SlashDoubleSigning:
 		return "double-signing"
+	case SlashBeaconNonReveal:
+		return "beacon-non-reveal"
 	default:
 		return "[unknown slash reason]"
 	}
@@ -43,6 +43,12 @@ var (
 	ErrNoMergeRoots = errors.New(ModuleName, 5, "storage: no roots to merge")
 	// ErrLimitReached means that a configured limit has been reached.
 	ErrLimitReached = errors.New(ModuleName, 6, "storage: limit reached")
+	// ErrMissingDiffProof is the error returned when a GetDiff chunk does not
+	// contain a proof even though proofs were requested.
+	ErrMissingDiffProof = errors.New(ModuleName, 7, "storage: missing diff proof")
+	// ErrInvalidDiffProof is the error returned when a GetDiff chunk does not
+	// match its proof or the proof is invalid.
+	ErrInvalidDiffProof = errors.New(ModuleName, 8, "storage: invalid diff proof")
 
 	// The following errors are reimports from NodeDB.
 
@@ -290,6 +296,9 @@ type SyncOptions struct {
 type SyncChunk struct {
 	Final    bool     `json:"final"`
 	WriteLog WriteLog `json:"writelog"`
+	// Proof is a proof against the end root covering all of the keys in the
+	// chunk's write log. It is only present if proofs were requested.
+	Proof *Proof `json:"proof,omitempty"`
 }
 
 // GetDiffRequest is a GetDiff request.
@@ -297,6 +306,9 @@ type GetDiffRequest struct {
 	StartRoot Root        `json:"start_root"`
 	EndRoot   Root        `json:"end_root"`
 	Options   SyncOptions `json:"options"`
+	// WithProofs requests each chunk of the diff to include a proof against
+	// the end root, allowing the client to verify each chunk as it arrives.
+	WithProofs bool `json:"with_proofs,omitempty"`
 }
 
 // Backend is a storage backend implementation.
@@ -334,6 +346,10 @@ type Backend interface {
 
 	// GetDiff returns an iterator of write log entries that must be applied
 	// to get from the first given root to the second one.
+	//
+	// When proofs are requested and the diff is obtained from a remote node,
+	// each received chunk is verified against the end root and the iterator
+	// fails as soon as an invalid chunk is received.
 	GetDiff(ctx context.Context, request *GetDiffRequest) (WriteLogIterator, error)
 
 	// Cleanup closes/cleans up the storage backend.
@@ -361,4 +377,9 @@ type ClientBackend interface {
 
 	// GetConnectedNodes returns currently connected storage nodes.
 	GetConnectedNodes() []*node.Node
+
+	// ApplyBatchAggregated applies multiple sets of operations against the
+	// MKVS on all connected storage nodes and returns a single aggregated
+	// receipt with signer indices into the current storage committee.
+	ApplyBatchAggregated(ctx context.Context, request *ApplyBatchRequest) (*AggregatedReceipt, error)
 }
@@ -10,10 +10,19 @@ import (
 
 	"github.com/oasislabs/oasis-core/go/common"
 	cmnGrpc "github.com/oasislabs/oasis-core/go/common/grpc"
+	"github.com/oasislabs/oasis-core/go/storage/mkvs"
 	"github.com/oasislabs/oasis-core/go/storage/mkvs/checkpoint"
 	"github.com/oasislabs/oasis-core/go/storage/mkvs/writelog"
 )
 
+const (
+	// diffProofCacheNodes is the maximum number of nodes cached while generating GetDiff proofs.
+	diffProofCacheNodes = 50_000
+	// diffProofCacheBytes is the maximum size of leaf values cached while generating GetDiff
+	// proofs.
+	diffProofCacheBytes = 16 * 1024 * 1024
+)
+
 var (
 	errInvalidRequestType = fmt.Errorf("invalid request type")
 
@@ -354,7 +363,12 @@ func handlerGetCheckpoints( // nolint: golint
 	return interceptor(ctx, &req, info, handler)
 }
 
-func sendWriteLogIterator(it WriteLogIterator, opts *SyncOptions, stream grpc.ServerStream) error {
+func sendWriteLogIterator(
+	it WriteLogIterator,
+	opts *SyncOptions,
+	prove func(WriteLog) (*Proof, error),
+	stream grpc.ServerStream,
+) error {
 	var totalSent uint64
 	skipping := true
 	final := false
@@ -403,6 +417,13 @@ func sendWriteLogIterator(it WriteLogIterator, opts *SyncOptions, stream grpc.Se
 			Final:    final,
 			WriteLog: entryArray,
 		}
+		if prove != nil && len(entryArray) > 0 {
+			proof, err := prove(entryArray)
+			if err != nil {
+				return err
+			}
+			chunk.Proof = proof
+		}
 
 		if err := stream.SendMsg(chunk); err != nil {
 			return err
@@ -428,7 +449,22 @@ func handlerGetDiff(srv interface{}, stream grpc.ServerStream) error {
 		return err
 	}
 
-	return sendWriteLogIterator(it, &req.Options, stream)
+	var prove func(WriteLog) (*Proof, error)
+	if req.WithProofs {
+		// Use a tree backed by the storage backend itself to generate proofs for the end root.
+		tree := mkvs.NewWithRoot(srv.(Backend), nil, req.EndRoot, mkvs.Capacity(diffProofCacheNodes, diffProofCacheBytes))
+		defer tree.Close()
+
+		prove = func(wl WriteLog) (*Proof, error) {
+			keys := make([][]byte, 0, len(wl))
+			for _, entry := range wl {
+				keys = append(keys, entry.Key)
+			}
+			return tree.ProveKeys(ctx, keys)
+		}
+	}
+
+	return sendWriteLogIterator(it, &req.Options, prove, stream)
 }
 
 func handlerGetCheckpointChunk(srv interface{}, stream grpc.ServerStream) error {
@@ -513,7 +549,7 @@ func (c *storageClient) GetCheckpoints(ctx context.Context, request *checkpoint.
 	return rsp, nil
 }
 
-func receiveWriteLogIterator(ctx context.Context, stream grpc.ClientStream) WriteLogIterator {
+func receiveWriteLogIterator(ctx context.Context, stream grpc.ClientStream, request *GetDiffRequest) WriteLogIterator {
 	pipe := writelog.NewPipeIterator(ctx)
 
 	go func() {
@@ -530,6 +566,19 @@ func receiveWriteLogIterator(ctx context.Context, stream grpc.ClientStream) Writ
 				continue
 			}
 
+			// Verify the chunk against the end root in case proofs were requested and abort on
+			// the first invalid chunk.
+			if request.WithProofs && len(chunk.WriteLog) > 0 {
+				if chunk.Proof == nil {
+					_ = pipe.PutError(ErrMissingDiffProof)
+					break
+				}
+				if err = mkvs.VerifyWriteLogProof(ctx, request.EndRoot.Hash, chunk.Proof, chunk.WriteLog); err != nil {
+					_ = pipe.PutError(fmt.Errorf("%w: %s", ErrInvalidDiffProof, err))
+					break
+				}
+			}
+
 			for i := range chunk.WriteLog {
 				if err := pipe.Put(&chunk.WriteLog[i]); err != nil {
 					_ = pipe.PutError(err)
@@ -557,7 +606,7 @@ func (c *storageClient) GetDiff(ctx context.Context, request *GetDiffRequest) (W
 		return nil, err
 	}
 
-	return receiveWriteLogIterator(ctx, stream), nil
+	return receiveWriteLogIterator(ctx, stream, request), nil
 }
 
 func (c *storageClient) GetCheckpointChunk(ctx context.Context, chunk *checkpoint.ChunkMetadata, w io.Writer) error {
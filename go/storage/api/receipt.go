@@ -0,0 +1,137 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/oasislabs/oasis-core/go/common/cbor"
+	"github.com/oasislabs/oasis-core/go/common/crypto/signature"
+)
+
+// AggregatedReceipt is a compact representation of multiple storage receipts
+// over the same body, signed by different members of the storage committee.
+//
+// Instead of repeating the body and the signer public key for each receipt,
+// the aggregated receipt contains the body once together with a list of
+// signer indices into the storage committee and the corresponding raw
+// signatures.
+type AggregatedReceipt struct {
+	// Body is the receipt body signed by all of the signers.
+	Body ReceiptBody `json:"body"`
+	// Signers are the indices of the signers in the storage committee. The
+	// indices must be unique and sorted in ascending order.
+	Signers []uint16 `json:"signers"`
+	// Signatures are the signatures of the signers, in the same order as
+	// the signer indices.
+	Signatures []signature.RawSignature `json:"signatures"`
+}
+
+// SignaturesForCommittee expands the aggregated receipt into a list of receipt
+// signatures, bundled with the signer public keys from the given storage
+// committee.
+//
+// Note: This method only validates the encoding of the aggregated receipt,
+// it does not verify the signatures.
+func (r *AggregatedReceipt) SignaturesForCommittee(committee []signature.PublicKey) ([]signature.Signature, error) {
+	if len(r.Signers) == 0 {
+		return nil, fmt.Errorf("storage: aggregated receipt has no signers")
+	}
+	if len(r.Signers) != len(r.Signatures) {
+		return nil, fmt.Errorf("storage: aggregated receipt signer/signature count mismatch")
+	}
+
+	sigs := make([]signature.Signature, 0, len(r.Signers))
+	for i, idx := range r.Signers {
+		if i > 0 && idx <= r.Signers[i-1] {
+			return nil, fmt.Errorf("storage: aggregated receipt signers not sorted or not unique")
+		}
+		if int(idx) >= len(committee) {
+			return nil, fmt.Errorf("storage: aggregated receipt signer index out of range: %d", idx)
+		}
+
+		sigs = append(sigs, signature.Signature{
+			PublicKey: committee[idx],
+			Signature: r.Signatures[i],
+		})
+	}
+	return sigs, nil
+}
+
+// Verify verifies the aggregated receipt signatures against the given storage
+// committee.
+func (r *AggregatedReceipt) Verify(committee []signature.PublicKey) error {
+	sigs, err := r.SignaturesForCommittee(committee)
+	if err != nil {
+		return err
+	}
+	if !signature.VerifyManyToOne(ReceiptSignatureContext, cbor.Marshal(r.Body), sigs) {
+		return signature.ErrVerifyFailed
+	}
+	return nil
+}
+
+// NewAggregatedReceipt aggregates the given storage receipts into a single
+// aggregated receipt. All receipts must be valid, must be signed by members
+// of the given storage committee and must have the same body.
+func NewAggregatedReceipt(committee []signature.PublicKey, receipts []*Receipt) (*AggregatedReceipt, error) {
+	if len(receipts) == 0 {
+		return nil, fmt.Errorf("storage: no receipts to aggregate")
+	}
+
+	indices := make(map[signature.PublicKey]int)
+	for idx, pk := range committee {
+		indices[pk] = idx
+	}
+
+	type signerSig struct {
+		index     int
+		signature signature.RawSignature
+	}
+	var (
+		body    ReceiptBody
+		blob    []byte
+		signers []signerSig
+		seen    = make(map[int]bool)
+	)
+	for i, receipt := range receipts {
+		idx, ok := indices[receipt.Signature.PublicKey]
+		if !ok {
+			return nil, fmt.Errorf("storage: receipt signer %s is not a committee member", receipt.Signature.PublicKey)
+		}
+		if idx > int(^uint16(0)) {
+			return nil, fmt.Errorf("storage: receipt signer index out of range: %d", idx)
+		}
+		if seen[idx] {
+			return nil, fmt.Errorf("storage: duplicate receipt from signer %s", receipt.Signature.PublicKey)
+		}
+		seen[idx] = true
+
+		var rb ReceiptBody
+		if err := receipt.Open(&rb); err != nil {
+			return nil, fmt.Errorf("storage: failed to open receipt: %w", err)
+		}
+		if i == 0 {
+			body, blob = rb, receipt.Blob
+		} else if !bytes.Equal(blob, receipt.Blob) {
+			return nil, fmt.Errorf("storage: receipts have different bodies")
+		}
+
+		signers = append(signers, signerSig{index: idx, signature: receipt.Signature.Signature})
+	}
+
+	sort.Slice(signers, func(i, j int) bool {
+		return signers[i].index < signers[j].index
+	})
+
+	ar := &AggregatedReceipt{
+		Body:       body,
+		Signers:    make([]uint16, 0, len(signers)),
+		Signatures: make([]signature.RawSignature, 0, len(signers)),
+	}
+	for _, s := range signers {
+		ar.Signers = append(ar.Signers, uint16(s.index))
+		ar.Signatures = append(ar.Signatures, s.signature)
+	}
+	return ar, nil
+}
@@ -4,10 +4,9 @@ package client
 
 import (
 	"context"
-	cryptorand "crypto/rand"
 	"errors"
+	"fmt"
 	"io"
-	"math/rand"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -16,7 +15,7 @@ import (
 
 	"github.com/oasislabs/oasis-core/go/common"
 	"github.com/oasislabs/oasis-core/go/common/crypto/hash"
-	"github.com/oasislabs/oasis-core/go/common/crypto/mathrand"
+	"github.com/oasislabs/oasis-core/go/common/crypto/signature"
 	"github.com/oasislabs/oasis-core/go/common/logging"
 	"github.com/oasislabs/oasis-core/go/common/node"
 	"github.com/oasislabs/oasis-core/go/runtime/committee"
@@ -37,6 +36,9 @@ var (
 const (
 	retryInterval = 1 * time.Second
 	maxRetries    = 15
+
+	// maxHedgedReads is the maximum number of concurrent reads issued for a single request.
+	maxHedgedReads = 2
 )
 
 // storageClientBackend contains all information about the client storage API
@@ -48,6 +50,9 @@ type storageClientBackend struct {
 	logger *logging.Logger
 
 	committeeClient committee.Client
+	// committeeWatcher may be nil in case the client follows a static set of nodes.
+	committeeWatcher committee.Watcher
+	latency          *latencyTracker
 }
 
 // GetConnectedNodes returns registry node information about all connected
@@ -73,14 +78,34 @@ func (b *storageClientBackend) writeWithClient(
 	round uint64,
 	fn func(context.Context, api.Backend, *node.Node) (interface{}, error),
 	expectedNewRoots []hash.Hash,
-) ([]*api.Receipt, error) {
+	aggregate bool,
+) ([]*api.Receipt, *api.AggregatedReceipt, error) {
+	// In case an aggregated receipt is requested, we need to know the storage committee as the
+	// aggregated receipt references signers by their index in the committee.
+	var committeeKeys []signature.PublicKey
+	if aggregate {
+		if b.committeeWatcher == nil {
+			return nil, nil, api.ErrUnsupported
+		}
+		c := b.committeeWatcher.Committee()
+		if c == nil {
+			b.logger.Error("writeWithClient: no storage committee for runtime",
+				"runtime_id", ns,
+			)
+			return nil, nil, ErrStorageNotAvailable
+		}
+		for _, member := range c.Members {
+			committeeKeys = append(committeeKeys, member.PublicKey)
+		}
+	}
+
 	conns := b.committeeClient.GetConnectionsWithMeta()
 	n := len(conns)
 	if n == 0 {
 		b.logger.Error("writeWithClient: no connected nodes for runtime",
 			"runtime_id", ns,
 		)
-		return nil, ErrStorageNotAvailable
+		return nil, nil, ErrStorageNotAvailable
 	}
 
 	// Use a buffered channel to allow all "write" goroutines to return as soon
@@ -128,7 +153,7 @@ func (b *storageClientBackend) writeWithClient(
 		var response *grpcResponse
 		select {
 		case <-ctx.Done():
-			return nil, ctx.Err()
+			return nil, nil, ctx.Err()
 		case response = <-ch:
 		}
 		if response.err != nil {
@@ -215,7 +240,7 @@ func (b *storageClientBackend) writeWithClient(
 
 	successes := len(receipts)
 	if successes == 0 {
-		return nil, errors.New("storage client: failed to write to any storage node")
+		return nil, nil, errors.New("storage client: failed to write to any storage node")
 	} else if successes < n {
 		b.logger.Warn("write operation only partially applied",
 			"connected_nodes", n,
@@ -223,11 +248,22 @@ func (b *storageClientBackend) writeWithClient(
 		)
 	}
 
-	return receipts, nil
+	if !aggregate {
+		return receipts, nil, nil
+	}
+
+	aggregated, err := api.NewAggregatedReceipt(committeeKeys, receipts)
+	if err != nil {
+		b.logger.Error("failed to aggregate storage receipts",
+			"err", err,
+		)
+		return nil, nil, fmt.Errorf("storage/client: failed to aggregate receipts: %w", err)
+	}
+	return receipts, aggregated, nil
 }
 
 func (b *storageClientBackend) Apply(ctx context.Context, request *api.ApplyRequest) ([]*api.Receipt, error) {
-	return b.writeWithClient(
+	receipts, _, err := b.writeWithClient(
 		ctx,
 		request.Namespace,
 		request.DstRound,
@@ -235,10 +271,16 @@ func (b *storageClientBackend) Apply(ctx context.Context, request *api.ApplyRequ
 			return c.Apply(ctx, request)
 		},
 		[]hash.Hash{request.DstRoot},
+		false,
 	)
+	return receipts, err
 }
 
-func (b *storageClientBackend) ApplyBatch(ctx context.Context, request *api.ApplyBatchRequest) ([]*api.Receipt, error) {
+func (b *storageClientBackend) applyBatch(
+	ctx context.Context,
+	request *api.ApplyBatchRequest,
+	aggregate bool,
+) ([]*api.Receipt, *api.AggregatedReceipt, error) {
 	expectedNewRoots := make([]hash.Hash, 0, len(request.Ops))
 	for _, op := range request.Ops {
 		expectedNewRoots = append(expectedNewRoots, op.DstRoot)
@@ -252,11 +294,22 @@ func (b *storageClientBackend) ApplyBatch(ctx context.Context, request *api.Appl
 			return c.ApplyBatch(ctx, request)
 		},
 		expectedNewRoots,
+		aggregate,
 	)
 }
 
+func (b *storageClientBackend) ApplyBatch(ctx context.Context, request *api.ApplyBatchRequest) ([]*api.Receipt, error) {
+	receipts, _, err := b.applyBatch(ctx, request, false)
+	return receipts, err
+}
+
+func (b *storageClientBackend) ApplyBatchAggregated(ctx context.Context, request *api.ApplyBatchRequest) (*api.AggregatedReceipt, error) {
+	_, receipt, err := b.applyBatch(ctx, request, true)
+	return receipt, err
+}
+
 func (b *storageClientBackend) Merge(ctx context.Context, request *api.MergeRequest) ([]*api.Receipt, error) {
-	return b.writeWithClient(
+	receipts, _, err := b.writeWithClient(
 		ctx,
 		request.Namespace,
 		request.Round+1,
@@ -264,11 +317,13 @@ func (b *storageClientBackend) Merge(ctx context.Context, request *api.MergeRequ
 			return c.Merge(ctx, request)
 		},
 		nil,
+		false,
 	)
+	return receipts, err
 }
 
 func (b *storageClientBackend) MergeBatch(ctx context.Context, request *api.MergeBatchRequest) ([]*api.Receipt, error) {
-	return b.writeWithClient(
+	receipts, _, err := b.writeWithClient(
 		ctx,
 		request.Namespace,
 		request.Round+1,
@@ -276,17 +331,129 @@ func (b *storageClientBackend) MergeBatch(ctx context.Context, request *api.Merg
 			return c.MergeBatch(ctx, request)
 		},
 		nil,
+		false,
 	)
+	return receipts, err
+}
+
+// recordRead records the outcome of a read from the given node and submits it as feedback to
+// the committee client's node selection policy. Canceled reads (e.g., hedged reads which lost
+// the race) say nothing about the node and are not recorded.
+func (b *storageClientBackend) recordRead(n *node.Node, latency time.Duration, err error) {
+	if errors.Is(err, context.Canceled) || status.Code(err) == codes.Canceled {
+		return
+	}
+	if err == nil {
+		b.latency.observe(latency)
+	}
+	b.committeeClient.UpdateNodeSelectionPolicy(committee.NodeSelectionFeedback{
+		ID:      n.ID,
+		Bad:     err,
+		Latency: latency,
+	})
+}
+
+// readFromNode performs a single read from the given node and records its latency. Reads which
+// have been aborted due to the context being canceled are not recorded.
+func (b *storageClientBackend) readFromNode(
+	ctx context.Context,
+	ns common.Namespace,
+	conn *committee.ClientConnWithMeta,
+	fn func(context.Context, api.Backend, *node.Node) (interface{}, error),
+) (interface{}, error) {
+	start := time.Now()
+	resp, err := fn(ctx, api.NewStorageClient(conn.ClientConn), conn.Node)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	b.recordRead(conn.Node, time.Since(start), err)
+	if err != nil {
+		b.logger.Error("failed to get response from a storage node",
+			"node", conn.Node,
+			"err", err,
+			"runtime_id", ns,
+		)
+	}
+	return resp, err
+}
+
+// hedgedRead reads from the given nodes in order. If a node does not respond within the
+// hedging delay, the read is additionally sent to the next node and the first successful
+// response is returned. Failed reads are immediately retried on the next node.
+func (b *storageClientBackend) hedgedRead(
+	ctx context.Context,
+	ns common.Namespace,
+	conns []*committee.ClientConnWithMeta,
+	fn func(context.Context, api.Backend, *node.Node) (interface{}, error),
+) (interface{}, error) {
+	readCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Use a buffered channel to allow all "read" goroutines to return as soon as they are
+	// finished, even when their response is no longer needed.
+	ch := make(chan *grpcResponse, len(conns))
+	var next, inFlight int
+	launch := func() {
+		conn := conns[next]
+		next++
+		inFlight++
+
+		go func() {
+			resp, err := b.readFromNode(readCtx, ns, conn, fn)
+			ch <- &grpcResponse{
+				resp: resp,
+				err:  err,
+				node: conn.Node,
+			}
+		}()
+	}
+
+	hedgeDelay := b.latency.hedgeDelay()
+	timer := time.NewTimer(hedgeDelay)
+	defer timer.Stop()
+
+	launch()
+
+	var err error
+	for inFlight > 0 {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+			// The outstanding reads are slow, hedge to the next node.
+			if next < len(conns) && inFlight < maxHedgedReads {
+				b.logger.Debug("hedging read to another storage node",
+					"runtime_id", ns,
+					"hedge_delay", hedgeDelay,
+				)
+				launch()
+			}
+			timer.Reset(hedgeDelay)
+		case rsp := <-ch:
+			inFlight--
+			if rsp.err == nil {
+				return rsp.resp, nil
+			}
+			err = rsp.err
+
+			// The read has failed, try the next node immediately.
+			if next < len(conns) {
+				launch()
+			}
+		}
+	}
+	return nil, err
 }
 
 func (b *storageClientBackend) readWithClient(
 	ctx context.Context,
 	ns common.Namespace,
-	fn func(context.Context, api.Backend) (interface{}, error),
+	hedged bool,
+	fn func(context.Context, api.Backend, *node.Node) (interface{}, error),
 ) (interface{}, error) {
 	var resp interface{}
 	op := func() error {
-		conns := b.committeeClient.GetConnectionsWithMeta()
+		conns := b.committeeClient.GetRankedConnectionsWithMeta()
 		n := len(conns)
 		if n == 0 {
 			b.logger.Error("readWithClient: no connected nodes for runtime",
@@ -295,25 +462,33 @@ func (b *storageClientBackend) readWithClient(
 			return ErrStorageNotAvailable
 		}
 
-		// TODO: Use a more clever approach to choose the order in which to read
-		// from the connected nodes:
-		// https://github.com/oasislabs/oasis-core/issues/1815.
-		rng := rand.New(mathrand.New(cryptorand.Reader))
+		// Try the node chosen by the node selection policy first, followed by the other nodes
+		// in the order preferred by the policy.
+		if picked := b.committeeClient.GetConnectionWithMeta(); picked != nil {
+			for i, conn := range conns {
+				if conn.Node.ID.Equal(picked.Node.ID) {
+					copy(conns[1:i+1], conns[:i])
+					conns[0] = conn
+					break
+				}
+			}
+		}
 
 		var err error
-		for _, randIndex := range rng.Perm(n) {
-			conn := conns[randIndex]
+		if hedged {
+			resp, err = b.hedgedRead(ctx, ns, conns, fn)
+			if ctx.Err() != nil {
+				return backoff.Permanent(ctx.Err())
+			}
+			return err
+		}
 
-			resp, err = fn(ctx, api.NewStorageClient(conn.ClientConn))
+		for _, conn := range conns {
+			resp, err = b.readFromNode(ctx, ns, conn, fn)
 			if ctx.Err() != nil {
 				return backoff.Permanent(ctx.Err())
 			}
 			if err != nil {
-				b.logger.Error("failed to get response from a storage node",
-					"node", conn.Node,
-					"err", err,
-					"runtime_id", ns,
-				)
 				continue
 			}
 			return nil
@@ -330,7 +505,8 @@ func (b *storageClientBackend) SyncGet(ctx context.Context, request *api.GetRequ
 	rsp, err := b.readWithClient(
 		ctx,
 		request.Tree.Root.Namespace,
-		func(ctx context.Context, c api.Backend) (interface{}, error) {
+		true,
+		func(ctx context.Context, c api.Backend, node *node.Node) (interface{}, error) {
 			return c.SyncGet(ctx, request)
 		},
 	)
@@ -344,7 +520,8 @@ func (b *storageClientBackend) SyncGetPrefixes(ctx context.Context, request *api
 	rsp, err := b.readWithClient(
 		ctx,
 		request.Tree.Root.Namespace,
-		func(ctx context.Context, c api.Backend) (interface{}, error) {
+		true,
+		func(ctx context.Context, c api.Backend, node *node.Node) (interface{}, error) {
 			return c.SyncGetPrefixes(ctx, request)
 		},
 	)
@@ -358,7 +535,8 @@ func (b *storageClientBackend) SyncIterate(ctx context.Context, request *api.Ite
 	rsp, err := b.readWithClient(
 		ctx,
 		request.Tree.Root.Namespace,
-		func(ctx context.Context, c api.Backend) (interface{}, error) {
+		true,
+		func(ctx context.Context, c api.Backend, node *node.Node) (interface{}, error) {
 			return c.SyncIterate(ctx, request)
 		},
 	)
@@ -372,8 +550,14 @@ func (b *storageClientBackend) GetDiff(ctx context.Context, request *api.GetDiff
 	rsp, err := b.readWithClient(
 		ctx,
 		request.StartRoot.Namespace,
-		func(ctx context.Context, c api.Backend) (interface{}, error) {
-			return c.GetDiff(ctx, request)
+		// The returned iterator streams the diff so the read cannot be hedged.
+		false,
+		func(ctx context.Context, c api.Backend, node *node.Node) (interface{}, error) {
+			it, err := c.GetDiff(ctx, request)
+			if err != nil || !request.WithProofs {
+				return it, err
+			}
+			return &diffIterator{WriteLogIterator: it, b: b, node: node}, nil
 		},
 	)
 	if err != nil {
@@ -382,11 +566,33 @@ func (b *storageClientBackend) GetDiff(ctx context.Context, request *api.GetDiff
 	return rsp.(api.WriteLogIterator), nil
 }
 
+// diffIterator is a streamed diff which reports a node that fails to provide valid diff proofs
+// to the node selection policy, so that the node is avoided when the diff is retried.
+type diffIterator struct {
+	api.WriteLogIterator
+
+	b    *storageClientBackend
+	node *node.Node
+}
+
+func (it *diffIterator) Next() (bool, error) {
+	more, err := it.WriteLogIterator.Next()
+	if errors.Is(err, api.ErrMissingDiffProof) || errors.Is(err, api.ErrInvalidDiffProof) {
+		it.b.logger.Error("storage node failed to provide valid diff proofs",
+			"node", it.node,
+			"err", err,
+		)
+		it.b.recordRead(it.node, 0, err)
+	}
+	return more, err
+}
+
 func (b *storageClientBackend) GetCheckpoints(ctx context.Context, request *checkpoint.GetCheckpointsRequest) ([]*checkpoint.Metadata, error) {
 	rsp, err := b.readWithClient(
 		ctx,
 		request.Namespace,
-		func(ctx context.Context, c api.Backend) (interface{}, error) {
+		true,
+		func(ctx context.Context, c api.Backend, node *node.Node) (interface{}, error) {
 			return c.GetCheckpoints(ctx, request)
 		},
 	)
@@ -400,7 +606,9 @@ func (b *storageClientBackend) GetCheckpointChunk(ctx context.Context, chunk *ch
 	_, err := b.readWithClient(
 		ctx,
 		chunk.Root.Namespace,
-		func(ctx context.Context, c api.Backend) (interface{}, error) {
+		// The chunk is streamed into the writer so the read cannot be hedged.
+		false,
+		func(ctx context.Context, c api.Backend, node *node.Node) (interface{}, error) {
 			return nil, c.GetCheckpointChunk(ctx, chunk, w)
 		},
 	)
@@ -22,27 +22,35 @@ func newClient(
 	namespace common.Namespace,
 	ident *identity.Identity,
 	nodes committee.NodeDescriptorLookup,
+	committeeWatcher committee.Watcher,
+	opts ...committee.ClientOption,
 ) (api.Backend, error) {
-	committeeClient, err := committee.NewClient(ctx, nodes, committee.WithClientAuthentication(ident))
+	opts = append([]committee.ClientOption{committee.WithClientAuthentication(ident)}, opts...)
+	committeeClient, err := committee.NewClient(ctx, nodes, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("storage/client: failed to create committee client: %w", err)
 	}
 
 	b := &storageClientBackend{
-		ctx:             ctx,
-		logger:          logging.GetLogger("storage/client"),
-		committeeClient: committeeClient,
+		ctx:              ctx,
+		logger:           logging.GetLogger("storage/client"),
+		committeeClient:  committeeClient,
+		committeeWatcher: committeeWatcher,
+		latency:          newLatencyTracker(),
 	}
 	return b, nil
 }
 
 // New creates a new storage client that automatically follows a given runtime's storage committee.
+//
+// Any additional options are passed to the underlying committee client.
 func New(
 	ctx context.Context,
 	namespace common.Namespace,
 	ident *identity.Identity,
 	schedulerBackend scheduler.Backend,
 	registryBackend registry.Backend,
+	opts ...committee.ClientOption,
 ) (api.Backend, error) {
 	committeeWatcher, err := committee.NewWatcher(
 		ctx,
@@ -56,7 +64,7 @@ func New(
 		return nil, fmt.Errorf("storage/client: failed to create committee watcher: %w", err)
 	}
 
-	return newClient(ctx, namespace, ident, committeeWatcher.Nodes())
+	return newClient(ctx, namespace, ident, committeeWatcher.Nodes(), committeeWatcher, opts...)
 }
 
 // NewStatic creates a new storage client that only follows a specific storage node. This is mostly
@@ -73,7 +81,7 @@ func NewStatic(
 		return nil, fmt.Errorf("storage/client: failed to create node descriptor watcher: %w", err)
 	}
 
-	client, err := newClient(ctx, namespace, ident, nw)
+	client, err := newClient(ctx, namespace, ident, nw, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,79 @@
+package client
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// latencyWindowSize is the number of most recent read latencies used to compute the
+	// hedging delay.
+	latencyWindowSize = 128
+	// latencyMinSamples is the minimum number of samples required before the observed
+	// latencies are used to compute the hedging delay.
+	latencyMinSamples = 16
+	// hedgeLatencyPercentile is the latency percentile after which a hedged read is sent to
+	// the next node.
+	hedgeLatencyPercentile = 0.9
+
+	// defaultHedgeDelay is the hedging delay used before enough samples are available.
+	defaultHedgeDelay = 100 * time.Millisecond
+	// minHedgeDelay is the minimum hedging delay.
+	minHedgeDelay = 5 * time.Millisecond
+	// maxHedgeDelay is the maximum hedging delay.
+	maxHedgeDelay = 1 * time.Second
+)
+
+// latencyTracker tracks successful read latencies of storage nodes in order to compute the
+// delay after which a read is hedged to another node. Per-node latencies are tracked by the
+// committee client's node selection policy.
+type latencyTracker struct {
+	sync.Mutex
+
+	// window is a ring buffer of the most recent successful read latencies.
+	window []time.Duration
+	pos    int
+}
+
+// observe records a successful read latency.
+func (lt *latencyTracker) observe(latency time.Duration) {
+	lt.Lock()
+	defer lt.Unlock()
+
+	if len(lt.window) < latencyWindowSize {
+		lt.window = append(lt.window, latency)
+	} else {
+		lt.window[lt.pos] = latency
+	}
+	lt.pos = (lt.pos + 1) % latencyWindowSize
+}
+
+// hedgeDelay returns the delay after which a read should be hedged to another node.
+func (lt *latencyTracker) hedgeDelay() time.Duration {
+	lt.Lock()
+	defer lt.Unlock()
+
+	if len(lt.window) < latencyMinSamples {
+		return defaultHedgeDelay
+	}
+
+	samples := append([]time.Duration{}, lt.window...)
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+	delay := samples[int(hedgeLatencyPercentile*float64(len(samples)-1))]
+
+	switch {
+	case delay < minHedgeDelay:
+		return minHedgeDelay
+	case delay > maxHedgeDelay:
+		return maxHedgeDelay
+	default:
+		return delay
+	}
+}
+
+func newLatencyTracker() *latencyTracker {
+	return &latencyTracker{
+		window: make([]time.Duration, 0, latencyWindowSize),
+	}
+}
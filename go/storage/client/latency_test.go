@@ -0,0 +1,30 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatencyTracker(t *testing.T) {
+	require := require.New(t)
+
+	lt := newLatencyTracker()
+	require.Equal(defaultHedgeDelay, lt.hedgeDelay(), "hedge delay should be the default without samples")
+
+	for i := 0; i < 90; i++ {
+		lt.observe(10 * time.Millisecond)
+	}
+	for i := 0; i < 10; i++ {
+		lt.observe(500 * time.Millisecond)
+	}
+
+	delay := lt.hedgeDelay()
+	require.True(delay >= 10*time.Millisecond && delay < 500*time.Millisecond, "hedge delay should be at the configured percentile")
+
+	for i := 0; i < latencyWindowSize; i++ {
+		lt.observe(10 * time.Second)
+	}
+	require.Equal(maxHedgeDelay, lt.hedgeDelay(), "hedge delay should be clamped")
+}
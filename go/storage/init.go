@@ -14,6 +14,7 @@ import (
 	"github.com/oasislabs/oasis-core/go/common/identity"
 	cmdFlags "github.com/oasislabs/oasis-core/go/oasis-node/cmd/common/flags"
 	registry "github.com/oasislabs/oasis-core/go/registry/api"
+	"github.com/oasislabs/oasis-core/go/runtime/committee"
 	scheduler "github.com/oasislabs/oasis-core/go/scheduler/api"
 	"github.com/oasislabs/oasis-core/go/storage/api"
 	"github.com/oasislabs/oasis-core/go/storage/client"
@@ -30,6 +31,10 @@ const (
 	// CfgMaxCacheSize configures the maximum in-memory cache size.
 	CfgMaxCacheSize = "storage.max_cache_size"
 
+	// CfgClientNodeSelectionPolicy configures the node selection policy used by the storage
+	// client backend.
+	CfgClientNodeSelectionPolicy = "storage.client.node_selection_policy"
+
 	cfgCrashEnabled       = "storage.crash.enabled"
 	cfgInsecureSkipChecks = "storage.debug.insecure_skip_checks"
 )
@@ -65,7 +70,12 @@ func New(
 		cfg.DB = filepath.Join(cfg.DB, database.DefaultFileName(cfg.Backend))
 		impl, err = database.New(cfg)
 	case client.BackendName:
-		impl, err = client.New(ctx, namespace, identity, schedulerBackend, registryBackend)
+		var policy committee.NodeSelectionPolicy
+		policy, err = committee.NewNodeSelectionPolicy(viper.GetString(CfgClientNodeSelectionPolicy), "storage", namespace)
+		if err != nil {
+			return nil, err
+		}
+		impl, err = client.New(ctx, namespace, identity, schedulerBackend, registryBackend, committee.WithNodeSelectionPolicy(policy))
 	default:
 		err = fmt.Errorf("storage: unsupported backend: '%v'", cfg.Backend)
 	}
@@ -87,6 +97,7 @@ func init() {
 	Flags.Bool(cfgCrashEnabled, false, "Enable the crashing storage wrapper")
 	Flags.Int(CfgLRUSlots, 1000, "How many LRU slots to use for Apply call locks in the MKVS tree root cache")
 	Flags.String(CfgMaxCacheSize, "64mb", "Maximum in-memory cache size")
+	Flags.String(CfgClientNodeSelectionPolicy, committee.NodeSelectionPolicyRoundRobin, "Storage client node selection policy (round-robin, latency)")
 
 	Flags.Bool(cfgInsecureSkipChecks, false, "INSECURE: Skip known root checks")
 
@@ -74,6 +74,24 @@ func (w *metricsWrapper) GetConnectedNodes() []*node.Node {
 	return []*node.Node{}
 }
 
+func (w *metricsWrapper) ApplyBatchAggregated(ctx context.Context, request *api.ApplyBatchRequest) (*api.AggregatedReceipt, error) {
+	clientBackend, ok := w.Backend.(api.ClientBackend)
+	if !ok {
+		return nil, api.ErrUnsupported
+	}
+
+	start := time.Now()
+	receipt, err := clientBackend.ApplyBatchAggregated(ctx, request)
+	storageLatency.With(labelApplyBatch).Observe(time.Since(start).Seconds())
+	if err != nil {
+		storageFailures.With(labelApplyBatch).Inc()
+		return nil, err
+	}
+
+	storageCalls.With(labelApplyBatch).Inc()
+	return receipt, nil
+}
+
 func (w *metricsWrapper) Apply(ctx context.Context, request *api.ApplyRequest) ([]*api.Receipt, error) {
 	start := time.Now()
 	receipts, err := w.Backend.Apply(ctx, request)
@@ -42,6 +42,8 @@ type cache struct {
 	valueCapacity uint64
 	// Persist all the nodes and values we obtain from the remote syncer?
 	persistEverythingFromSyncer bool
+	// sharedCache is an optional verified node cache shared between trees.
+	sharedCache *SharedCache
 
 	lruInternal    *list.List
 	lruInternalPos *list.Element
@@ -72,6 +74,7 @@ func (c *cache) close() {
 	// Clear references.
 	c.db = nil
 	c.rs = nil
+	c.sharedCache = nil
 	c.pendingRoot = nil
 	c.lruInternal = nil
 	c.lruInternalPos = nil
@@ -364,7 +367,16 @@ func (c *cache) derefNodePtr(
 		// Commit node to cache.
 		c.commitNode(ptr)
 	case db.ErrNodeNotFound:
-		// Node not found in local node database, try the syncer if available.
+		// Node not found in local node database, try the shared cache if available.
+		if c.sharedCache != nil {
+			if n = c.sharedCache.get(ptr.Hash); n != nil {
+				ptr.Node = n
+				c.commitNode(ptr)
+				return ptr.Node, nil
+			}
+		}
+
+		// Try the syncer if available.
 		if c.rs == syncer.NopReadSyncer {
 			return nil, err
 		}
@@ -453,6 +465,10 @@ func (c *cache) remoteSync(ctx context.Context, ptr *node.Pointer, fetcher readS
 		if c.persistEverythingFromSyncer {
 			_ = dbSubtree.PutNode(0, p)
 		}
+		// Make verified nodes available to other trees sharing the cache.
+		if c.sharedCache != nil {
+			c.sharedCache.put(p.Node)
+		}
 		return nil
 	}
 
@@ -75,6 +75,13 @@ type Tree interface {
 	// starting with given prefixes.
 	PrefetchPrefixes(ctx context.Context, prefixes [][]byte, limit uint16) error
 
+	// ProveKeys builds a single Merkle proof covering the lookup paths of all
+	// of the given keys. The proof can be used to verify presence (including
+	// the values) or absence of the keys (see VerifyWriteLogProof).
+	//
+	// The tree must not have any uncommitted modifications.
+	ProveKeys(ctx context.Context, keys [][]byte) (*syncer.Proof, error)
+
 	// ApplyWriteLog applies the operations from a write log to the current tree.
 	//
 	// The caller is responsible for calling Commit.
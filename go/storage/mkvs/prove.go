@@ -0,0 +1,116 @@
+package mkvs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/oasislabs/oasis-core/go/common/crypto/hash"
+	"github.com/oasislabs/oasis-core/go/storage/mkvs/node"
+	"github.com/oasislabs/oasis-core/go/storage/mkvs/syncer"
+	"github.com/oasislabs/oasis-core/go/storage/mkvs/writelog"
+)
+
+// ErrIncompleteProof is the error returned when a proof does not contain
+// enough nodes to look up a given key.
+var ErrIncompleteProof = errors.New("mkvs: incomplete proof")
+
+// Implements Tree.
+func (t *tree) ProveKeys(ctx context.Context, keys [][]byte) (*syncer.Proof, error) {
+	t.cache.Lock()
+	defer t.cache.Unlock()
+
+	if t.cache.isClosed() {
+		return nil, ErrClosed
+	}
+	if !t.cache.pendingRoot.IsClean() {
+		return nil, syncer.ErrDirtyRoot
+	}
+
+	// Remember where the path from root to target node ends (will end).
+	t.cache.markPosition()
+
+	pb := syncer.NewProofBuilder(t.cache.syncRoot.Hash)
+	opts := doGetOptions{
+		proofBuilder: pb,
+	}
+	for _, key := range keys {
+		if _, err := t.doGet(ctx, t.cache.pendingRoot, 0, key, opts, false); err != nil {
+			return nil, err
+		}
+	}
+	return pb.Build(ctx)
+}
+
+// VerifyWriteLogProof verifies that the given proof is a valid proof for the
+// given root and that all of the write log entries match the proven state. For
+// insertions the key must be present with the same value while for deletions
+// the key must not be present.
+func VerifyWriteLogProof(ctx context.Context, root hash.Hash, proof *syncer.Proof, wl writelog.WriteLog) error {
+	var pv syncer.ProofVerifier
+	ptr, err := pv.VerifyProof(ctx, root, proof)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range wl {
+		value, found, err := lookupProof(ptr, 0, entry.Key)
+		if err != nil {
+			return err
+		}
+
+		switch entry.Type() {
+		case writelog.LogInsert:
+			if !found || !bytes.Equal(value, entry.Value) {
+				return fmt.Errorf("mkvs: write log entry does not match proof (key: %X)", entry.Key)
+			}
+		case writelog.LogDelete:
+			if found {
+				return fmt.Errorf("mkvs: removed key present in proof (key: %X)", entry.Key)
+			}
+		}
+	}
+	return nil
+}
+
+// lookupProof looks up a key in a subtree obtained from a verified proof. It
+// follows the same traversal as doGet.
+func lookupProof(ptr *node.Pointer, bitDepth node.Depth, key node.Key) ([]byte, bool, error) {
+	if ptr == nil {
+		return nil, false, nil
+	}
+	if ptr.Node == nil {
+		if ptr.Hash.IsEmpty() {
+			return nil, false, nil
+		}
+		return nil, false, ErrIncompleteProof
+	}
+
+	switch n := ptr.Node.(type) {
+	case *node.InternalNode:
+		bitLength := bitDepth + n.LabelBitLength
+
+		// Does lookup key end here? Look into LeafNode.
+		if key.BitLength() == bitLength {
+			return lookupProof(n.LeafNode, bitLength, key)
+		}
+		// Lookup key is too short for the current n.Label. It's not stored.
+		if key.BitLength() < bitLength {
+			return nil, false, nil
+		}
+		// Continue recursively based on a bit value.
+		if key.GetBit(bitLength) {
+			return lookupProof(n.Right, bitLength, key)
+		}
+		return lookupProof(n.Left, bitLength, key)
+	case *node.LeafNode:
+		// Reached a leaf node, check if key matches.
+		if n.Key.Equal(key) {
+			return n.Value, true, nil
+		}
+		return nil, false, nil
+	default:
+		panic(fmt.Sprintf("mkvs: unknown node type: %+v", n))
+	}
+}
@@ -0,0 +1,59 @@
+package mkvs
+
+import (
+	"fmt"
+
+	"github.com/oasislabs/oasis-core/go/common/cache/lru"
+	"github.com/oasislabs/oasis-core/go/common/crypto/hash"
+	"github.com/oasislabs/oasis-core/go/storage/mkvs/node"
+)
+
+// SharedCache is a size-limited cache of verified nodes that can be shared between multiple tree
+// instances (e.g., multiple trees created for the same root).
+//
+// Only nodes that were obtained from a remote syncer and whose proofs were successfully verified
+// are inserted into the shared cache. As nodes are content-addressed, any tree that references a
+// node by its hash can safely reuse the cached node without contacting the remote syncer.
+type SharedCache struct {
+	nodes *lru.Cache
+}
+
+// NewSharedCache creates a new shared verified node cache with the given capacity in bytes.
+func NewSharedCache(capacityBytes uint64) (*SharedCache, error) {
+	nodes, err := lru.New(lru.Capacity(capacityBytes, true))
+	if err != nil {
+		return nil, fmt.Errorf("mkvs: failed to create shared cache: %w", err)
+	}
+	return &SharedCache{nodes: nodes}, nil
+}
+
+// get looks up a node with the given hash and returns a copy containing only hash references to
+// its children. If the node is not available in the cache, nil is returned.
+func (sc *SharedCache) get(h hash.Hash) node.Node {
+	v, ok := sc.nodes.Get(h)
+	if !ok {
+		return nil
+	}
+	return v.(node.Node).ExtractUnchecked()
+}
+
+// put inserts a verified clean node into the cache.
+func (sc *SharedCache) put(n node.Node) {
+	if n == nil || !n.IsClean() {
+		return
+	}
+	if nd, ok := n.(*node.InternalNode); ok && nd.LeafNode != nil && nd.LeafNode.Node == nil {
+		// Leaf node has been evicted, we cannot cache an incomplete internal node.
+		return
+	}
+	_ = sc.nodes.Put(n.GetHash(), n.ExtractUnchecked())
+}
+
+// WithSharedCache configures the tree to use the given shared verified node cache. Before going to
+// the remote syncer, the tree will consult the shared cache and all verified nodes obtained from
+// the remote syncer will be inserted into the shared cache.
+func WithSharedCache(sc *SharedCache) Option {
+	return func(t *tree) {
+		t.cache.sharedCache = sc
+	}
+}
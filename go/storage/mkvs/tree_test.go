@@ -621,6 +621,93 @@ func testSyncerBasic(t *testing.T, ndb db.NodeDB, factory NodeDBFactory) {
 	require.Equal(t, 0, stats.SyncIterateCount, "SyncIterate count")
 }
 
+func testSyncerSharedCache(t *testing.T, ndb db.NodeDB, factory NodeDBFactory) {
+	ctx := context.Background()
+	keys, values, r, tree := generatePopulatedTree(t, ndb)
+
+	sc, err := NewSharedCache(16 * 1024 * 1024)
+	require.NoError(t, err, "NewSharedCache")
+
+	// The first remote tree should populate the shared cache.
+	stats := syncer.NewStatsCollector(tree)
+	remoteTree := NewWithRoot(stats, nil, r, Capacity(0, 0), WithSharedCache(sc))
+	for i := 0; i < len(keys); i++ {
+		var value []byte
+		value, err = remoteTree.Get(ctx, keys[i])
+		require.NoError(t, err, "Get")
+		require.Equal(t, values[i], value)
+	}
+	require.Equal(t, len(keys), stats.SyncGetCount, "SyncGet count")
+	remoteTree.Close()
+
+	// The second remote tree for the same root should be served from the shared cache.
+	stats = syncer.NewStatsCollector(tree)
+	remoteTree = NewWithRoot(stats, nil, r, Capacity(0, 0), WithSharedCache(sc))
+	defer remoteTree.Close()
+	for i := 0; i < len(keys); i++ {
+		var value []byte
+		value, err = remoteTree.Get(ctx, keys[i])
+		require.NoError(t, err, "Get")
+		require.Equal(t, values[i], value)
+	}
+	require.Equal(t, 0, stats.SyncGetCount, "SyncGet count")
+	require.Equal(t, 0, stats.SyncGetPrefixesCount, "SyncGetPrefixes count")
+	require.Equal(t, 0, stats.SyncIterateCount, "SyncIterate count")
+}
+
+func testProveKeys(t *testing.T, ndb db.NodeDB, factory NodeDBFactory) {
+	ctx := context.Background()
+	keys, values, r, tree := generatePopulatedTree(t, ndb)
+
+	remoteTree := NewWithRoot(tree, nil, r, Capacity(0, 0))
+	defer remoteTree.Close()
+
+	// Build a proof for some existing keys and a missing key.
+	missingKey := []byte("this key does not exist")
+	var wl writelog.WriteLog
+	proveKeys := [][]byte{missingKey}
+	for i := 0; i < 10; i++ {
+		wl = append(wl, writelog.LogEntry{Key: keys[i], Value: values[i]})
+		proveKeys = append(proveKeys, keys[i])
+	}
+	wl = append(wl, writelog.LogEntry{Key: missingKey})
+
+	proof, err := remoteTree.ProveKeys(ctx, proveKeys)
+	require.NoError(t, err, "ProveKeys")
+	err = VerifyWriteLogProof(ctx, r.Hash, proof, wl)
+	require.NoError(t, err, "VerifyWriteLogProof")
+
+	// Verification against a different root should fail.
+	var otherRoot hash.Hash
+	otherRoot.FromBytes([]byte("other root"))
+	err = VerifyWriteLogProof(ctx, otherRoot, proof, wl)
+	require.Error(t, err, "VerifyWriteLogProof should fail for a different root")
+
+	// Verification of a modified value should fail.
+	badWl := append(writelog.WriteLog{}, wl...)
+	badWl[0] = writelog.LogEntry{Key: keys[0], Value: []byte("bad value")}
+	err = VerifyWriteLogProof(ctx, r.Hash, proof, badWl)
+	require.Error(t, err, "VerifyWriteLogProof should fail for a modified value")
+
+	// Verification of a removed key which exists should fail.
+	badWl = append(writelog.WriteLog{}, wl...)
+	badWl[0] = writelog.LogEntry{Key: keys[0]}
+	err = VerifyWriteLogProof(ctx, r.Hash, proof, badWl)
+	require.Error(t, err, "VerifyWriteLogProof should fail for an existing removed key")
+
+	// Verification of an inserted key which does not exist should fail.
+	badWl = append(writelog.WriteLog{}, wl...)
+	badWl[len(badWl)-1] = writelog.LogEntry{Key: missingKey, Value: []byte("value")}
+	err = VerifyWriteLogProof(ctx, r.Hash, proof, badWl)
+	require.Error(t, err, "VerifyWriteLogProof should fail for a missing inserted key")
+
+	// Verification of keys not covered by the proof should fail.
+	badWl = append(writelog.WriteLog{}, wl...)
+	badWl = append(badWl, writelog.LogEntry{Key: keys[len(keys)-1], Value: values[len(values)-1]})
+	err = VerifyWriteLogProof(ctx, r.Hash, proof, badWl)
+	require.Error(t, err, "VerifyWriteLogProof should fail for keys not covered by the proof")
+}
+
 func testSyncerRootEmptyLabelNeedsDeref(t *testing.T, ndb db.NodeDB, factory NodeDBFactory) {
 	ctx := context.Background()
 	tree := New(nil, ndb)
@@ -2001,6 +2088,8 @@ func testBackend(
 		{"Remove", testRemove},
 		{"ApplyWriteLog", testApplyWriteLog},
 		{"SyncerBasic", testSyncerBasic},
+		{"SyncerSharedCache", testSyncerSharedCache},
+		{"ProveKeys", testProveKeys},
 		{"SyncerRootEmptyLabelNeedsDeref", testSyncerRootEmptyLabelNeedsDeref},
 		{"SyncerRemove", testSyncerRemove},
 		{"SyncerInsert", testSyncerInsert},
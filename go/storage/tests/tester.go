@@ -215,6 +215,13 @@ func testBasic(t *testing.T, localBackend api.LocalBackend, backend api.Backend,
 	sort.Slice(getDiffWl, makeWriteLogLess(getDiffWl))
 	require.Equal(t, getDiffWl, originalWl)
 
+	// Getting the write log with proofs should return the same write log.
+	it, err = backend.GetDiff(ctx, &api.GetDiffRequest{StartRoot: root, EndRoot: newRoot, WithProofs: true})
+	require.NoError(t, err, "GetDiff(WithProofs)")
+	getDiffWl = foldWriteLogIterator(t, it)
+	sort.Slice(getDiffWl, makeWriteLogLess(getDiffWl))
+	require.Equal(t, getDiffWl, originalWl)
+
 	// Now try applying the same operations again, we should get the same root.
 	receipts, err = backend.Apply(ctx, &api.ApplyRequest{
 		Namespace: namespace,
@@ -235,6 +235,31 @@ func (e *EpochSnapshot) VerifyCommitteeSignatures(kind scheduler.CommitteeKind,
 	return nil
 }
 
+// CommitteePublicKeys returns the public keys of the current committee
+// members of the given kind, in committee order.
+//
+// Implements commitment.SignatureVerifier.
+func (e *EpochSnapshot) CommitteePublicKeys(kind scheduler.CommitteeKind) ([]signature.PublicKey, error) {
+	var committee *CommitteeInfo
+	switch kind {
+	case scheduler.KindStorage:
+		committee = e.storageCommittee
+	case scheduler.KindComputeTxnScheduler:
+		committee = e.txnSchedulerCommittee
+	default:
+		return nil, fmt.Errorf("epoch: unsupported committee kind: %s", kind)
+	}
+	if committee == nil {
+		return nil, fmt.Errorf("epoch: no %s committee", kind)
+	}
+
+	pks := make([]signature.PublicKey, 0, len(committee.Committee.Members))
+	for _, m := range committee.Committee.Members {
+		pks = append(pks, m.PublicKey)
+	}
+	return pks, nil
+}
+
 // Group encapsulates communication with a group of nodes in the compute committees.
 type Group struct {
 	sync.RWMutex
@@ -10,12 +10,14 @@ import (
 	"github.com/oasislabs/oasis-core/go/common/identity"
 	"github.com/oasislabs/oasis-core/go/common/logging"
 	consensus "github.com/oasislabs/oasis-core/go/consensus/api"
+	control "github.com/oasislabs/oasis-core/go/control/api"
 	keymanagerApi "github.com/oasislabs/oasis-core/go/keymanager/api"
 	keymanagerClient "github.com/oasislabs/oasis-core/go/keymanager/client"
 	roothash "github.com/oasislabs/oasis-core/go/roothash/api"
 	"github.com/oasislabs/oasis-core/go/roothash/api/block"
 	"github.com/oasislabs/oasis-core/go/runtime/committee"
 	runtimeRegistry "github.com/oasislabs/oasis-core/go/runtime/registry"
+	scheduler "github.com/oasislabs/oasis-core/go/scheduler/api"
 	storage "github.com/oasislabs/oasis-core/go/storage/api"
 	"github.com/oasislabs/oasis-core/go/worker/common/p2p"
 )
@@ -150,6 +152,34 @@ func (n *Node) AddHooks(hooks NodeHooks) {
 	n.hooks = append(n.hooks, hooks)
 }
 
+// GetStatus returns the common committee node status.
+func (n *Node) GetStatus(ctx context.Context) (*control.RuntimeStatus, error) {
+	n.CrossNode.Lock()
+	defer n.CrossNode.Unlock()
+
+	var status control.RuntimeStatus
+	if n.CurrentBlock != nil {
+		status.LatestRound = n.CurrentBlock.Header.Round
+		status.LatestHash = n.CurrentBlock.Header.EncodedHash()
+	}
+
+	epoch := n.Group.GetEpochSnapshot()
+	if epoch.GetRuntime() != nil {
+		status.Committee = &control.CommitteeStatus{
+			Epoch:                    epoch.epochNumber,
+			ExecutorRole:             epoch.executorRole,
+			TransactionSchedulerRole: epoch.txnSchedulerRole,
+			MergeRole:                epoch.mergeRole,
+			StorageRole:              scheduler.Invalid,
+		}
+		if sc := epoch.GetStorageCommittee(); sc != nil {
+			status.Committee.StorageRole = sc.Role
+		}
+	}
+
+	return &status, nil
+}
+
 func (n *Node) getMetricLabels() prometheus.Labels {
 	return prometheus.Labels{
 		"runtime": n.Runtime.ID().String(),
@@ -2,12 +2,15 @@ package common
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	flag "github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
 	"github.com/oasislabs/oasis-core/go/common"
+	"github.com/oasislabs/oasis-core/go/common/grpc"
 	"github.com/oasislabs/oasis-core/go/common/logging"
 	"github.com/oasislabs/oasis-core/go/common/node"
 	ias "github.com/oasislabs/oasis-core/go/ias/api"
@@ -25,6 +28,16 @@ var (
 
 	cfgClientAddresses = "worker.client.addresses"
 
+	// CfgClientRateLimit configures the default number of calls per second
+	// allowed for each client and method on the worker client port.
+	CfgClientRateLimit = "worker.client.rate_limit.rate"
+	// CfgClientRateLimitBurst configures the default call burst allowed for
+	// each client and method on the worker client port.
+	CfgClientRateLimitBurst = "worker.client.rate_limit.burst"
+	// CfgClientRateLimitMethods configures per-method rate limits on the
+	// worker client port.
+	CfgClientRateLimitMethods = "worker.client.rate_limit.methods"
+
 	// CfgSentryAddresses configures addresses and public keys of sentry nodes the worker should
 	// connect to.
 	CfgSentryAddresses = "worker.sentry.address"
@@ -70,6 +83,10 @@ type Config struct { // nolint: maligned
 	ClientAddresses []node.Address
 	SentryAddresses []node.TLSAddress
 
+	// ClientRateLimit contains the rate limiting configuration for the
+	// worker client port. It may be nil if rate limiting is disabled.
+	ClientRateLimit *grpc.RateLimitConfig
+
 	// RuntimeHost contains configuration for a worker that hosts runtimes. It may be nil if the
 	// worker is not configured to host runtimes.
 	RuntimeHost *RuntimeHostConfig
@@ -114,6 +131,56 @@ func (c *Config) GetNodeAddresses() ([]node.Address, error) {
 	return addresses, nil
 }
 
+func parseRateLimitConfig() (*grpc.RateLimitConfig, error) {
+	cfg := grpc.RateLimitConfig{
+		Default: grpc.RateLimit{
+			Rate:  viper.GetFloat64(CfgClientRateLimit),
+			Burst: viper.GetUint64(CfgClientRateLimitBurst),
+		},
+		Methods: make(map[string]grpc.RateLimit),
+	}
+
+	for method, limit := range viper.GetStringMapString(CfgClientRateLimitMethods) {
+		var (
+			rl  grpc.RateLimit
+			err error
+		)
+		atoms := strings.SplitN(limit, ":", 2)
+		if rl.Rate, err = strconv.ParseFloat(atoms[0], 64); err != nil {
+			return nil, fmt.Errorf("worker: bad rate limit for method '%s': %w", method, err)
+		}
+		if len(atoms) == 2 {
+			if rl.Burst, err = strconv.ParseUint(atoms[1], 10, 64); err != nil {
+				return nil, fmt.Errorf("worker: bad rate limit burst for method '%s': %w", method, err)
+			}
+		}
+		cfg.Methods[method] = rl
+	}
+
+	if cfg.Default.Rate <= 0 && len(cfg.Methods) == 0 {
+		return nil, nil
+	}
+	return &cfg, nil
+}
+
+// ParseSentryAddresses parses the configured sentry node addresses.
+func ParseSentryAddresses() ([]node.TLSAddress, error) {
+	return ParseSentryAddressList(viper.GetStringSlice(CfgSentryAddresses))
+}
+
+// ParseSentryAddressList parses the given sentry node addresses.
+func ParseSentryAddressList(addresses []string) ([]node.TLSAddress, error) {
+	var sentryAddresses []node.TLSAddress
+	for _, v := range addresses {
+		var tlsAddr node.TLSAddress
+		if err := tlsAddr.UnmarshalText([]byte(v)); err != nil {
+			return nil, fmt.Errorf("worker: bad sentry address (%s): %w", v, err)
+		}
+		sentryAddresses = append(sentryAddresses, tlsAddr)
+	}
+	return sentryAddresses, nil
+}
+
 // NewConfig creates a new worker config.
 func NewConfig(ias ias.Endpoint) (*Config, error) {
 	// Parse register address overrides.
@@ -123,19 +190,22 @@ func NewConfig(ias ias.Endpoint) (*Config, error) {
 	}
 
 	// Parse sentry configuration.
-	var sentryAddresses []node.TLSAddress
-	for _, v := range viper.GetStringSlice(CfgSentryAddresses) {
-		var tlsAddr node.TLSAddress
-		if err = tlsAddr.UnmarshalText([]byte(v)); err != nil {
-			return nil, fmt.Errorf("worker: bad sentry address (%s): %w", v, err)
-		}
-		sentryAddresses = append(sentryAddresses, tlsAddr)
+	sentryAddresses, err := ParseSentryAddresses()
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse rate limiting configuration.
+	clientRateLimit, err := parseRateLimitConfig()
+	if err != nil {
+		return nil, err
 	}
 
 	cfg := Config{
 		ClientPort:           uint16(viper.GetInt(CfgClientPort)),
 		ClientAddresses:      clientAddresses,
 		SentryAddresses:      sentryAddresses,
+		ClientRateLimit:      clientRateLimit,
 		StorageCommitTimeout: viper.GetDuration(cfgStorageCommitTimeout),
 		logger:               logging.GetLogger("worker/config"),
 	}
@@ -230,6 +300,10 @@ func init() {
 	Flags.StringSlice(cfgClientAddresses, []string{}, "Address/port(s) to use for client connections when registering this node (if not set, all non-loopback local interfaces will be used)")
 	Flags.StringSlice(CfgSentryAddresses, []string{}, fmt.Sprintf("Address(es) of sentry node(s) to connect to of the form [PubKey@]ip:port (where PubKey@ part represents base64 encoded node TLS public key)"))
 
+	Flags.Float64(CfgClientRateLimit, 0, "Number of calls per second allowed for each client and method on the client port (0 = unlimited)")
+	Flags.Uint64(CfgClientRateLimitBurst, 0, "Maximum call burst allowed for each client and method on the client port")
+	Flags.StringToString(CfgClientRateLimitMethods, nil, "Per-method rate limits on the client port (format: <method>=<rate>[:<burst>],...)")
+
 	Flags.String(CfgRuntimeProvisioner, RuntimeProvisionerSandboxed, "Runtime provisioner to use")
 	Flags.String(CfgRuntimeSGXLoader, "", "(for SGX runtimes) Path to SGXS runtime loader binary")
 	Flags.StringToString(CfgRuntimePaths, nil, "Paths to runtime resources (format: <rt1-ID>=<path>,<rt2-ID>=<path>)")
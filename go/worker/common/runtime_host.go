@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/oasislabs/oasis-core/go/common/pubsub"
+	control "github.com/oasislabs/oasis-core/go/control/api"
 	"github.com/oasislabs/oasis-core/go/runtime/host"
 	"github.com/oasislabs/oasis-core/go/runtime/host/protocol"
 	runtimeRegistry "github.com/oasislabs/oasis-core/go/runtime/registry"
@@ -19,6 +21,7 @@ type RuntimeHostNode struct {
 	notifier protocol.Notifier
 
 	runtime host.Runtime
+	status  *control.RuntimeHostStatus
 }
 
 // ProvisionHostedRuntime provisions the configured runtime.
@@ -50,14 +53,69 @@ func (n *RuntimeHostNode) ProvisionHostedRuntime(ctx context.Context) (host.Runt
 	}
 	notifier := n.factory.NewNotifier(ctx, prt)
 
+	// Track the hosted runtime status.
+	evCh, evSub, err := prt.WatchEvents(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to subscribe to runtime events: %w", err)
+	}
+
 	n.Lock()
 	n.runtime = prt
 	n.notifier = notifier
+	n.status = &control.RuntimeHostStatus{
+		State: control.RuntimeHostStateProvisioned,
+	}
 	n.Unlock()
 
+	go n.watchStatus(ctx, evCh, evSub)
+
 	return prt, notifier, nil
 }
 
+func (n *RuntimeHostNode) watchStatus(ctx context.Context, evCh <-chan *host.Event, evSub pubsub.ClosableSubscription) {
+	defer evSub.Close()
+
+	for {
+		var (
+			ev *host.Event
+			ok bool
+		)
+		select {
+		case <-ctx.Done():
+			return
+		case ev, ok = <-evCh:
+			if !ok {
+				return
+			}
+		}
+
+		n.Lock()
+		switch {
+		case ev.Started != nil:
+			version := ev.Started.Version
+			n.status = &control.RuntimeHostStatus{
+				State:   control.RuntimeHostStateStarted,
+				Version: &version,
+				TEE:     ev.Started.CapabilityTEE,
+			}
+		case ev.Updated != nil:
+			status := *n.status
+			status.TEE = ev.Updated.CapabilityTEE
+			n.status = &status
+		case ev.FailedToStart != nil:
+			n.status = &control.RuntimeHostStatus{
+				State: control.RuntimeHostStateFailed,
+				Error: ev.FailedToStart.Error.Error(),
+			}
+		case ev.Stopped != nil:
+			n.status = &control.RuntimeHostStatus{
+				State: control.RuntimeHostStateStopped,
+			}
+		}
+		n.Unlock()
+	}
+}
+
 // GetHostedRuntime returns the provisioned hosted runtime (if any).
 func (n *RuntimeHostNode) GetHostedRuntime() host.Runtime {
 	n.Lock()
@@ -66,6 +124,18 @@ func (n *RuntimeHostNode) GetHostedRuntime() host.Runtime {
 	return rt
 }
 
+// GetHostedRuntimeStatus returns the status of the provisioned hosted runtime (if any).
+func (n *RuntimeHostNode) GetHostedRuntimeStatus() *control.RuntimeHostStatus {
+	n.Lock()
+	defer n.Unlock()
+
+	if n.status == nil {
+		return nil
+	}
+	status := *n.status
+	return &status
+}
+
 // RuntimeHostHandlerFactory is an interface that can be used to create new runtime handlers and
 // notifiers when provisioning hosted runtimes.
 type RuntimeHostHandlerFactory interface {
@@ -2,6 +2,7 @@ package common
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/oasislabs/oasis-core/go/common"
 	"github.com/oasislabs/oasis-core/go/common/grpc"
@@ -9,6 +10,8 @@ import (
 	policyAPI "github.com/oasislabs/oasis-core/go/common/grpc/policy/api"
 	"github.com/oasislabs/oasis-core/go/common/identity"
 	"github.com/oasislabs/oasis-core/go/common/logging"
+	"github.com/oasislabs/oasis-core/go/common/node"
+	"github.com/oasislabs/oasis-core/go/common/pubsub"
 	consensus "github.com/oasislabs/oasis-core/go/consensus/api"
 	genesis "github.com/oasislabs/oasis-core/go/genesis/api"
 	ias "github.com/oasislabs/oasis-core/go/ias/api"
@@ -20,9 +23,13 @@ import (
 
 // Worker is a garbage bag with lower level services and common runtime objects.
 type Worker struct {
+	sync.RWMutex
+
 	enabled bool
 	cfg     Config
 
+	sentryNotifier *pubsub.Broker
+
 	Identity          *identity.Identity
 	Consensus         consensus.Backend
 	Grpc              *grpc.Server
@@ -140,9 +147,36 @@ func (w *Worker) Initialized() <-chan struct{} {
 
 // GetConfig returns the worker's configuration.
 func (w *Worker) GetConfig() Config {
+	w.RLock()
+	defer w.RUnlock()
+
 	return w.cfg
 }
 
+// SetSentryAddresses changes the addresses of the sentry nodes the worker
+// should connect to and notifies all sentry address watchers.
+func (w *Worker) SetSentryAddresses(addrs []node.TLSAddress) {
+	w.Lock()
+	w.cfg.SentryAddresses = addrs
+	w.Unlock()
+
+	w.logger.Info("sentry addresses updated",
+		"sentry_addresses", addrs,
+	)
+
+	w.sentryNotifier.Broadcast(addrs)
+}
+
+// WatchSentryAddresses returns a channel that produces the new sentry
+// addresses each time they are changed via SetSentryAddresses.
+func (w *Worker) WatchSentryAddresses() (<-chan []node.TLSAddress, *pubsub.Subscription) {
+	typedCh := make(chan []node.TLSAddress)
+	sub := w.sentryNotifier.Subscribe()
+	sub.Unwrap(typedCh)
+
+	return typedCh, sub
+}
+
 // GetRuntimes returns a map of registered runtimes.
 func (w *Worker) GetRuntimes() map[common.Namespace]*committee.Node {
 	return w.runtimes
@@ -221,6 +255,7 @@ func newWorker(
 	w := &Worker{
 		enabled:           enabled,
 		cfg:               cfg,
+		sentryNotifier:    pubsub.NewBroker(false),
 		Identity:          identity,
 		Consensus:         consensus,
 		Grpc:              grpc,
@@ -267,9 +302,10 @@ func New(
 
 	// Create externally-accessible gRPC server.
 	serverConfig := &grpc.ServerConfig{
-		Name:     "external",
-		Port:     cfg.ClientPort,
-		Identity: identity,
+		Name:      "external",
+		Port:      cfg.ClientPort,
+		Identity:  identity,
+		RateLimit: cfg.ClientRateLimit,
 	}
 	grpc, err := grpc.NewServer(serverConfig)
 	if err != nil {
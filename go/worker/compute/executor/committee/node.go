@@ -627,11 +627,43 @@ func (n *Node) proposeBatchLocked(batch *protocol.ComputedBatch) {
 			},
 		}
 
-		receipts, err := n.commonNode.Storage.ApplyBatch(ctx, &storage.ApplyBatchRequest{
+		request := &storage.ApplyBatchRequest{
 			Namespace: lastHeader.Namespace,
 			DstRound:  lastHeader.Round + 1,
 			Ops:       applyOps,
-		})
+		}
+
+		// Prefer a compact aggregated storage receipt if supported by the storage backend.
+		if clientBackend, ok := n.commonNode.Storage.(storage.ClientBackend); ok {
+			receipt, err := clientBackend.ApplyBatchAggregated(ctx, request)
+			switch {
+			case err == nil:
+				var storageCommittee []signature.PublicKey
+				if storageCommittee, err = epoch.CommitteePublicKeys(scheduler.KindStorage); err != nil {
+					n.logger.Error("failed to get storage committee public keys",
+						"err", err,
+					)
+					return err
+				}
+				proposedResults.StorageReceipt = receipt
+				if err = proposedResults.VerifyAggregatedStorageReceipt(lastHeader.Namespace, lastHeader.Round+1, storageCommittee); err != nil {
+					n.logger.Error("failed to validate aggregated receipt",
+						"err", err,
+					)
+					return err
+				}
+				return nil
+			case errors.Is(err, storage.ErrUnsupported):
+				// Fall back to individual receipts.
+			default:
+				n.logger.Error("failed to apply to storage",
+					"err", err,
+				)
+				return err
+			}
+		}
+
+		receipts, err := n.commonNode.Storage.ApplyBatch(ctx, request)
 		if err != nil {
 			n.logger.Error("failed to apply to storage",
 				"err", err,
@@ -16,6 +16,7 @@ import (
 	"github.com/oasislabs/oasis-core/go/common/logging"
 	"github.com/oasislabs/oasis-core/go/common/node"
 	"github.com/oasislabs/oasis-core/go/common/pubsub"
+	"github.com/oasislabs/oasis-core/go/common/tracing"
 	consensus "github.com/oasislabs/oasis-core/go/consensus/api"
 	roothash "github.com/oasislabs/oasis-core/go/roothash/api"
 	"github.com/oasislabs/oasis-core/go/roothash/api/block"
@@ -219,6 +220,7 @@ func (n *Node) newStateWaitingForResultsLocked(epoch *committee.EpochSnapshot) S
 		pool:             pool,
 		timer:            time.NewTimer(infiniteTimeout),
 		consensusTimeout: make(map[hash.Hash]bool),
+		batchSpanCtx:     new(opentracing.SpanContext),
 	}
 }
 
@@ -273,8 +275,14 @@ func (n *Node) HandleNewBlockLocked(blk *block.Block) {
 // HandleResultsFromExecutorWorkerLocked processes results from an executor worker.
 // Guarded by n.commonNode.CrossNode.
 func (n *Node) HandleResultsFromExecutorWorkerLocked(spanCtx opentracing.SpanContext, commit *commitment.ExecutorCommitment) {
-	// TODO: Context.
-	if err := n.handleResultsLocked(context.TODO(), commit); err != nil {
+	ctx := n.ctx
+	if spanCtx != nil {
+		span := opentracing.StartSpan("HandleResultsFromExecutorWorker", opentracing.FollowsFrom(spanCtx))
+		defer span.Finish()
+		ctx = opentracing.ContextWithSpan(ctx, span)
+	}
+
+	if err := n.handleResultsLocked(ctx, commit); err != nil {
 		n.logger.Warn("failed to handle results from local executor worker",
 			"err", err,
 		)
@@ -293,6 +301,11 @@ func (n *Node) handleResultsLocked(ctx context.Context, commit *commitment.Execu
 		"node_id", commit.Signature.PublicKey,
 	)
 
+	// Continue the trace of the first batch received in this round.
+	if span := opentracing.SpanFromContext(ctx); span != nil && *state.batchSpanCtx == nil {
+		*state.batchSpanCtx = span.Context()
+	}
+
 	epoch := n.commonNode.Group.GetEpochSnapshot()
 	sp, err := state.pool.AddExecutorCommitment(ctx, n.commonNode.CurrentBlock, epoch, epoch, commit)
 	if err != nil {
@@ -376,9 +389,15 @@ func (n *Node) tryFinalizeResultsLocked(pool *commitment.Pool, didTimeout bool)
 
 		// Submit executor commit to BFT.
 		ccs := pool.GetExecutorCommitments()
+		batchSpanCtx := *state.batchSpanCtx
 		go func() {
+			span, ctx := tracing.StartSpanWithContext(n.roundCtx, "roothash.ExecutorCommit",
+				opentracing.ChildOf(batchSpanCtx),
+			)
+			defer span.Finish()
+
 			tx := roothash.NewExecutorCommitTx(0, nil, n.commonNode.Runtime.ID(), ccs)
-			ccErr := consensus.SignAndSubmitTx(n.roundCtx, n.commonNode.Consensus, n.commonNode.Identity.NodeSigner, tx)
+			ccErr := consensus.SignAndSubmitTx(ctx, n.commonNode.Consensus, n.commonNode.Identity.NodeSigner, tx)
 
 			switch ccErr {
 			case nil:
@@ -411,16 +430,24 @@ func (n *Node) tryFinalizeResultsLocked(pool *commitment.Pool, didTimeout bool)
 
 	if epoch.IsMergeBackupWorker() && state.pendingEvent == nil {
 		// Backup workers only perform merge after receiving a discrepancy event.
-		n.transitionLocked(StateWaitingForEvent{commitments: commitments, results: state.results})
+		n.transitionLocked(StateWaitingForEvent{
+			commitments:  commitments,
+			results:      state.results,
+			batchSpanCtx: *state.batchSpanCtx,
+		})
 		return
 	}
 
 	// No discrepancy, perform merge.
-	n.startMergeLocked(commitments, state.results)
+	n.startMergeLocked(*state.batchSpanCtx, commitments, state.results)
 }
 
 // Guarded by n.commonNode.CrossNode.
-func (n *Node) startMergeLocked(commitments []commitment.ExecutorCommitment, results []*commitment.ComputeResultsHeader) {
+func (n *Node) startMergeLocked(
+	batchSpanCtx opentracing.SpanContext,
+	commitments []commitment.ExecutorCommitment,
+	results []*commitment.ComputeResultsHeader,
+) {
 	doneCh := make(chan *commitment.MergeBody, 1)
 	ctx, cancel := context.WithCancel(n.roundCtx)
 
@@ -430,7 +457,7 @@ func (n *Node) startMergeLocked(commitments []commitment.ExecutorCommitment, res
 	prevBlk := n.commonNode.CurrentBlock
 	blk := block.NewEmptyBlock(prevBlk, 0, block.Normal)
 
-	n.transitionLocked(StateProcessingMerge{doneCh: doneCh, cancel: cancel})
+	n.transitionLocked(StateProcessingMerge{doneCh: doneCh, cancel: cancel, batchSpanCtx: batchSpanCtx})
 
 	// Start processing merge in a separate goroutine. This is to make it possible
 	// to abort the merge if a newer block is seen while we are merging.
@@ -475,11 +502,15 @@ func (n *Node) startMergeLocked(commitments []commitment.ExecutorCommitment, res
 			},
 		}
 
+		span, ctx := tracing.StartSpanWithContext(ctx, "MergeBatch(ioRoots, stateRoots)",
+			opentracing.ChildOf(batchSpanCtx),
+		)
 		receipts, err := n.commonNode.Storage.MergeBatch(ctx, &storage.MergeBatchRequest{
 			Namespace: prevBlk.Header.Namespace,
 			Round:     prevBlk.Header.Round,
 			Ops:       mergeOps,
 		})
+		span.Finish()
 		if err != nil {
 			n.logger.Error("failed to merge",
 				"err", err,
@@ -541,7 +572,7 @@ func (n *Node) startMergeLocked(commitments []commitment.ExecutorCommitment, res
 }
 
 // Guarded by n.commonNode.CrossNode.
-func (n *Node) proposeHeaderLocked(result *commitment.MergeBody) {
+func (n *Node) proposeHeaderLocked(batchSpanCtx opentracing.SpanContext, result *commitment.MergeBody) {
 	n.logger.Debug("proposing header",
 		"previous_hash", result.Header.PreviousHash,
 		"round", result.Header.Round,
@@ -559,16 +590,17 @@ func (n *Node) proposeHeaderLocked(result *commitment.MergeBody) {
 
 	n.transitionLocked(StateWaitingForFinalize{})
 
-	// TODO: Tracing.
-	// span := opentracing.StartSpan("roothash.MergeCommit", opentracing.ChildOf(state.batchSpanCtx))
-	// defer span.Finish()
-
 	// Submit merge commit to consensus.
 	mcs := []commitment.MergeCommitment{*mc}
 	mergeCommitStart := time.Now()
 	go func() {
+		span, ctx := tracing.StartSpanWithContext(n.roundCtx, "roothash.MergeCommit",
+			opentracing.ChildOf(batchSpanCtx),
+		)
+		defer span.Finish()
+
 		tx := roothash.NewMergeCommitTx(0, nil, n.commonNode.Runtime.ID(), mcs)
-		mcErr := consensus.SignAndSubmitTx(n.roundCtx, n.commonNode.Consensus, n.commonNode.Identity.NodeSigner, tx)
+		mcErr := consensus.SignAndSubmitTx(ctx, n.commonNode.Consensus, n.commonNode.Identity.NodeSigner, tx)
 		// Record merge commit latency.
 		roothashCommitLatency.With(n.getMetricLabels()).Observe(time.Since(mergeCommitStart).Seconds())
 
@@ -649,7 +681,7 @@ func (n *Node) handleMergeDiscrepancyLocked(ev *roothash.MergeDiscrepancyDetecte
 
 	// Backup worker, start processing merge.
 	n.logger.Info("backup worker activating and processing merge")
-	n.startMergeLocked(state.commitments, state.results)
+	n.startMergeLocked(state.batchSpanCtx, state.commitments, state.results)
 }
 
 // Guarded by n.commonNode.CrossNode.
@@ -747,7 +779,8 @@ func (n *Node) worker() {
 				n.commonNode.CrossNode.Lock()
 				defer n.commonNode.CrossNode.Unlock()
 
-				if state, ok := n.state.(StateProcessingMerge); !ok || state.doneCh != mergeDoneCh {
+				state, ok := n.state.(StateProcessingMerge)
+				if !ok || state.doneCh != mergeDoneCh {
 					return
 				}
 
@@ -756,7 +789,7 @@ func (n *Node) worker() {
 					n.abortMergeLocked(errMergeFailed)
 				} else {
 					n.logger.Info("merge completed, proposing header")
-					n.proposeHeaderLocked(result)
+					n.proposeHeaderLocked(state.batchSpanCtx, result)
 				}
 			}()
 		case <-n.reselect:
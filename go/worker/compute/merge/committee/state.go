@@ -4,6 +4,8 @@ import (
 	"context"
 	"time"
 
+	"github.com/opentracing/opentracing-go"
+
 	"github.com/oasislabs/oasis-core/go/common/crypto/hash"
 	roothash "github.com/oasislabs/oasis-core/go/roothash/api"
 	"github.com/oasislabs/oasis-core/go/roothash/api/commitment"
@@ -101,6 +103,9 @@ type StateWaitingForResults struct {
 	// Pending merge discrepancy detected event in case the node is a
 	// backup worker and the event was received before the results.
 	pendingEvent *roothash.MergeDiscrepancyDetectedEvent
+	// Span context of the batch the results are for, set when the first
+	// commitment of the round is received.
+	batchSpanCtx *opentracing.SpanContext
 }
 
 // Name returns the name of the state.
@@ -115,8 +120,9 @@ func (s StateWaitingForResults) String() string {
 
 // StateWaitingForEvent is the waiting for event state.
 type StateWaitingForEvent struct {
-	commitments []commitment.ExecutorCommitment
-	results     []*commitment.ComputeResultsHeader
+	commitments  []commitment.ExecutorCommitment
+	results      []*commitment.ComputeResultsHeader
+	batchSpanCtx opentracing.SpanContext
 }
 
 // Name returns the name of the state.
@@ -131,8 +137,9 @@ func (s StateWaitingForEvent) String() string {
 
 // StateProcessingMerge is the processing merge state.
 type StateProcessingMerge struct {
-	doneCh <-chan *commitment.MergeBody
-	cancel context.CancelFunc
+	doneCh       <-chan *commitment.MergeBody
+	cancel       context.CancelFunc
+	batchSpanCtx opentracing.SpanContext
 }
 
 // Name returns the name of the state.
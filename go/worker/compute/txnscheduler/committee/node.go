@@ -41,6 +41,10 @@ var (
 	errNoBlocks       = errors.New("no blocks")
 )
 
+// maxTxSpans is the maximum number of queued transactions for which span
+// contexts are retained to be linked to the batch span.
+const maxTxSpans = 10000
+
 var (
 	incomingQueueSize = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -75,6 +79,11 @@ type Node struct { // nolint: maligned
 	algorithmMutex sync.RWMutex
 	algorithm      txnSchedulerAlgorithmApi.Algorithm
 
+	// Span contexts of the calls that submitted queued transactions, used
+	// to link transaction traces to the trace of the batch.
+	txSpansLock sync.Mutex
+	txSpans     map[hash.Hash]opentracing.SpanContext
+
 	ctx       context.Context
 	cancelCtx context.CancelFunc
 	stopCh    chan struct{}
@@ -239,6 +248,16 @@ func (n *Node) QueueCall(ctx context.Context, expectedEpochNumber epochtime.Epoc
 	if err := n.algorithm.ScheduleTx(call); err != nil {
 		return err
 	}
+	if span := opentracing.SpanFromContext(ctx); span != nil {
+		n.txSpansLock.Lock()
+		if len(n.txSpans) >= maxTxSpans {
+			n.pruneTxSpansLocked()
+		}
+		if len(n.txSpans) < maxTxSpans {
+			n.txSpans[hash.NewFromBytes(call)] = span.Context()
+		}
+		n.txSpansLock.Unlock()
+	}
 
 	incomingQueueSize.With(n.getMetricLabels()).Set(float64(n.algorithm.UnscheduledSize()))
 
@@ -313,6 +332,7 @@ func (n *Node) HandleEpochTransitionLocked(epoch *committee.EpochSnapshot) {
 		n.transitionLocked(StateWaitingForBatch{})
 	} else {
 		n.algorithm.Clear()
+		n.clearTxSpans()
 		// Clear incoming queue if we are not a leader.
 		incomingQueueSize.With(n.getMetricLabels()).Set(0)
 		n.transitionLocked(StateNotReady{})
@@ -350,6 +370,42 @@ func (n *Node) HandleNodeUpdateLocked(update *runtimeCommittee.NodeUpdate, snaps
 	// Nothing to do here.
 }
 
+// takeTxSpanReferences removes the span contexts of the given transactions
+// and returns references to them for use when starting the batch span.
+func (n *Node) takeTxSpanReferences(batch transaction.RawBatch) []opentracing.StartSpanOption {
+	n.txSpansLock.Lock()
+	defer n.txSpansLock.Unlock()
+
+	var refs []opentracing.StartSpanOption
+	for _, tx := range batch {
+		txHash := hash.NewFromBytes(tx)
+		if sc, ok := n.txSpans[txHash]; ok {
+			refs = append(refs, opentracing.FollowsFrom(sc))
+			delete(n.txSpans, txHash)
+		}
+	}
+	return refs
+}
+
+// pruneTxSpansLocked removes the span contexts of transactions that are no
+// longer queued (e.g., because they have been dropped from the queue).
+//
+// Guarded by n.txSpansLock and n.algorithmMutex (read).
+func (n *Node) pruneTxSpansLocked() {
+	for txHash := range n.txSpans {
+		if !n.algorithm.IsQueued(txHash) {
+			delete(n.txSpans, txHash)
+		}
+	}
+}
+
+func (n *Node) clearTxSpans() {
+	n.txSpansLock.Lock()
+	defer n.txSpansLock.Unlock()
+
+	n.txSpans = make(map[hash.Hash]opentracing.SpanContext)
+}
+
 // Dispatch dispatches a batch to the executor committee.
 func (n *Node) Dispatch(committeeID hash.Hash, batch transaction.RawBatch) error {
 	n.commonNode.CrossNode.Lock()
@@ -371,8 +427,9 @@ func (n *Node) Dispatch(committeeID hash.Hash, batch transaction.RawBatch) error
 
 	lastHeader := n.commonNode.CurrentBlock.Header
 
-	// Leader node opens a new parent span for batch processing.
-	batchSpan := opentracing.StartSpan("TakeBatchFromQueue(batch)")
+	// Leader node opens a new parent span for batch processing, linked to
+	// the traces of the calls that submitted the transactions.
+	batchSpan := opentracing.StartSpan("TakeBatchFromQueue(batch)", n.takeTxSpanReferences(batch)...)
 	defer batchSpan.Finish()
 	batchSpanCtx := batchSpan.Context()
 
@@ -618,6 +675,7 @@ func NewNode(
 		commonNode:       commonNode,
 		executorNode:     executorNode,
 		roleProvider:     roleProvider,
+		txSpans:          make(map[hash.Hash]opentracing.SpanContext),
 		ctx:              ctx,
 		cancelCtx:        cancel,
 		stopCh:           make(chan struct{}),
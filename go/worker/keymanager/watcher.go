@@ -46,6 +46,9 @@ func (knw *kmNodeWatcher) watchNodes() {
 	}
 	defer watcherSub.Close()
 
+	sentryCh, sentrySub := knw.w.commonWorker.WatchSentryAddresses()
+	defer sentrySub.Close()
+
 	var activeNodes map[signature.PublicKey]bool
 	for {
 		select {
@@ -67,6 +70,8 @@ func (knw *kmNodeWatcher) watchNodes() {
 			if !activeNodes[watcherEv.Update.ID] {
 				continue
 			}
+		case <-sentryCh:
+			// The sentry addresses have changed.
 		case <-knw.w.stopCh:
 			return
 		}
@@ -19,6 +19,7 @@ import (
 	"github.com/oasislabs/oasis-core/go/common/node"
 	"github.com/oasislabs/oasis-core/go/common/pubsub"
 	"github.com/oasislabs/oasis-core/go/common/service"
+	control "github.com/oasislabs/oasis-core/go/control/api"
 	"github.com/oasislabs/oasis-core/go/keymanager/api"
 	registry "github.com/oasislabs/oasis-core/go/registry/api"
 	roothash "github.com/oasislabs/oasis-core/go/roothash/api"
@@ -123,6 +124,24 @@ func (w *Worker) Quit() <-chan struct{} {
 func (w *Worker) Cleanup() {
 }
 
+// GetStatus returns the key manager worker status.
+func (w *Worker) GetStatus(ctx context.Context) (*control.KeymanagerStatus, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	status := &control.KeymanagerStatus{
+		RuntimeID:   w.runtime.ID(),
+		MayGenerate: w.mayGenerate,
+	}
+	if w.enclaveStatus != nil {
+		status.Initialized = true
+		status.IsSecure = w.enclaveStatus.InitResponse.IsSecure
+		status.Checksum = w.enclaveStatus.InitResponse.Checksum
+		status.PolicyChecksum = w.enclaveStatus.InitResponse.PolicyChecksum
+	}
+	return status, nil
+}
+
 // Implements workerCommon.RuntimeHostHandlerFactory.
 func (w *Worker) GetRuntime() runtimeRegistry.Runtime {
 	return w.runtime
@@ -390,6 +409,11 @@ func (w *Worker) worker() { // nolint: gocyclo
 	}
 	defer rtSub.Close()
 
+	// Subscribe to sentry address updates in order to refresh the client
+	// runtime access policies.
+	sentryCh, sentrySub := w.commonWorker.WatchSentryAddresses()
+	defer sentrySub.Close()
+
 	var (
 		hrtEventCh          <-chan *host.Event
 		currentStatus       *api.Status
@@ -547,6 +571,10 @@ func (w *Worker) worker() { // nolint: gocyclo
 			}()
 
 			clientRuntimes[rt.ID] = crw
+		case <-sentryCh:
+			for _, crw := range clientRuntimes {
+				crw.refreshExternalServicePolicy()
+			}
 		case crw := <-clientRuntimesQuitCh:
 			w.logger.Error("client runtime watcher quit unexpectedly, terminating",
 				"runtme_id", crw.node.Runtime.ID(),
@@ -589,6 +617,18 @@ func (crw *clientRuntimeWatcher) updateExternalServicePolicyLocked(snapshot *com
 	crw.w.logger.Debug("worker/keymanager: new normal runtime access policy in effect", "policy", policy)
 }
 
+func (crw *clientRuntimeWatcher) refreshExternalServicePolicy() {
+	crw.node.CrossNode.Lock()
+	defer crw.node.CrossNode.Unlock()
+
+	snapshot := crw.node.Group.GetEpochSnapshot()
+	if snapshot.GetRuntime() == nil {
+		// The policy will be set on the first epoch transition.
+		return
+	}
+	crw.updateExternalServicePolicyLocked(snapshot)
+}
+
 // Guarded by CrossNode.
 func (crw *clientRuntimeWatcher) HandleEpochTransitionLocked(snapshot *committeeCommon.EpochSnapshot) {
 	crw.updateExternalServicePolicyLocked(snapshot)
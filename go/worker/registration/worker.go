@@ -22,6 +22,7 @@ import (
 	"github.com/oasislabs/oasis-core/go/common/node"
 	"github.com/oasislabs/oasis-core/go/common/persistent"
 	consensus "github.com/oasislabs/oasis-core/go/consensus/api"
+	control "github.com/oasislabs/oasis-core/go/control/api"
 	epochtime "github.com/oasislabs/oasis-core/go/epochtime/api"
 	"github.com/oasislabs/oasis-core/go/oasis-node/cmd/common/flags"
 	registry "github.com/oasislabs/oasis-core/go/registry/api"
@@ -44,6 +45,9 @@ const (
 	// CfgRegistrationRotateCerts sets the number of epochs that a node's TLS
 	// certificate should be valid for.
 	CfgRegistrationRotateCerts = "worker.registration.rotate_certs"
+	// CfgRegistrationDeregisterOnShutdown enables submitting an explicit
+	// node deregistration transaction on graceful shutdown.
+	CfgRegistrationDeregisterOnShutdown = "worker.registration.deregister_on_shutdown"
 )
 
 var (
@@ -151,6 +155,8 @@ type Worker struct { // nolint: maligned
 
 	roleProviders []*roleProvider
 	registerCh    chan struct{}
+
+	status *control.RegistrationStatus
 }
 
 // DebugForceallowUnroutableAddresses allows unroutable addresses.
@@ -158,35 +164,47 @@ func DebugForceAllowUnroutableAddresses() {
 	allowUnroutableAddresses = true
 }
 
-func (w *Worker) registrationLoop() { // nolint: gocyclo
-	// If we have any sentry nodes, let them know about our TLS certs.
-	if len(w.sentryAddresses) > 0 {
-		pubKeys := w.identity.GetTLSPubKeys()
-		for _, sentryAddr := range w.sentryAddresses {
-			pushCerts := func() error {
-				client, err := sentryClient.New(sentryAddr, w.identity)
-				if err != nil {
-					return err
-				}
-				defer client.Close()
+// pushTLSCertificates lets the sentry nodes that have not been notified yet
+// know about our TLS certificates. The set of notified sentry nodes is
+// updated accordingly.
+func (w *Worker) pushTLSCertificates(notified map[string]bool) {
+	pubKeys := w.identity.GetTLSPubKeys()
+	for _, sentryAddr := range w.getSentryAddresses() {
+		if notified[sentryAddr.String()] {
+			continue
+		}
 
-				err = client.SetUpstreamTLSPubKeys(w.ctx, pubKeys)
-				if err != nil {
-					return err
-				}
-				return nil
+		pushCerts := func() error {
+			client, err := sentryClient.New(sentryAddr, w.identity)
+			if err != nil {
+				return err
 			}
+			defer client.Close()
 
-			sched := backoff.WithMaxRetries(backoff.NewConstantBackOff(1*time.Second), 60)
-			err := backoff.Retry(pushCerts, backoff.WithContext(sched, w.ctx))
+			err = client.SetUpstreamTLSPubKeys(w.ctx, pubKeys)
 			if err != nil {
-				w.logger.Error("unable to push upstream TLS certificates to sentry node",
-					"err", err,
-					"sentry_address", sentryAddr,
-				)
+				return err
 			}
+			return nil
+		}
+
+		sched := backoff.WithMaxRetries(backoff.NewConstantBackOff(1*time.Second), 60)
+		err := backoff.Retry(pushCerts, backoff.WithContext(sched, w.ctx))
+		if err != nil {
+			w.logger.Error("unable to push upstream TLS certificates to sentry node",
+				"err", err,
+				"sentry_address", sentryAddr,
+			)
+			continue
 		}
+		notified[sentryAddr.String()] = true
 	}
+}
+
+func (w *Worker) registrationLoop() { // nolint: gocyclo
+	// If we have any sentry nodes, let them know about our TLS certs.
+	notifiedSentries := make(map[string]bool)
+	w.pushTLSCertificates(notifiedSentries)
 
 	// Delay node registration till after the consensus service has
 	// finished initial synchronization if applicable.
@@ -289,7 +307,10 @@ Loop:
 				}
 			}
 		case <-w.registerCh:
-			// Notification that a role provider has been updated.
+			// Notification that a role provider or the sentry addresses
+			// have been updated. Make sure that any newly added sentry
+			// nodes know about our TLS certs.
+			w.pushTLSCertificates(notifiedSentries)
 		}
 
 		// If there are any role providers which are still not ready, we must wait for more
@@ -392,6 +413,16 @@ func (w *Worker) doNodeRegistration() {
 		return
 	}
 
+	// Explicitly deregister the node if configured, otherwise wait for the
+	// node registration to expire.
+	if viper.GetBool(CfgRegistrationDeregisterOnShutdown) {
+		if err = w.deregisterNode(); err != nil {
+			w.logger.Error("failed to explicitly deregister node, waiting for expiration",
+				"err", err,
+			)
+		}
+	}
+
 	w.logger.Info("waiting for node to deregister")
 	for {
 		select {
@@ -421,6 +452,24 @@ func (w *Worker) InitialRegistrationCh() chan struct{} {
 	return w.initialRegCh
 }
 
+// WillNeverRegister returns true iff the worker will never attempt to
+// register the node as there is no entity or registration signer configured.
+func (w *Worker) WillNeverRegister() bool {
+	return !w.entityID.IsValid() || w.registrationSigner == nil
+}
+
+// GetRegistrationStatus returns the node's current registration status.
+func (w *Worker) GetRegistrationStatus(ctx context.Context) (*control.RegistrationStatus, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	status := new(control.RegistrationStatus)
+	if w.status != nil {
+		*status = *w.status
+	}
+	return status, nil
+}
+
 // NewRoleProvider creates a new role provider slot.
 //
 // Each part of the code that wishes to contribute something to the node descriptor can use this
@@ -465,7 +514,7 @@ func (w *Worker) gatherConsensusAddresses(sentryConsensusAddrs []node.ConsensusA
 	var consensusAddrs []node.ConsensusAddress
 	var err error
 
-	switch len(w.sentryAddresses) > 0 {
+	switch len(w.getSentryAddresses()) > 0 {
 	// If sentry nodes are used, use sentry addresses.
 	case true:
 		consensusAddrs = sentryConsensusAddrs
@@ -506,7 +555,7 @@ func (w *Worker) gatherConsensusAddresses(sentryConsensusAddrs []node.ConsensusA
 func (w *Worker) gatherTLSAddresses(sentryTLSAddrs []node.TLSAddress) ([]node.TLSAddress, error) {
 	var tlsAddresses []node.TLSAddress
 
-	switch len(w.sentryAddresses) > 0 {
+	switch len(w.getSentryAddresses()) > 0 {
 	// If sentry nodes are used, use sentry addresses.
 	case true:
 		tlsAddresses = sentryTLSAddrs
@@ -603,8 +652,8 @@ func (w *Worker) registerNode(epoch epochtime.EpochTime, hook RegisterNodeHook)
 
 	var sentryConsensusAddrs []node.ConsensusAddress
 	var sentryTLSAddrs []node.TLSAddress
-	if len(w.sentryAddresses) > 0 {
-		sentryConsensusAddrs, sentryTLSAddrs = w.querySentries()
+	if sentryAddrs := w.getSentryAddresses(); len(sentryAddrs) > 0 {
+		sentryConsensusAddrs, sentryTLSAddrs = w.querySentries(sentryAddrs)
 	}
 
 	// Add Consensus Addresses if required.
@@ -659,17 +708,38 @@ func (w *Worker) registerNode(epoch epochtime.EpochTime, hook RegisterNodeHook)
 		return err
 	}
 
+	w.Lock()
+	w.status = &control.RegistrationStatus{
+		LastRegistration: time.Now(),
+		Descriptor:       &nodeDesc,
+	}
+	w.Unlock()
+
 	w.logger.Info("node registered with the registry")
 	return nil
 }
 
-func (w *Worker) querySentries() ([]node.ConsensusAddress, []node.TLSAddress) {
+func (w *Worker) deregisterNode() error {
+	w.logger.Info("performing node deregistration")
+
+	tx := registry.NewDeregisterNodeTx(0, nil, &registry.DeregisterNode{
+		NodeID: w.identity.NodeSigner.Public(),
+	})
+	if err := consensus.SignAndSubmitTx(w.ctx, w.consensus, w.registrationSigner, tx); err != nil {
+		return err
+	}
+
+	w.logger.Info("node deregistration submitted, node will not be scheduled from the next epoch")
+	return nil
+}
+
+func (w *Worker) querySentries(sentryAddrs []node.TLSAddress) ([]node.ConsensusAddress, []node.TLSAddress) {
 	var consensusAddrs []node.ConsensusAddress
 	var tlsAddrs []node.TLSAddress
 	var err error
 
 	pubKeys := w.identity.GetTLSPubKeys()
-	for _, sentryAddr := range w.sentryAddresses {
+	for _, sentryAddr := range sentryAddrs {
 		var client *sentryClient.Client
 		client, err = sentryClient.New(sentryAddr, w.identity)
 		if err != nil {
@@ -706,18 +776,41 @@ func (w *Worker) querySentries() ([]node.ConsensusAddress, []node.TLSAddress) {
 
 	if len(consensusAddrs) == 0 {
 		w.logger.Error("failed to obtain any consensus address from the configured sentry nodes",
-			"sentry_addresses", w.sentryAddresses,
+			"sentry_addresses", sentryAddrs,
 		)
 	}
 	if len(tlsAddrs) == 0 {
 		w.logger.Error("failed to obtain any TLS address from the configured sentry nodes",
-			"sentry_addresses", w.sentryAddresses,
+			"sentry_addresses", sentryAddrs,
 		)
 	}
 
 	return consensusAddrs, tlsAddrs
 }
 
+func (w *Worker) getSentryAddresses() []node.TLSAddress {
+	w.RLock()
+	defer w.RUnlock()
+
+	return w.sentryAddresses
+}
+
+// SetSentryAddresses changes the addresses of the sentry nodes and triggers
+// a node re-registration so that the node descriptor advertises the
+// addresses provided by the new sentry nodes. Any newly added sentry nodes
+// are provided with the node's TLS certificates before re-registering.
+func (w *Worker) SetSentryAddresses(addrs []node.TLSAddress) {
+	w.Lock()
+	w.sentryAddresses = addrs
+	w.Unlock()
+
+	select {
+	case w.registerCh <- struct{}{}:
+	default:
+		// A re-registration is already pending.
+	}
+}
+
 // RequestDeregistration requests that the node not register itself in the next epoch.
 func (w *Worker) RequestDeregistration() error {
 	if !atomic.CompareAndSwapUint32(&w.deregRequested, 0, 1) {
@@ -908,7 +1001,7 @@ func (w *Worker) Start() error {
 	w.logger.Info("starting node registration service")
 
 	// HACK: This can be ok in certain configurations.
-	if !w.entityID.IsValid() || w.registrationSigner == nil {
+	if w.WillNeverRegister() {
 		w.logger.Warn("no entity/signer for this node, registration will NEVER succeed")
 		// Make sure the node is stopped on quit.
 		go func() {
@@ -949,6 +1042,7 @@ func init() {
 	Flags.String(CfgDebugRegistrationPrivateKey, "", "private key to use to sign node registrations")
 	Flags.Bool(CfgRegistrationForceRegister, false, "override a previously saved deregistration request")
 	Flags.Uint64(CfgRegistrationRotateCerts, 0, "rotate node TLS certificates every N epochs (0 to disable)")
+	Flags.Bool(CfgRegistrationDeregisterOnShutdown, false, "explicitly deregister the node on graceful shutdown")
 	_ = Flags.MarkHidden(CfgDebugRegistrationPrivateKey)
 
 	_ = viper.BindPFlags(Flags)
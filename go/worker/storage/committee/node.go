@@ -345,6 +345,23 @@ func (n *Node) updateExternalServicePolicyLocked(snapshot *committee.EpochSnapsh
 	n.logger.Debug("set new storage gRPC access policy", "policy", policy)
 }
 
+// UpdateSentryAddresses updates the addresses of the sentry nodes that
+// are allowed to access the storage gRPC interface and refreshes the access
+// policy accordingly.
+func (n *Node) UpdateSentryAddresses(addrs []node.TLSAddress) {
+	n.commonNode.CrossNode.Lock()
+	defer n.commonNode.CrossNode.Unlock()
+
+	n.workerCommonCfg.SentryAddresses = addrs
+
+	snapshot := n.commonNode.Group.GetEpochSnapshot()
+	if snapshot.GetRuntime() == nil {
+		// The policy will be set on the first epoch transition.
+		return
+	}
+	n.updateExternalServicePolicyLocked(snapshot)
+}
+
 func (n *Node) HandlePeerMessage(context.Context, *p2p.Message) (bool, error) {
 	// Nothing to do here.
 	return false, nil
@@ -410,6 +427,35 @@ func (n *Node) ForceFinalize(ctx context.Context, round uint64) error {
 	})
 }
 
+func (n *Node) getDiff(prevRoot *mkvsNode.Root, thisRoot *mkvsNode.Root) (storageApi.WriteLog, error) {
+	it, err := n.storageClient.GetDiff(n.ctx, &storageApi.GetDiffRequest{
+		StartRoot:  *prevRoot,
+		EndRoot:    *thisRoot,
+		WithProofs: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var writeLog storageApi.WriteLog
+	for {
+		more, err := it.Next()
+		if err != nil {
+			return nil, err
+		}
+		if !more {
+			break
+		}
+
+		chunk, err := it.Value()
+		if err != nil {
+			return nil, err
+		}
+		writeLog = append(writeLog, chunk)
+	}
+	return writeLog, nil
+}
+
 func (n *Node) fetchDiff(round uint64, prevRoot *mkvsNode.Root, thisRoot *mkvsNode.Root, fetchMask outstandingMask) {
 	result := &fetchedDiff{
 		fetchMask: fetchMask,
@@ -439,28 +485,10 @@ func (n *Node) fetchDiff(round uint64, prevRoot *mkvsNode.Root, thisRoot *mkvsNo
 				"fetch_mask", fetchMask,
 			)
 
-			it, err := n.storageClient.GetDiff(n.ctx, &storageApi.GetDiffRequest{StartRoot: *prevRoot, EndRoot: *thisRoot})
-			if err != nil {
-				result.err = err
-				return
-			}
-			for {
-				more, err := it.Next()
-				if err != nil {
-					result.err = err
-					return
-				}
-				if !more {
-					break
-				}
-
-				chunk, err := it.Value()
-				if err != nil {
-					result.err = err
-					return
-				}
-				result.writeLog = append(result.writeLog, chunk)
-			}
+			// Diff proofs are always required so that each write log chunk is verified as
+			// soon as it arrives. A node which fails to provide valid proofs is reported to
+			// the node selection policy so that the retried diff is fetched from another node.
+			result.writeLog, result.err = n.getDiff(prevRoot, thisRoot)
 		}
 	}
 }
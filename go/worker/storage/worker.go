@@ -124,6 +124,14 @@ func New(
 	return s, nil
 }
 
+// GetRuntime returns a registered runtime.
+//
+// In case the runtime with the specified id was not registered it
+// returns nil.
+func (s *Worker) GetRuntime(id common.Namespace) *committee.Node {
+	return s.runtimes[id]
+}
+
 func (s *Worker) registerRuntime(commonNode *committeeCommon.Node, checkpointerCfg checkpoint.CheckpointerConfig) error {
 	id := commonNode.Runtime.ID()
 	s.logger.Info("registering new runtime",
@@ -207,9 +215,28 @@ func (s *Worker) Start() error {
 		close(s.initCh)
 	}()
 
+	// Refresh the access policies when the sentry addresses change.
+	go s.watchSentryAddresses()
+
 	return nil
 }
 
+func (s *Worker) watchSentryAddresses() {
+	ch, sub := s.commonWorker.WatchSentryAddresses()
+	defer sub.Close()
+
+	for {
+		select {
+		case addrs := <-ch:
+			for _, r := range s.runtimes {
+				r.UpdateSentryAddresses(addrs)
+			}
+		case <-s.quitCh:
+			return
+		}
+	}
+}
+
 // Stop halts the service.
 func (s *Worker) Stop() {
 	if !s.enabled {